@@ -19,26 +19,248 @@ package v1alpha1
 import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 )
 
+// SopsSecretFormat describes the layout of the decrypted document.
+type SopsSecretFormat string
+
+const (
+	// FormatFlat uses every top-level key of the decrypted document as a Secret entry.
+	FormatFlat SopsSecretFormat = "flat"
+
+	// FormatCRD reads the entries from the data and stringData fields of a
+	// decrypted Kubernetes Secret manifest.
+	FormatCRD SopsSecretFormat = "crd"
+)
+
+// ComplexValueFormat is the encoding of nested maps and lists in Secret values.
+type ComplexValueFormat string
+
+const (
+	// ComplexValueYAML keeps nested values as YAML.
+	ComplexValueYAML ComplexValueFormat = "yaml"
+
+	// ComplexValueJSON encodes nested values as JSON with sorted keys.
+	ComplexValueJSON ComplexValueFormat = "json"
+)
+
+// KeyType is the expected content of a decrypted value.
+// +kubebuilder:validation:Enum=json
+type KeyType string
+
+const (
+	// KeyTypeJSON requires the value to be well-formed JSON.
+	KeyTypeJSON KeyType = "json"
+)
+
+// SourceDeletionPolicy decides what happens to the managed Secret when the
+// source of the encrypted document disappears.
+type SourceDeletionPolicy string
+
+const (
+	// SourceDeletionRetain keeps the last written Secret.
+	SourceDeletionRetain SourceDeletionPolicy = "Retain"
+
+	// SourceDeletionDelete removes the managed Secret.
+	SourceDeletionDelete SourceDeletionPolicy = "Delete"
+)
+
+// OwnerReferenceMode decides how the managed Secret references its SopsSecret.
+type OwnerReferenceMode string
+
+const (
+	// OwnerReferenceController sets a controller reference. The Secret is
+	// deleted with the SopsSecret.
+	OwnerReferenceController OwnerReferenceMode = "Controller"
+
+	// OwnerReferenceNonController sets an owner reference that is not a
+	// controller reference, so tools can show the relationship. The reference
+	// is removed and the Secret retained when the SopsSecret is deleted.
+	OwnerReferenceNonController OwnerReferenceMode = "NonController"
+
+	// OwnerReferenceNone sets no owner reference. The Secret is retained when
+	// the SopsSecret is deleted.
+	OwnerReferenceNone OwnerReferenceMode = "None"
+)
+
+// ConfigMapChunkRef selects one chunk of an encrypted document split across
+// ConfigMaps.
+type ConfigMapChunkRef struct {
+	// name of the ConfigMap in the namespace of the SopsSecret.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// key in the ConfigMap holding the chunk, in data or binaryData.
+	// +kubebuilder:validation:MinLength=1
+	Key string `json:"key"`
+
+	// index is the position of the chunk in the document, starting at 0.
+	// +kubebuilder:validation:Minimum=0
+	Index int32 `json:"index"`
+}
+
+// SchemaRef selects a JSON schema stored in a ConfigMap.
+type SchemaRef struct {
+	// name of the ConfigMap in the namespace of the SopsSecret.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// key in the ConfigMap holding the schema. Defaults to schema.json.
+	// +optional
+	Key string `json:"key,omitempty"`
+}
+
+// VaultSink writes the decrypted values to a HashiCorp Vault KV version 2
+// secret next to the Kubernetes Secret.
+type VaultSink struct {
+	// address of the Vault server, such as https://vault.example.com:8200.
+	// It must be allowed by the --vault-sink-addresses flag of the operator.
+	// +kubebuilder:validation:MinLength=1
+	Address string `json:"address"`
+
+	// mount is the path the KV version 2 engine is mounted at. Defaults to
+	// secret.
+	// +optional
+	Mount string `json:"mount,omitempty"`
+
+	// path of the secret within the mount.
+	// +kubebuilder:validation:MinLength=1
+	Path string `json:"path"`
+
+	// auth selects how the operator logs in to Vault.
+	Auth VaultAuth `json:"auth"`
+
+	// deleteOnCleanup deletes the Vault secret with all its versions when the
+	// SopsSecret is deleted.
+	// +optional
+	DeleteOnCleanup bool `json:"deleteOnCleanup,omitempty"`
+}
+
+// VaultAuth selects how the operator logs in to Vault. Exactly one method
+// must be set.
+// +kubebuilder:validation:XValidation:rule="has(self.tokenSecretRef) != has(self.kubernetes)",message="exactly one of tokenSecretRef or kubernetes must be set"
+type VaultAuth struct {
+	// tokenSecretRef selects a Secret in the namespace of the SopsSecret
+	// holding a Vault token.
+	// +optional
+	TokenSecretRef *VaultTokenSecretRef `json:"tokenSecretRef,omitempty"`
+
+	// kubernetes logs in with the service account token of the operator
+	// through the Kubernetes auth method. path must then start with the
+	// namespace of the SopsSecret.
+	// +optional
+	Kubernetes *VaultKubernetesAuth `json:"kubernetes,omitempty"`
+}
+
+// VaultTokenSecretRef selects a Vault token stored in a Secret.
+type VaultTokenSecretRef struct {
+	// name of the Secret in the namespace of the SopsSecret.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// key in the Secret holding the token. Defaults to token.
+	// +optional
+	Key string `json:"key,omitempty"`
+}
+
+// VaultKubernetesAuth logs in through the Vault Kubernetes auth method.
+type VaultKubernetesAuth struct {
+	// role to log in as.
+	// +kubebuilder:validation:MinLength=1
+	Role string `json:"role"`
+
+	// mountPath is the path the auth method is mounted at. Defaults to
+	// kubernetes.
+	// +optional
+	MountPath string `json:"mountPath,omitempty"`
+}
+
 // SopsSecretSpec defines the desired state of SopsSecret
+// +kubebuilder:validation:XValidation:rule="[has(self.sopsSecret), has(self.encryptedFromFile), has(self.encryptedFromChunks)].filter(x, x).size() == 1",message="exactly one of sopsSecret, encryptedFromFile or encryptedFromChunks must be set"
+// +kubebuilder:validation:XValidation:rule="has(self.targetNamespace) == has(oldSelf.targetNamespace) && (!has(self.targetNamespace) || self.targetNamespace == oldSelf.targetNamespace)",message="targetNamespace cannot be changed"
 type SopsSecretSpec struct {
 	// sopsSecret contains the full SOPS-encrypted YAML including MAC and metadata.
 	// This is the raw output from `sops -e secret.yaml`.
-	// +kubebuilder:validation:Required
-	SopsSecret string `json:"sopsSecret"`
+	// Exactly one of sopsSecret, encryptedFromFile or encryptedFromChunks must be set.
+	// +optional
+	SopsSecret string `json:"sopsSecret,omitempty"`
+
+	// encryptedFromFile is an absolute path on the operator's filesystem to read the
+	// SOPS-encrypted YAML from, e.g. a volume populated by an init container.
+	// The path must be inside a directory allowed by the operator's --encrypted-file-dirs flag.
+	// +optional
+	EncryptedFromFile string `json:"encryptedFromFile,omitempty"`
+
+	// encryptedFromChunks assembles the SOPS-encrypted YAML from ConfigMap
+	// chunks, concatenated by index, for documents larger than a single object
+	// may be. The indexes must run from 0 without gaps or duplicates.
+	// +kubebuilder:validation:MinItems=1
+	// +optional
+	EncryptedFromChunks []ConfigMapChunkRef `json:"encryptedFromChunks,omitempty"`
+
+	// sourceDeletionPolicy decides what happens to the managed Secret when the
+	// encryptedFromFile source no longer exists. Retain keeps the last written
+	// Secret, Delete removes it. Defaults to Retain.
+	// +kubebuilder:validation:Enum=Retain;Delete
+	// +kubebuilder:default=Retain
+	// +optional
+	SourceDeletionPolicy SourceDeletionPolicy `json:"sourceDeletionPolicy,omitempty"`
+
+	// format is the layout of the decrypted document.
+	// flat uses every top-level key as a Secret entry, crd reads data and
+	// stringData from a Kubernetes Secret manifest.
+	// Defaults to flat.
+	// +kubebuilder:validation:Enum=flat;crd
+	// +kubebuilder:default=flat
+	// +optional
+	Format SopsSecretFormat `json:"format,omitempty"`
+
+	// complexValueFormat is the encoding of nested maps and lists in the Secret
+	// values when format is flat. Scalar values are not affected.
+	// Defaults to yaml.
+	// +kubebuilder:validation:Enum=yaml;json
+	// +kubebuilder:default=yaml
+	// +optional
+	ComplexValueFormat ComplexValueFormat `json:"complexValueFormat,omitempty"`
+
+	// omitNullValues leaves keys whose decrypted value is an explicit null out of
+	// the Secret, so `key: null` removes a key. Empty strings are always kept.
+	// +optional
+	OmitNullValues bool `json:"omitNullValues,omitempty"`
+
+	// recursiveDecrypt decrypts top-level values that are sops documents
+	// themselves and writes the decrypted document as the value. Documents
+	// are decrypted up to three levels deep.
+	// +optional
+	RecursiveDecrypt bool `json:"recursiveDecrypt,omitempty"`
 
 	// secretName is the name of the Kubernetes Secret to create.
 	// Defaults to the SopsSecret name if not specified.
 	// +optional
 	SecretName string `json:"secretName,omitempty"`
 
+	// useGenerateName creates a Secret with a generated name, prefixed with
+	// secretName or the SopsSecret name, every time the encrypted payload changes.
+	// The current name is reported in status.secretName and the Secret of the
+	// previous generation is deleted.
+	// +optional
+	UseGenerateName bool `json:"useGenerateName,omitempty"`
+
 	// secretType is the type of Secret to create.
 	// Defaults to Opaque.
 	// +kubebuilder:default=Opaque
 	// +optional
 	SecretType corev1.SecretType `json:"secretType,omitempty"`
 
+	// targetNamespace is the namespace the Secret is written to. Defaults to
+	// the namespace of the SopsSecret. Owner references cannot cross
+	// namespaces, so a Secret in another namespace is tracked by labels and
+	// deleted by the finalizer. It cannot be added, changed or removed later.
+	// +kubebuilder:validation:MinLength=1
+	// +optional
+	TargetNamespace string `json:"targetNamespace,omitempty"`
+
 	// secretLabels are additional labels to add to the created Secret.
 	// +optional
 	SecretLabels map[string]string `json:"secretLabels,omitempty"`
@@ -50,14 +272,188 @@ type SopsSecretSpec struct {
 	// suspend stops reconciliation when true.
 	// +optional
 	Suspend bool `json:"suspend,omitempty"`
+
+	// maxValueBytes is the largest size, in bytes, allowed for any single decrypted value.
+	// Overrides the operator-wide limit. Secrets with larger values are not written.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxValueBytes int64 `json:"maxValueBytes,omitempty"`
+
+	// warnOnTrailingNewline reports keys whose decrypted values start or end
+	// with whitespace, such as a newline left by echo, in the ValueFormatWarning
+	// condition. The Secret is written regardless.
+	// +optional
+	WarnOnTrailingNewline bool `json:"warnOnTrailingNewline,omitempty"`
+
+	// backupRecipient is an AGE recipient the decrypted document is re-encrypted
+	// to after every decrypt. The ciphertext is stored in the Secret
+	// <name>-backup under the key backup.enc.yaml.
+	// +kubebuilder:validation:Pattern=`^age1[0-9a-z]+$`
+	// +optional
+	BackupRecipient string `json:"backupRecipient,omitempty"`
+
+	// vaultSink also writes the values of the Secret to a HashiCorp Vault KV
+	// version 2 secret. A write that leaves the Vault secret unchanged is
+	// skipped.
+	// +optional
+	VaultSink *VaultSink `json:"vaultSink,omitempty"`
+
+	// allowPlaintext uses a document without a sops block as is, without
+	// decryption, and sets the Plaintext condition. Intended for development.
+	// +optional
+	AllowPlaintext bool `json:"allowPlaintext,omitempty"`
+
+	// configMapData is plaintext configuration written to a ConfigMap with the
+	// same name as the Secret and owned by the SopsSecret. The ConfigMap is
+	// removed when configMapData is emptied.
+	// +optional
+	ConfigMapData map[string]string `json:"configMapData,omitempty"`
+
+	// keyTypes maps Secret keys to the expected content of their values. A
+	// json key whose value does not parse as JSON sets the InvalidJSON
+	// condition and the Secret is not written.
+	// +optional
+	KeyTypes map[string]KeyType `json:"keyTypes,omitempty"`
+
+	// validateKubeconfig checks that the kubeconfig or value key holds a
+	// kubeconfig with a usable current context. Otherwise the InvalidKubeconfig
+	// condition is set and the Secret is not written.
+	// +optional
+	ValidateKubeconfig bool `json:"validateKubeconfig,omitempty"`
+
+	// secretTTL deletes the managed Secret once this long has passed since
+	// status.lastDecryptedTime and sets the Expired condition. The Secret is
+	// recreated the next time the SopsSecret is updated.
+	// +optional
+	SecretTTL *metav1.Duration `json:"secretTTL,omitempty"`
+
+	// reconcileInterval is how often the SopsSecret is reconciled after a
+	// successful write to correct drift of the managed Secret. Must be
+	// positive. Defaults to the --reconcile-interval of the operator.
+	// +optional
+	ReconcileInterval *metav1.Duration `json:"reconcileInterval,omitempty"`
+
+	// rotationSchedule is a cron expression in UTC, such as "0 3 * * *", at
+	// which the document is decrypted and the Secret written again even
+	// though the document did not change, e.g. to pick up a rotated key.
+	// +optional
+	RotationSchedule string `json:"rotationSchedule,omitempty"`
+
+	// ownerReferenceMode decides how the managed Secret references the
+	// SopsSecret. Controller sets a controller reference and deletes the Secret
+	// with the SopsSecret. NonController sets a plain owner reference and None
+	// sets none, both retain the Secret when the SopsSecret is deleted.
+	// Defaults to Controller.
+	// +kubebuilder:validation:Enum=Controller;NonController;None
+	// +kubebuilder:default=Controller
+	// +optional
+	OwnerReferenceMode OwnerReferenceMode `json:"ownerReferenceMode,omitempty"`
+
+	// publishKeyList writes the sorted key names of the Secret, without their
+	// values, to a ConfigMap named <secret>-keys so other tools can discover
+	// which keys the Secret provides without reading it.
+	// +optional
+	PublishKeyList bool `json:"publishKeyList,omitempty"`
+
+	// stringData writes the values of the Secret to stringData instead of
+	// data, for tools that read stringData. Values that are not valid UTF-8
+	// are still written to data.
+	// +optional
+	StringData bool `json:"stringData,omitempty"`
+
+	// immutable creates the managed Secret as immutable. The data of an
+	// immutable Secret cannot be updated in place, so a change to it deletes
+	// and recreates the Secret.
+	// +optional
+	Immutable bool `json:"immutable,omitempty"`
+
+	// lockData makes the encrypted document immutable once it was decrypted
+	// successfully. The validating webhook rejects changes to sopsSecret,
+	// encryptedFromFile and encryptedFromChunks until the lock is removed, and
+	// the controller keeps the Secret of the last decrypt when the document
+	// changes anyway.
+	// +optional
+	LockData bool `json:"lockData,omitempty"`
+
+	// reflectToNamespaces copies the managed Secret into each listed
+	// namespace. The copies are kept in sync with the Secret, tracked by
+	// labels since owner references cannot cross namespaces, and deleted when
+	// a namespace is removed from the list or the SopsSecret is deleted.
+	// +optional
+	ReflectToNamespaces []string `json:"reflectToNamespaces,omitempty"`
+
+	// aliases are further names in the SopsSecret's namespace under which
+	// the managed Secret is written, for workloads that expect a different
+	// name. The aliases are owned by the SopsSecret, kept in sync with the
+	// Secret, and deleted when removed from the list or when the SopsSecret
+	// is deleted.
+	// +optional
+	Aliases []string `json:"aliases,omitempty"`
+
+	// transforms maps Secret keys to an ordered list of named transforms
+	// applied to their values before the Secret is written: trim, lower,
+	// upper, base64encode and base64decode. An unknown transform fails the
+	// reconcile with reason TransformFailed and the Secret is not written.
+	// +optional
+	Transforms map[string][]string `json:"transforms,omitempty"`
+
+	// schemaRef points at a ConfigMap holding a JSON schema the decrypted
+	// data must match. Data that does not match sets the SchemaInvalid
+	// condition and the Secret is not written.
+	// +optional
+	SchemaRef *SchemaRef `json:"schemaRef,omitempty"`
 }
 
+// Key sources recorded in status.keySource.
+const (
+	// KeySourceGlobalEnv is the operator's keys from SOPS_AGE_KEY.
+	KeySourceGlobalEnv = "global-env"
+
+	// KeySourceGlobalFile is the operator's keys from SOPS_AGE_KEY_FILE.
+	KeySourceGlobalFile = "global-file"
+
+	// KeySourceAnnotation is the key Secret named in the
+	// secrets.scalaric.io/age-key-secret annotation.
+	KeySourceAnnotation = "annotation"
+)
+
 // SopsSecretStatus defines the observed state of SopsSecret.
 type SopsSecretStatus struct {
 	// secretName is the name of the created Kubernetes Secret.
 	// +optional
 	SecretName string `json:"secretName,omitempty"`
 
+	// secretUID is the UID of the created Kubernetes Secret. It changes when
+	// the Secret is recreated, so tools can correlate the SopsSecret with one
+	// Secret object rather than a name.
+	// +optional
+	SecretUID types.UID `json:"secretUID,omitempty"`
+
+	// schemaResourceVersion is the resourceVersion of the spec.schemaRef
+	// ConfigMap the decrypted data was last validated against. A change of
+	// the schema validates the data again.
+	// +optional
+	SchemaResourceVersion string `json:"schemaResourceVersion,omitempty"`
+
+	// managedKeys are the sorted key names of the managed Secret as last
+	// written. A change is reported in a KeysChanged event.
+	// +optional
+	ManagedKeys []string `json:"managedKeys,omitempty"`
+
+	// keySource records where the AGE keys of the last successful decrypt
+	// came from: global-env or global-file for the operator's own keys,
+	// comma-separated when both are configured, or annotation for the
+	// age-key-secret annotation. It is empty for plaintext documents.
+	// +optional
+	KeySource string `json:"keySource,omitempty"`
+
+	// lastErrorDetail describes the sops invocation of the last failed
+	// decrypt: its arguments, the names of its environment variables and its
+	// output. It is only set when the operator runs with verbose errors, and
+	// cleared by the next successful decrypt.
+	// +optional
+	LastErrorDetail string `json:"lastErrorDetail,omitempty"`
+
 	// lastDecryptedHash is the hash of the last successfully decrypted sopsSecret.
 	// Used to detect changes and trigger re-decryption.
 	// +optional
@@ -71,6 +467,33 @@ type SopsSecretStatus struct {
 	// +optional
 	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
 
+	// observedSpecHash is the hash of the spec, without suspend, that the
+	// Secret was last written from.
+	// +optional
+	ObservedSpecHash string `json:"observedSpecHash,omitempty"`
+
+	// failureCount is the number of failed reconciles in a row. The retry
+	// delay grows with it. It is reset once the SopsSecret is Ready again or
+	// its generation changes.
+	// +optional
+	FailureCount int32 `json:"failureCount,omitempty"`
+
+	// failureGeneration is the generation failureCount counts the failures
+	// of. A new generation starts the count again.
+	// +optional
+	FailureGeneration int64 `json:"failureGeneration,omitempty"`
+
+	// firstFailureTime is when the current streak of failed reconciles started.
+	// It is cleared once the SopsSecret is Ready again.
+	// +optional
+	FirstFailureTime *metav1.Time `json:"firstFailureTime,omitempty"`
+
+	// recreateAcknowledged records that the Secret was recreated for the
+	// secrets.scalaric.io/recreate annotation. It is cleared once the
+	// annotation is removed, so setting it again recreates the Secret again.
+	// +optional
+	RecreateAcknowledged bool `json:"recreateAcknowledged,omitempty"`
+
 	// conditions represent the current state of the SopsSecret resource.
 	// +listType=map
 	// +listMapKey=type
@@ -85,6 +508,91 @@ const (
 
 	// ConditionTypeDecrypted indicates the sopsSecret was successfully decrypted.
 	ConditionTypeDecrypted = "Decrypted"
+
+	// ConditionTypeWaitingForDependency indicates reconciliation is held back until
+	// the object named in the secrets.scalaric.io/depends-on annotation is ready.
+	ConditionTypeWaitingForDependency = "WaitingForDependency"
+
+	// ConditionTypeSourceMissing indicates the source of the encrypted document
+	// no longer exists.
+	ConditionTypeSourceMissing = "SourceMissing"
+
+	// ConditionTypeDegraded indicates decryption is failing while the Secret
+	// from the last successful decrypt is kept unchanged.
+	ConditionTypeDegraded = "Degraded"
+
+	// ConditionTypeValueFormatWarning lists keys whose decrypted values have
+	// leading or trailing whitespace, when spec.warnOnTrailingNewline is set.
+	ConditionTypeValueFormatWarning = "ValueFormatWarning"
+
+	// ConditionTypePlaintext indicates the document is not encrypted and was
+	// used without decryption because spec.allowPlaintext is set.
+	ConditionTypePlaintext = "Plaintext"
+
+	// ConditionTypeInvalidJSON lists keys typed json in spec.keyTypes whose
+	// decrypted values are not well-formed JSON.
+	ConditionTypeInvalidJSON = "InvalidJSON"
+
+	// ConditionTypeInvalidKubeconfig indicates the decrypted kubeconfig cannot
+	// be used, when spec.validateKubeconfig is set.
+	ConditionTypeInvalidKubeconfig = "InvalidKubeconfig"
+
+	// ConditionTypeEncryptedKeyUnsupported indicates the decrypted document
+	// still has encrypted top-level keys, from a sops configuration that
+	// encrypts map keys, so the Secret is not written.
+	ConditionTypeEncryptedKeyUnsupported = "EncryptedKeyUnsupported"
+
+	// ConditionTypeSchemaInvalid indicates the decrypted data does not match
+	// the schema referenced by spec.schemaRef.
+	ConditionTypeSchemaInvalid = "SchemaInvalid"
+
+	// ConditionTypeAdopted records that the operator took ownership of an
+	// existing Secret that carried the SopsSecret's label but had no owner.
+	ConditionTypeAdopted = "Adopted"
+
+	// ConditionTypeChunksComplete indicates whether the encryptedFromChunks
+	// chunks were found and form a complete, ordered document.
+	ConditionTypeChunksComplete = "ChunksComplete"
+
+	// ConditionTypeExpired indicates the managed Secret was deleted because
+	// spec.secretTTL elapsed.
+	ConditionTypeExpired = "Expired"
+
+	// ConditionTypePending indicates a new SopsSecret failed to decrypt
+	// within the operator's initial grace period and is retried quietly.
+	ConditionTypePending = "Pending"
+
+	// ConditionTypeBackendPolicyViolation indicates the document is encrypted
+	// with key backends its namespace does not allow, so it is not decrypted.
+	ConditionTypeBackendPolicyViolation = "BackendPolicyViolation"
+
+	// ConditionTypeVerificationFailed indicates the operator's verifier
+	// rejected the decrypted data, so the Secret is not updated.
+	ConditionTypeVerificationFailed = "VerificationFailed"
+
+	// ConditionTypeWeakMACWarning indicates the document was written by a sops
+	// release older than the operator's minimum or uses a discouraged MAC
+	// setting, and should be re-encrypted.
+	ConditionTypeWeakMACWarning = "WeakMacWarning"
+
+	// ConditionTypeTooDeeplyNested indicates the decrypted document is nested
+	// deeper than the operator's limit, so it is not converted.
+	ConditionTypeTooDeeplyNested = "TooDeeplyNested"
+
+	// ConditionTypeReflectionFailed lists the namespaces of
+	// spec.reflectToNamespaces the Secret could not be copied into. The other
+	// namespaces are still written.
+	ConditionTypeReflectionFailed = "ReflectionFailed"
+
+	// ConditionTypeTargetNamespaceRefused indicates the Secret is not written
+	// because spec.targetNamespace does not accept Secrets from the namespace
+	// of the SopsSecret, or the operator does not allow it.
+	ConditionTypeTargetNamespaceRefused = "TargetNamespaceRefused"
+
+	// ConditionTypeLockedDataChanged indicates the encrypted document changed
+	// while spec.lockData locks it, so it is not decrypted and the Secret
+	// keeps the data of the last decrypt.
+	ConditionTypeLockedDataChanged = "LockedDataChanged"
 )
 
 // +kubebuilder:object:root=true
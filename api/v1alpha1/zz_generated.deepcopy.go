@@ -25,6 +25,36 @@ import (
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigMapChunkRef) DeepCopyInto(out *ConfigMapChunkRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigMapChunkRef.
+func (in *ConfigMapChunkRef) DeepCopy() *ConfigMapChunkRef {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigMapChunkRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SchemaRef) DeepCopyInto(out *SchemaRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchemaRef.
+func (in *SchemaRef) DeepCopy() *SchemaRef {
+	if in == nil {
+		return nil
+	}
+	out := new(SchemaRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SopsSecret) DeepCopyInto(out *SopsSecret) {
 	*out = *in
@@ -87,6 +117,11 @@ func (in *SopsSecretList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SopsSecretSpec) DeepCopyInto(out *SopsSecretSpec) {
 	*out = *in
+	if in.EncryptedFromChunks != nil {
+		in, out := &in.EncryptedFromChunks, &out.EncryptedFromChunks
+		*out = make([]ConfigMapChunkRef, len(*in))
+		copy(*out, *in)
+	}
 	if in.SecretLabels != nil {
 		in, out := &in.SecretLabels, &out.SecretLabels
 		*out = make(map[string]string, len(*in))
@@ -101,6 +136,66 @@ func (in *SopsSecretSpec) DeepCopyInto(out *SopsSecretSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.ConfigMapData != nil {
+		in, out := &in.ConfigMapData, &out.ConfigMapData
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.KeyTypes != nil {
+		in, out := &in.KeyTypes, &out.KeyTypes
+		*out = make(map[string]KeyType, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.SecretTTL != nil {
+		in, out := &in.SecretTTL, &out.SecretTTL
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.ReconcileInterval != nil {
+		in, out := &in.ReconcileInterval, &out.ReconcileInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.ReflectToNamespaces != nil {
+		in, out := &in.ReflectToNamespaces, &out.ReflectToNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Aliases != nil {
+		in, out := &in.Aliases, &out.Aliases
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Transforms != nil {
+		in, out := &in.Transforms, &out.Transforms
+		*out = make(map[string][]string, len(*in))
+		for key, val := range *in {
+			var outVal []string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				inVal := (*in)[key]
+				in, out := &inVal, &outVal
+				*out = make([]string, len(*in))
+				copy(*out, *in)
+			}
+			(*out)[key] = outVal
+		}
+	}
+	if in.SchemaRef != nil {
+		in, out := &in.SchemaRef, &out.SchemaRef
+		*out = new(SchemaRef)
+		**out = **in
+	}
+	if in.VaultSink != nil {
+		in, out := &in.VaultSink, &out.VaultSink
+		*out = new(VaultSink)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SopsSecretSpec.
@@ -116,10 +211,19 @@ func (in *SopsSecretSpec) DeepCopy() *SopsSecretSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SopsSecretStatus) DeepCopyInto(out *SopsSecretStatus) {
 	*out = *in
+	if in.ManagedKeys != nil {
+		in, out := &in.ManagedKeys, &out.ManagedKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.LastDecryptedTime != nil {
 		in, out := &in.LastDecryptedTime, &out.LastDecryptedTime
 		*out = (*in).DeepCopy()
 	}
+	if in.FirstFailureTime != nil {
+		in, out := &in.FirstFailureTime, &out.FirstFailureTime
+		*out = (*in).DeepCopy()
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]v1.Condition, len(*in))
@@ -138,3 +242,74 @@ func (in *SopsSecretStatus) DeepCopy() *SopsSecretStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultAuth) DeepCopyInto(out *VaultAuth) {
+	*out = *in
+	if in.TokenSecretRef != nil {
+		in, out := &in.TokenSecretRef, &out.TokenSecretRef
+		*out = new(VaultTokenSecretRef)
+		**out = **in
+	}
+	if in.Kubernetes != nil {
+		in, out := &in.Kubernetes, &out.Kubernetes
+		*out = new(VaultKubernetesAuth)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultAuth.
+func (in *VaultAuth) DeepCopy() *VaultAuth {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultAuth)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultKubernetesAuth) DeepCopyInto(out *VaultKubernetesAuth) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultKubernetesAuth.
+func (in *VaultKubernetesAuth) DeepCopy() *VaultKubernetesAuth {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultKubernetesAuth)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultSink) DeepCopyInto(out *VaultSink) {
+	*out = *in
+	in.Auth.DeepCopyInto(&out.Auth)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultSink.
+func (in *VaultSink) DeepCopy() *VaultSink {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultSink)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultTokenSecretRef) DeepCopyInto(out *VaultTokenSecretRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultTokenSecretRef.
+func (in *VaultTokenSecretRef) DeepCopy() *VaultTokenSecretRef {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultTokenSecretRef)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -17,10 +17,13 @@ limitations under the License.
 package main
 
 import (
+	"context"
 	"crypto/tls"
 	"flag"
 	"fmt"
 	"os"
+	"strings"
+	"time"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
@@ -38,6 +41,7 @@ import (
 
 	secretsv1alpha1 "github.com/scalaric/sops-operator/api/v1alpha1"
 	"github.com/scalaric/sops-operator/internal/controller"
+	webhookv1alpha1 "github.com/scalaric/sops-operator/internal/webhook/v1alpha1"
 	"github.com/scalaric/sops-operator/pkg/sops"
 	// +kubebuilder:scaffold:imports
 )
@@ -71,6 +75,38 @@ func main() {
 	var probeAddr string
 	var secureMetrics bool
 	var enableHTTP2 bool
+	var maxValueBytes int64
+	var maxKeysPerSecret int
+	var decryptCacheSize int
+	var decryptCacheMaxAge time.Duration
+	var encryptedFileDirs string
+	var selfTestFile string
+	var skipPreValidation bool
+	var enableWebhooks bool
+	var enableKeyUsageEndpoint bool
+	var failFastOnStartup bool
+	var failFastSamples int
+	var failFastRatio float64
+	var failFastWindow time.Duration
+	var conditionStabilizationWindow time.Duration
+	var nameCollisionCheckInterval time.Duration
+	var shutdownGracePeriod time.Duration
+	var initialGracePeriod time.Duration
+	var enableAuditLog bool
+	var requiredLabels string
+	var encryptedFilePollInterval time.Duration
+	var disableNamespaceMetricLabels bool
+	var verboseErrors bool
+	var minSopsVersion string
+	var sopsTempFile bool
+	var maxNestingDepth int
+	var reconcileInterval time.Duration
+	var failureBackoffBase time.Duration
+	var failureBackoffMax time.Duration
+	var serverSideApply bool
+	var allowCrossNamespace bool
+	var vaultSinkAddresses string
+	var legacyMetadataKeys string
 	var tlsOpts []func(*tls.Config)
 	flag.BoolVar(&showVersion, "version", false, "Print version information and exit.")
 	flag.StringVar(&metricsAddr, "metrics-bind-address", "0", "The address the metrics endpoint binds to. "+
@@ -90,6 +126,81 @@ func main() {
 	flag.StringVar(&metricsCertKey, "metrics-cert-key", "tls.key", "The name of the metrics server key file.")
 	flag.BoolVar(&enableHTTP2, "enable-http2", false,
 		"If set, HTTP/2 will be enabled for the metrics and webhook servers")
+	flag.Int64Var(&maxValueBytes, "max-value-bytes", 0,
+		"Maximum size in bytes of a single decrypted value. SopsSecrets exceeding it are not written. 0 disables the limit.")
+	flag.IntVar(&maxKeysPerSecret, "max-keys-per-secret", 0,
+		"Maximum number of keys in a single Secret. SopsSecrets exceeding it are not written. 0 disables the limit.")
+	flag.IntVar(&decryptCacheSize, "decrypt-cache-size", 0,
+		"Number of decrypted documents to keep in memory, keyed by the hash of the encrypted payload. 0 disables the cache.")
+	flag.DurationVar(&decryptCacheMaxAge, "decrypt-cache-max-age", time.Hour,
+		"How long a decrypted document is served from the cache before it is decrypted again. 0 disables the limit.")
+	flag.StringVar(&encryptedFileDirs, "encrypted-file-dirs", "",
+		"Comma-separated directories that spec.encryptedFromFile may read from. Empty disables file sources.")
+	flag.DurationVar(&encryptedFilePollInterval, "encrypted-file-poll-interval", time.Minute,
+		"How often SopsSecrets with encryptedFromFile are checked for changes to the file. 0 disables polling.")
+	flag.BoolVar(&disableNamespaceMetricLabels, "disable-namespace-metric-labels", false,
+		"Leave the namespace label of the reconcile and decrypt counters empty to limit their cardinality.")
+	flag.StringVar(&selfTestFile, "self-test-file", "",
+		"Path to a SOPS-encrypted fixture to decrypt on startup to verify keys and the sops binary. Empty skips the self-test.")
+	flag.BoolVar(&verboseErrors, "verbose-errors", false,
+		"Record the sops arguments, environment variable names and output of failed decrypts in status.lastErrorDetail. "+
+			"The detail may name key recipients.")
+	flag.StringVar(&minSopsVersion, "min-sops-version", "",
+		"Warn about documents written by sops releases older than this version, or with a MAC that only covers "+
+			"encrypted values, in the WeakMacWarning condition. Empty disables the check.")
+	flag.DurationVar(&reconcileInterval, "reconcile-interval", controller.DefaultReconcileInterval,
+		"How often a SopsSecret is reconciled after a successful write to correct drift of its Secret. "+
+			"spec.reconcileInterval takes precedence.")
+	flag.DurationVar(&failureBackoffBase, "failure-backoff-base", 10*time.Second,
+		"How soon a SopsSecret that failed to reconcile is retried. The delay doubles with every further failure. "+
+			"0 retries failures after the reconcile interval.")
+	flag.DurationVar(&failureBackoffMax, "failure-backoff-max", 5*time.Minute,
+		"Maximum delay between retries of a failing SopsSecret. 0 uses the reconcile interval.")
+	flag.StringVar(&vaultSinkAddresses, "vault-sink-addresses", "",
+		"Comma-separated addresses of the Vault servers spec.vaultSink may write to. Empty disables the Vault sink.")
+	flag.StringVar(&legacyMetadataKeys, "legacy-metadata-keys", "",
+		"Comma-separated old=new label and annotation keys. Managed Secrets still carrying an old key are "+
+			"rewritten to the new one.")
+	flag.BoolVar(&allowCrossNamespace, "allow-cross-namespace", false,
+		"Let SopsSecrets write Secrets into other namespaces that accept them in their "+
+			"secrets.scalaric.io/accept-secrets-from annotation.")
+	flag.BoolVar(&serverSideApply, "server-side-apply", false,
+		"Write managed Secrets with server-side apply as the sops-operator field manager, "+
+			"keeping fields that other managers own.")
+	flag.IntVar(&maxNestingDepth, "max-nesting-depth", 0,
+		"Maximum depth of nested maps and lists in a decrypted document, counting the top level as 1. "+
+			"Deeper documents are rejected with the TooDeeplyNested condition. 0 disables the limit.")
+	flag.BoolVar(&sopsTempFile, "sops-temp-file", false,
+		"Write the encrypted document to a temporary file for sops instead of passing it on stdin. "+
+			"Needed for sops releases that cannot read documents from stdin.")
+	flag.BoolVar(&skipPreValidation, "skip-pre-validation", false,
+		"Skip checking for a sops metadata block with a MAC before decrypting and let sops reject invalid documents.")
+	flag.BoolVar(&failFastOnStartup, "fail-fast-on-startup", false,
+		"Exit when too many of the first decrypts after startup fail, which usually means the keys are wrong.")
+	flag.IntVar(&failFastSamples, "fail-fast-samples", 10,
+		"Number of decrypts after startup judged by --fail-fast-on-startup.")
+	flag.Float64Var(&failFastRatio, "fail-fast-ratio", 0.5,
+		"Fraction of failed decrypts among --fail-fast-samples that makes the operator exit.")
+	flag.DurationVar(&failFastWindow, "fail-fast-window", 5*time.Minute,
+		"Time after the first decrypt within which --fail-fast-samples must be collected.")
+	flag.DurationVar(&conditionStabilizationWindow, "condition-stabilization-window", 0,
+		"How long failures must persist before a Ready SopsSecret reports Ready=False. 0 reports failures immediately.")
+	flag.DurationVar(&nameCollisionCheckInterval, "name-collision-check-interval", 10*time.Minute,
+		"How often to look for SopsSecrets in a namespace that write to the same Secret. 0 disables the check.")
+	flag.DurationVar(&initialGracePeriod, "initial-grace-period", 0,
+		"How long a new SopsSecret that fails to decrypt is retried quietly as Pending before the failure is "+
+			"reported. 0 reports failures immediately.")
+	flag.DurationVar(&shutdownGracePeriod, "shutdown-grace-period", sops.DefaultDecryptTimeout,
+		"How long reconciles in flight at shutdown may run to finish their decrypt and writes. "+
+			"0 stops them immediately.")
+	flag.BoolVar(&enableAuditLog, "enable-audit-log", false,
+		"Write a line of JSON to stdout for every decrypt attempt, with the SopsSecret, outcome and key source.")
+	flag.BoolVar(&enableKeyUsageEndpoint, "enable-key-usage-endpoint", false,
+		"Serve "+controller.KeyUsagePath+" on the metrics server to list SopsSecrets encrypted to a given key.")
+	flag.StringVar(&requiredLabels, "required-labels", "",
+		"Comma-separated label keys the admission webhook requires on every SopsSecret. Empty requires none.")
+	flag.BoolVar(&enableWebhooks, "enable-webhooks", false,
+		"Serve the SopsSecret validating webhook. Requires a webhook certificate and ValidatingWebhookConfiguration.")
 	opts := zap.Options{
 		Development: true,
 	}
@@ -101,7 +212,20 @@ func main() {
 		os.Exit(0)
 	}
 
-	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
+	// Scrub decrypted values from every log call, whatever the encoder
+	ctrl.SetLogger(controller.NewRedactingLogger(zap.New(zap.UseFlagOptions(&opts))))
+
+	if reconcileInterval <= 0 {
+		setupLog.Error(fmt.Errorf("must be positive, got %s", reconcileInterval), "invalid --reconcile-interval")
+		os.Exit(1)
+	}
+
+	if minSopsVersion != "" {
+		if err := sops.ValidateVersion(minSopsVersion); err != nil {
+			setupLog.Error(err, "invalid --min-sops-version")
+			os.Exit(1)
+		}
+	}
 
 	// if the enable-http2 flag is false (the default), http/2 should be disabled
 	// due to its vulnerabilities. More specifically, disabling http/2 will
@@ -170,6 +294,7 @@ func main() {
 		metricsServerOptions.KeyName = metricsCertKey
 	}
 
+	gracefulShutdownTimeout := shutdownGracePeriod + 5*time.Second
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
 		Scheme:                 scheme,
 		Metrics:                metricsServerOptions,
@@ -177,6 +302,9 @@ func main() {
 		HealthProbeBindAddress: probeAddr,
 		LeaderElection:         enableLeaderElection,
 		LeaderElectionID:       "a1687995.scalaric.io",
+		// Wait for reconciles in flight to drain, with some time to spare for
+		// them to return once the grace period cancels them
+		GracefulShutdownTimeout: &gracefulShutdownTimeout,
 		// LeaderElectionReleaseOnCancel defines if the leader should step down voluntarily
 		// when the Manager ends. This requires the binary to immediately end when the
 		// Manager is stopped, otherwise, this setting is unsafe. Setting this significantly
@@ -195,21 +323,111 @@ func main() {
 	}
 
 	// Initialize SOPS decryptor from environment
-	decryptor, err := sops.NewDecryptorFromEnv()
+	var decryptorOpts []sops.Option
+	if sopsTempFile {
+		decryptorOpts = append(decryptorOpts, sops.WithTempFile())
+	}
+	if maxNestingDepth > 0 {
+		decryptorOpts = append(decryptorOpts, sops.WithMaxDepth(maxNestingDepth))
+	}
+	decryptor, err := sops.NewDecryptorFromEnv(decryptorOpts...)
 	if err != nil {
 		setupLog.Error(err, "unable to create SOPS decryptor - ensure SOPS_AGE_KEY or SOPS_AGE_KEY_FILE is set")
 		os.Exit(1)
 	}
 
+	if selfTestFile != "" {
+		fixture, err := os.ReadFile(selfTestFile)
+		if err == nil {
+			err = sops.SelfTest(context.Background(), decryptor, fixture)
+		}
+		if err != nil {
+			setupLog.Error(err, "SOPS self-test failed", "file", selfTestFile)
+			os.Exit(1)
+		}
+		setupLog.Info("SOPS self-test passed", "file", selfTestFile)
+	}
+
+	var dec sops.DecryptorInterface = decryptor
+	if decryptCacheSize > 0 {
+		dec = sops.NewCachingDecryptor(decryptor, decryptCacheSize, sops.WithCacheMaxAge(decryptCacheMaxAge))
+	}
+
+	var auditLogger controller.AuditLogger
+	if enableAuditLog {
+		auditLogger = controller.NewJSONAuditLogger(os.Stdout)
+	}
+
+	legacyKeys, err := parseKeyMap(legacyMetadataKeys)
+	if err != nil {
+		setupLog.Error(err, "invalid --legacy-metadata-keys")
+		os.Exit(1)
+	}
+
+	var vaultSink controller.SecretSink
+	if addresses := splitList(vaultSinkAddresses); len(addresses) > 0 {
+		vaultSink = controller.NewVaultSink(mgr.GetClient(), addresses)
+	}
+
 	if err := (&controller.SopsSecretReconciler{
-		Client:    mgr.GetClient(),
-		Scheme:    mgr.GetScheme(),
-		Recorder:  mgr.GetEventRecorder("sopssecret-controller"),
-		Decryptor: decryptor,
+		Client:                       mgr.GetClient(),
+		Scheme:                       mgr.GetScheme(),
+		Recorder:                     mgr.GetEventRecorder("sopssecret-controller"),
+		Decryptor:                    dec,
+		Encryptor:                    decryptor,
+		VaultSink:                    vaultSink,
+		LegacyMetadataKeys:           legacyKeys,
+		MaxValueBytes:                maxValueBytes,
+		MaxKeysPerSecret:             maxKeysPerSecret,
+		EncryptedFileDirs:            splitList(encryptedFileDirs),
+		FilePollInterval:             encryptedFilePollInterval,
+		SkipPreValidation:            skipPreValidation,
+		FailFastOnStartup:            failFastOnStartup,
+		FailFastSamples:              failFastSamples,
+		FailFastRatio:                failFastRatio,
+		FailFastWindow:               failFastWindow,
+		ConditionStabilizationWindow: conditionStabilizationWindow,
+		AuditLogger:                  auditLogger,
+		InitialGracePeriod:           initialGracePeriod,
+		GlobalKeySource:              globalKeySource(),
+		ShutdownGracePeriod:          shutdownGracePeriod,
+		DisableNamespaceLabels:       disableNamespaceMetricLabels,
+		VerboseErrors:                verboseErrors,
+		MinSopsVersion:               minSopsVersion,
+		ReconcileInterval:            reconcileInterval,
+		ServerSideApply:              serverSideApply,
+		AllowCrossNamespace:          allowCrossNamespace,
+		FailureBackoffBase:           failureBackoffBase,
+		FailureBackoffMax:            failureBackoffMax,
+		NewDecryptor: func(ageKeys []string) sops.DecryptorInterface {
+			return sops.NewDecryptor(ageKeys, decryptorOpts...)
+		},
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "SopsSecret")
 		os.Exit(1)
 	}
+	if nameCollisionCheckInterval > 0 {
+		if err := mgr.Add(&controller.NameCollisionChecker{
+			Client:   mgr.GetClient(),
+			Interval: nameCollisionCheckInterval,
+		}); err != nil {
+			setupLog.Error(err, "unable to add Secret name collision check")
+			os.Exit(1)
+		}
+	}
+	if enableKeyUsageEndpoint {
+		if err := mgr.AddMetricsServerExtraHandler(controller.KeyUsagePath,
+			controller.NewKeyUsageHandler(mgr.GetClient())); err != nil {
+			setupLog.Error(err, "unable to add key usage endpoint")
+			os.Exit(1)
+		}
+	}
+	if enableWebhooks {
+		if err := webhookv1alpha1.SetupSopsSecretWebhookWithManager(mgr, splitList(requiredLabels)); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "SopsSecret")
+			os.Exit(1)
+		}
+	}
 	// +kubebuilder:scaffold:builder
 
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
@@ -227,3 +445,41 @@ func main() {
 		os.Exit(1)
 	}
 }
+
+// splitList splits a comma-separated flag value, dropping empty entries.
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// parseKeyMap parses a comma-separated list of old=new key pairs.
+func parseKeyMap(value string) (map[string]string, error) {
+	keys := make(map[string]string)
+	for _, pair := range splitList(value) {
+		oldKey, newKey, ok := strings.Cut(pair, "=")
+		oldKey, newKey = strings.TrimSpace(oldKey), strings.TrimSpace(newKey)
+		if !ok || oldKey == "" || newKey == "" || oldKey == newKey {
+			return nil, fmt.Errorf("%q is not an old=new pair of different keys", pair)
+		}
+		keys[oldKey] = newKey
+	}
+	return keys, nil
+}
+
+// globalKeySource describes where the operator's own AGE keys come from, in
+// the terms of status.keySource.
+func globalKeySource() string {
+	var sources []string
+	if os.Getenv("SOPS_AGE_KEY") != "" {
+		sources = append(sources, secretsv1alpha1.KeySourceGlobalEnv)
+	}
+	if os.Getenv("SOPS_AGE_KEY_FILE") != "" {
+		sources = append(sources, secretsv1alpha1.KeySourceGlobalFile)
+	}
+	return strings.Join(sources, ",")
+}
@@ -0,0 +1,165 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	secretsv1alpha1 "github.com/scalaric/sops-operator/api/v1alpha1"
+)
+
+// aliasOfLabel marks the copies of a managed Secret written under the names
+// in spec.aliases. Its value is the name of the SopsSecret. The sopssecret
+// label is not copied, so an alias is never mistaken for the managed Secret.
+const aliasOfLabel = "secrets.scalaric.io/alias-of"
+
+// aliasNames returns the sorted, distinct names of spec.aliases, without the
+// name of the managed Secret itself.
+func aliasNames(sopsSecret *secretsv1alpha1.SopsSecret, secretName string) []string {
+	names := slices.DeleteFunc(slices.Clone(sopsSecret.Spec.Aliases), func(name string) bool {
+		return name == "" || name == secretName
+	})
+	slices.Sort(names)
+	return slices.Compact(names)
+}
+
+// reconcileAliases writes secret under every name of spec.aliases and deletes
+// aliases that are no longer listed. A Secret of an alias name that is not an
+// alias of this SopsSecret is never modified.
+func (r *SopsSecretReconciler) reconcileAliases(
+	ctx context.Context, sopsSecret *secretsv1alpha1.SopsSecret, secret *corev1.Secret,
+) error {
+	log := logf.FromContext(ctx)
+	wanted := aliasNames(sopsSecret, secret.Name)
+
+	for _, name := range wanted {
+		alias, err := r.aliasSecret(sopsSecret, secret, name)
+		if err != nil {
+			return err
+		}
+		existing := &corev1.Secret{}
+		err = r.Get(ctx, types.NamespacedName{Namespace: sopsSecret.Namespace, Name: name}, existing)
+		if apierrors.IsNotFound(err) {
+			if err := r.Create(ctx, alias); err != nil {
+				return err
+			}
+			log.Info("Created alias Secret", "name", name)
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if !isAliasOf(existing, sopsSecret) {
+			return fmt.Errorf("secret %s/%s exists and is not an alias of this SopsSecret", sopsSecret.Namespace, name)
+		}
+		existing.Labels = alias.Labels
+		existing.Annotations = alias.Annotations
+		existing.OwnerReferences = alias.OwnerReferences
+		existing.Type = alias.Type
+		existing.Data = alias.Data
+		if err := r.Update(ctx, existing); err != nil {
+			return err
+		}
+	}
+
+	// Remove aliases that were dropped from the list
+	aliases := &corev1.SecretList{}
+	if err := r.List(ctx, aliases, client.InNamespace(sopsSecret.Namespace),
+		client.MatchingLabels{aliasOfLabel: sopsSecret.Name}); err != nil {
+		return err
+	}
+	for i := range aliases.Items {
+		stale := &aliases.Items[i]
+		if slices.Contains(wanted, stale.Name) {
+			continue
+		}
+		if err := r.Delete(ctx, stale); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+		log.Info("Deleted alias Secret", "name", stale.Name)
+	}
+	return nil
+}
+
+// deleteAliases removes all aliases of the SopsSecret.
+func (r *SopsSecretReconciler) deleteAliases(ctx context.Context, sopsSecret *secretsv1alpha1.SopsSecret) error {
+	aliases := &corev1.SecretList{}
+	if err := r.List(ctx, aliases, client.InNamespace(sopsSecret.Namespace),
+		client.MatchingLabels{aliasOfLabel: sopsSecret.Name}); err != nil {
+		return err
+	}
+	for i := range aliases.Items {
+		if err := r.Delete(ctx, &aliases.Items[i]); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isAliasOf reports whether secret is an alias written for sopsSecret.
+func isAliasOf(secret *corev1.Secret, sopsSecret *secretsv1alpha1.SopsSecret) bool {
+	return secret.Labels[aliasOfLabel] == sopsSecret.Name
+}
+
+// aliasSecret returns the copy of secret named name, controlled by the
+// SopsSecret whatever spec.ownerReferenceMode says, as aliases only exist for
+// the SopsSecret.
+func (r *SopsSecretReconciler) aliasSecret(
+	sopsSecret *secretsv1alpha1.SopsSecret, secret *corev1.Secret, name string,
+) (*corev1.Secret, error) {
+	labels := maps.Clone(secret.Labels)
+	if labels == nil {
+		labels = make(map[string]string)
+	}
+	delete(labels, sopsSecretLabel)
+	labels[managedByLabel] = "sops-operator"
+	labels[aliasOfLabel] = sopsSecret.Name
+
+	annotations := maps.Clone(secret.Annotations)
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	delete(annotations, managedLabelsAnnotation)
+	delete(annotations, managedAnnotationsAnnotation)
+	annotations[sourceAnnotation] = fmt.Sprintf("%s/%s", sopsSecret.Namespace, sopsSecret.Name)
+
+	alias := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   sopsSecret.Namespace,
+			Labels:      labels,
+			Annotations: annotations,
+		},
+		Type: secret.Type,
+		Data: maps.Clone(secret.Data),
+	}
+	if err := controllerutil.SetControllerReference(sopsSecret, alias, r.Scheme); err != nil {
+		return nil, err
+	}
+	return alias, nil
+}
@@ -0,0 +1,96 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	corev1ac "k8s.io/client-go/applyconfigurations/core/v1"
+	metav1ac "k8s.io/client-go/applyconfigurations/meta/v1"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/util/csaupgrade"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// fieldManager is the field manager the managed Secret is applied with.
+const fieldManager = "sops-operator"
+
+// updateFieldManagers are the field managers of the operator's Update and
+// Create calls: fieldManager, and the one the API server derives from the
+// user agent for the versions that wrote without a field manager.
+var updateFieldManagers = sets.New(fieldManager, strings.SplitN(rest.DefaultKubernetesUserAgent(), "/", 2)[0])
+
+// upgradeManagedFields hands the fields the operator wrote to secret with
+// Update to the apply field manager. Without it the first apply after
+// switching to server-side apply would leave labels, annotations and keys
+// the operator no longer sets in place, owned by the old Update manager.
+func (r *SopsSecretReconciler) upgradeManagedFields(ctx context.Context, secret *corev1.Secret) error {
+	patch, err := csaupgrade.UpgradeManagedFieldsPatch(secret, updateFieldManagers, fieldManager)
+	if err != nil || patch == nil {
+		return err
+	}
+	return r.Patch(ctx, secret, client.RawPatch(types.JSONPatchType, patch))
+}
+
+// applySecret writes secret with server-side apply. Fields the operator sets
+// are taken over from other field managers that changed them, fields only
+// other managers set are kept. The UID and resourceVersion of the result are
+// filled in.
+func (r *SopsSecretReconciler) applySecret(ctx context.Context, secret *corev1.Secret) error {
+	apply := corev1ac.Secret(secret.Name, secret.Namespace).
+		WithLabels(secret.Labels).
+		WithAnnotations(secret.Annotations).
+		WithType(secret.Type)
+	if secret.Immutable != nil {
+		apply.WithImmutable(*secret.Immutable)
+	}
+	if len(secret.Data) > 0 {
+		apply.WithData(secret.Data)
+	}
+	if len(secret.StringData) > 0 {
+		apply.WithStringData(secret.StringData)
+	}
+	for _, ref := range secret.OwnerReferences {
+		owner := metav1ac.OwnerReference().
+			WithAPIVersion(ref.APIVersion).
+			WithKind(ref.Kind).
+			WithName(ref.Name).
+			WithUID(ref.UID)
+		if ref.Controller != nil {
+			owner.WithController(*ref.Controller)
+		}
+		if ref.BlockOwnerDeletion != nil {
+			owner.WithBlockOwnerDeletion(*ref.BlockOwnerDeletion)
+		}
+		apply.WithOwnerReferences(owner)
+	}
+
+	if err := r.Apply(ctx, apply, client.FieldOwner(fieldManager), client.ForceOwnership); err != nil {
+		return err
+	}
+	if apply.UID != nil {
+		secret.UID = *apply.UID
+	}
+	if apply.ResourceVersion != nil {
+		secret.ResourceVersion = *apply.ResourceVersion
+	}
+	return nil
+}
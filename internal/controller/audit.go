@@ -0,0 +1,107 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	secretsv1alpha1 "github.com/scalaric/sops-operator/api/v1alpha1"
+)
+
+// Outcomes of a decrypt attempt in an AuditEvent.
+const (
+	AuditOutcomeSuccess = "success"
+	AuditOutcomeFailure = "failure"
+)
+
+// AuditEvent records one decrypt attempt. It identifies the SopsSecret and
+// the outcome only, never decrypted values or error messages, which may
+// quote them.
+type AuditEvent struct {
+	Time      time.Time `json:"time"`
+	Namespace string    `json:"namespace"`
+	Name      string    `json:"name"`
+	Outcome   string    `json:"outcome"`
+	KeySource string    `json:"keySource,omitempty"`
+}
+
+// AuditLogger receives an AuditEvent for every decrypt attempt, for example
+// to forward it to a compliance sink.
+type AuditLogger interface {
+	Record(ctx context.Context, event AuditEvent)
+}
+
+// NoopAuditLogger drops all events. It is used when no audit logger is
+// configured.
+type NoopAuditLogger struct{}
+
+// Record does nothing.
+func (NoopAuditLogger) Record(_ context.Context, _ AuditEvent) {}
+
+// JSONAuditLogger writes every event as a line of JSON.
+type JSONAuditLogger struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// NewJSONAuditLogger returns an audit logger that writes to w.
+func NewJSONAuditLogger(w io.Writer) *JSONAuditLogger {
+	return &JSONAuditLogger{w: w}
+}
+
+// Record writes event to the underlying writer. Write errors are logged, a
+// failing sink does not hold back reconciles.
+func (l *JSONAuditLogger) Record(ctx context.Context, event AuditEvent) {
+	line, err := json.Marshal(event)
+	if err == nil {
+		l.mu.Lock()
+		_, err = l.w.Write(append(line, '\n'))
+		l.mu.Unlock()
+	}
+	if err != nil {
+		logf.FromContext(ctx).Error(err, "Failed to write audit event")
+	}
+}
+
+// audit records a decrypt attempt of the SopsSecret that failed with err, or
+// succeeded when err is nil, in the audit log and the decrypt counter.
+func (r *SopsSecretReconciler) audit(
+	ctx context.Context, sopsSecret *secretsv1alpha1.SopsSecret, plaintext bool, err error,
+) {
+	logger := r.AuditLogger
+	if logger == nil {
+		logger = NoopAuditLogger{}
+	}
+	outcome := AuditOutcomeSuccess
+	if err != nil {
+		outcome = AuditOutcomeFailure
+	}
+	decryptAttempts.WithLabelValues(r.metricNamespace(sopsSecret.Namespace), outcome).Inc()
+	logger.Record(ctx, AuditEvent{
+		Time:      r.now().UTC(),
+		Namespace: sopsSecret.Namespace,
+		Name:      sopsSecret.Name,
+		Outcome:   outcome,
+		KeySource: r.keySource(sopsSecret, plaintext),
+	})
+}
@@ -0,0 +1,64 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+// recordingAuditLogger keeps the events it receives.
+type recordingAuditLogger struct {
+	events []AuditEvent
+}
+
+func (l *recordingAuditLogger) Record(_ context.Context, event AuditEvent) {
+	l.events = append(l.events, event)
+}
+
+func TestJSONAuditLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewJSONAuditLogger(&buf)
+	at := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	logger.Record(context.Background(), AuditEvent{
+		Time: at, Namespace: "prod", Name: "db", Outcome: AuditOutcomeSuccess, KeySource: "global-env",
+	})
+	logger.Record(context.Background(), AuditEvent{
+		Time: at, Namespace: "prod", Name: "api", Outcome: AuditOutcomeFailure,
+	})
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("got %d lines, want one per event: %q", len(lines), buf.String())
+	}
+	want := `{"time":"2026-03-01T12:00:00Z","namespace":"prod","name":"db","outcome":"success","keySource":"global-env"}`
+	if lines[0] != want {
+		t.Errorf("first line = %s, want %s", lines[0], want)
+	}
+	var event AuditEvent
+	if err := json.Unmarshal([]byte(lines[1]), &event); err != nil {
+		t.Fatalf("second line is not JSON: %v", err)
+	}
+	if event.Name != "api" || event.Outcome != AuditOutcomeFailure || event.KeySource != "" {
+		t.Errorf("second event = %+v, want the failure of prod/api without a key source", event)
+	}
+}
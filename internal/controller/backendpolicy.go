@@ -0,0 +1,101 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	secretsv1alpha1 "github.com/scalaric/sops-operator/api/v1alpha1"
+	"github.com/scalaric/sops-operator/pkg/sops"
+)
+
+// requiredBackendLabel on a namespace lists the key backends, comma-separated,
+// that documents of the SopsSecrets in it may be encrypted with, e.g. "kms" or
+// "kms,gcp_kms".
+const requiredBackendLabel = "secrets.scalaric.io/required-backend"
+
+// backendPolicyViolation checks the document against the backend policy of
+// the SopsSecret's namespace. It returns why the document does not comply, or
+// "" when it does or the namespace has no policy.
+func (r *SopsSecretReconciler) backendPolicyViolation(
+	ctx context.Context, sopsSecret *secretsv1alpha1.SopsSecret, payload []byte,
+) (string, error) {
+	namespace := &corev1.Namespace{}
+	if err := r.Get(ctx, client.ObjectKey{Name: sopsSecret.Namespace}, namespace); err != nil {
+		if apierrors.IsNotFound(err) {
+			return "", nil
+		}
+		return "", err
+	}
+	value, ok := namespace.Labels[requiredBackendLabel]
+	if !ok {
+		return "", nil
+	}
+	allowed := parseBackendList(value)
+
+	metadata, err := sops.ParseSopsMetadata(payload)
+	if err != nil {
+		return fmt.Sprintf("Namespace %s requires backend %s, document has no sops metadata",
+			sopsSecret.Namespace, strings.Join(allowed, ", ")), nil
+	}
+	backends := metadata.Backends()
+	if len(backends) == 0 {
+		return fmt.Sprintf("Namespace %s requires backend %s, document has no keys",
+			sopsSecret.Namespace, strings.Join(allowed, ", ")), nil
+	}
+	for _, backend := range backends {
+		if !slices.Contains(allowed, backend) {
+			return fmt.Sprintf("Namespace %s requires backend %s, document is encrypted with %s",
+				sopsSecret.Namespace, strings.Join(allowed, ", "), strings.Join(backends, ", ")), nil
+		}
+	}
+	return "", nil
+}
+
+// parseBackendList splits a required-backend label value into backend names.
+func parseBackendList(value string) []string {
+	var backends []string
+	for _, backend := range strings.Split(value, ",") {
+		if backend = strings.TrimSpace(backend); backend != "" {
+			backends = append(backends, backend)
+		}
+	}
+	return backends
+}
+
+// sopsSecretsInNamespace enqueues all SopsSecrets in a namespace, so that a
+// changed backend policy is checked again.
+func (r *SopsSecretReconciler) sopsSecretsInNamespace(ctx context.Context, obj client.Object) []reconcile.Request {
+	list := &secretsv1alpha1.SopsSecretList{}
+	if err := r.List(ctx, list, client.InNamespace(obj.GetName())); err != nil {
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0, len(list.Items))
+	for _, item := range list.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&item)})
+	}
+	return requests
+}
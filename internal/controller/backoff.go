@@ -0,0 +1,75 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	secretsv1alpha1 "github.com/scalaric/sops-operator/api/v1alpha1"
+)
+
+// sopsSecretChanged lets through the events of a SopsSecret that change its
+// spec, labels or annotations, and its creation and deletion. Status writes
+// are dropped: every failed reconcile writes status.failureCount, and
+// reconciling again on that write would retry right away instead of after
+// the backoff. Setting the deletion timestamp bumps the generation.
+var sopsSecretChanged = predicate.Or(
+	predicate.GenerationChangedPredicate{},
+	predicate.LabelChangedPredicate{},
+	predicate.AnnotationChangedPredicate{},
+)
+
+// failureRequeue counts a reconcile that leaves the SopsSecret not Ready, or
+// Ready only with a stale Secret, in status.failureCount and returns how long
+// to back off before retrying. A new generation starts the count again. Once
+// the SopsSecret is Ready the count is reset and false is returned, as it is
+// when FailureBackoffBase is zero.
+func (r *SopsSecretReconciler) failureRequeue(sopsSecret *secretsv1alpha1.SopsSecret) (time.Duration, bool) {
+	conditions := sopsSecret.Status.Conditions
+	if meta.IsStatusConditionTrue(conditions, secretsv1alpha1.ConditionTypeReady) &&
+		!meta.IsStatusConditionTrue(conditions, secretsv1alpha1.ConditionTypeDegraded) {
+		sopsSecret.Status.FailureCount = 0
+		sopsSecret.Status.FailureGeneration = 0
+		return 0, false
+	}
+	if sopsSecret.Status.FailureGeneration != sopsSecret.Generation {
+		sopsSecret.Status.FailureCount = 0
+		sopsSecret.Status.FailureGeneration = sopsSecret.Generation
+	}
+	sopsSecret.Status.FailureCount++
+	if r.FailureBackoffBase <= 0 {
+		return 0, false
+	}
+	maxBackoff := r.FailureBackoffMax
+	if maxBackoff <= 0 {
+		maxBackoff = r.reconcileInterval(sopsSecret)
+	}
+	return failureBackoff(r.FailureBackoffBase, maxBackoff, sopsSecret.Status.FailureCount), true
+}
+
+// failureBackoff doubles base for every failure after the first, up to
+// maxBackoff.
+func failureBackoff(base, maxBackoff time.Duration, failures int32) time.Duration {
+	backoff := base
+	for i := int32(1); i < failures && backoff < maxBackoff; i++ {
+		backoff *= 2
+	}
+	return min(backoff, maxBackoff)
+}
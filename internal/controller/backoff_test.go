@@ -0,0 +1,97 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	secretsv1alpha1 "github.com/scalaric/sops-operator/api/v1alpha1"
+)
+
+func TestFailureBackoff(t *testing.T) {
+	tests := []struct {
+		failures int32
+		want     time.Duration
+	}{
+		{1, 10 * time.Second},
+		{2, 20 * time.Second},
+		{3, 40 * time.Second},
+		{4, 80 * time.Second},
+		{5, 160 * time.Second},
+		{6, 5 * time.Minute},
+		{1000, 5 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		if got := failureBackoff(10*time.Second, 5*time.Minute, tt.failures); got != tt.want {
+			t.Errorf("failureBackoff(%d) = %v, want %v", tt.failures, got, tt.want)
+		}
+	}
+}
+
+func TestSopsSecretChangedIgnoresStatusWrites(t *testing.T) {
+	old := &secretsv1alpha1.SopsSecret{ObjectMeta: metav1.ObjectMeta{
+		Name: "db", Namespace: "default", Generation: 3, ResourceVersion: "10",
+	}}
+
+	// A failed reconcile writes the status, which must not retry right away
+	failed := old.DeepCopy()
+	failed.ResourceVersion = "11"
+	failed.Status.FailureCount = 4
+	meta.SetStatusCondition(&failed.Status.Conditions, metav1.Condition{
+		Type: secretsv1alpha1.ConditionTypeReady, Status: metav1.ConditionFalse, Reason: "DecryptFailed",
+	})
+	if sopsSecretChanged.Update(event.UpdateEvent{ObjectOld: old, ObjectNew: failed}) {
+		t.Error("status write triggered a reconcile")
+	}
+
+	edited := old.DeepCopy()
+	edited.Generation++
+	if !sopsSecretChanged.Update(event.UpdateEvent{ObjectOld: old, ObjectNew: edited}) {
+		t.Error("spec change did not trigger a reconcile")
+	}
+	annotated := old.DeepCopy()
+	annotated.Annotations = map[string]string{"secrets.scalaric.io/recreate": "true"}
+	if !sopsSecretChanged.Update(event.UpdateEvent{ObjectOld: old, ObjectNew: annotated}) {
+		t.Error("annotation change did not trigger a reconcile")
+	}
+}
+
+func TestFailureRequeueResetsOnNewGeneration(t *testing.T) {
+	r := &SopsSecretReconciler{FailureBackoffBase: 10 * time.Second, FailureBackoffMax: 5 * time.Minute}
+	sopsSecret := &secretsv1alpha1.SopsSecret{ObjectMeta: metav1.ObjectMeta{Generation: 1}}
+
+	for _, want := range []time.Duration{10 * time.Second, 20 * time.Second, 40 * time.Second} {
+		if got, ok := r.failureRequeue(sopsSecret); !ok || got != want {
+			t.Errorf("failureRequeue() = %v, %v, want %v", got, ok, want)
+		}
+	}
+
+	sopsSecret.Generation++
+	if got, _ := r.failureRequeue(sopsSecret); got != 10*time.Second {
+		t.Errorf("failureRequeue() after a new generation = %v, want %v", got, 10*time.Second)
+	}
+	if sopsSecret.Status.FailureCount != 1 || sopsSecret.Status.FailureGeneration != 2 {
+		t.Errorf("status = %d failures at generation %d, want 1 at 2",
+			sopsSecret.Status.FailureCount, sopsSecret.Status.FailureGeneration)
+	}
+}
@@ -0,0 +1,90 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	secretsv1alpha1 "github.com/scalaric/sops-operator/api/v1alpha1"
+	"github.com/scalaric/sops-operator/pkg/sops"
+)
+
+const (
+	// backupSecretSuffix is appended to the SopsSecret name for the backup Secret.
+	backupSecretSuffix = "-backup"
+
+	// backupSecretKey holds the re-encrypted document in the backup Secret.
+	backupSecretKey = "backup.enc.yaml"
+)
+
+// backupSecretName returns the name of the backup Secret of a SopsSecret.
+func backupSecretName(sopsSecret *secretsv1alpha1.SopsSecret) string {
+	return sopsSecret.Name + backupSecretSuffix
+}
+
+// writeBackup re-encrypts the decrypted document to spec.backupRecipient and
+// stores the ciphertext in the backup Secret. The plaintext never leaves the
+// sops process.
+func (r *SopsSecretReconciler) writeBackup(ctx context.Context, sopsSecret *secretsv1alpha1.SopsSecret, decrypted *sops.DecryptedData) error {
+	if r.Encryptor == nil {
+		return errors.New("backups are not supported, no encryptor is configured")
+	}
+	encrypted, err := r.Encryptor.EncryptWithContext(ctx, decrypted.Document(), []string{sopsSecret.Spec.BackupRecipient})
+	if err != nil {
+		return err
+	}
+
+	backup := &corev1.Secret{}
+	err = r.Get(ctx, types.NamespacedName{Namespace: sopsSecret.Namespace, Name: backupSecretName(sopsSecret)}, backup)
+	if apierrors.IsNotFound(err) {
+		backup = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      backupSecretName(sopsSecret),
+				Namespace: sopsSecret.Namespace,
+				Labels: map[string]string{
+					managedByLabel:  "sops-operator",
+					sopsSecretLabel: sopsSecret.Name,
+				},
+				Annotations: map[string]string{
+					sourceAnnotation: fmt.Sprintf("%s/%s", sopsSecret.Namespace, sopsSecret.Name),
+				},
+			},
+			Type: corev1.SecretTypeOpaque,
+			Data: map[string][]byte{backupSecretKey: encrypted},
+		}
+		if err := controllerutil.SetControllerReference(sopsSecret, backup, r.Scheme); err != nil {
+			return err
+		}
+		return r.Create(ctx, backup)
+	}
+	if err != nil {
+		return err
+	}
+	if !metav1.IsControlledBy(backup, sopsSecret) {
+		return fmt.Errorf("secret %s exists and is not managed by this SopsSecret", backup.Name)
+	}
+	backup.Data = map[string][]byte{backupSecretKey: encrypted}
+	return r.Update(ctx, backup)
+}
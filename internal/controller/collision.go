@@ -0,0 +1,117 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	secretsv1alpha1 "github.com/scalaric/sops-operator/api/v1alpha1"
+)
+
+// NameCollision is a Secret name in a namespace that more than one SopsSecret
+// writes to.
+type NameCollision struct {
+	Namespace   string
+	SecretName  string
+	SopsSecrets []string
+}
+
+// FindNameCollisions returns the Secret names that more than one SopsSecret
+// resolves to in the same namespace, sorted by namespace and name. A
+// SopsSecret that writes to spec.targetNamespace is listed as namespace/name.
+// SopsSecrets with useGenerateName never collide and are skipped.
+func FindNameCollisions(ctx context.Context, c client.Reader) ([]NameCollision, error) {
+	list := &secretsv1alpha1.SopsSecretList{}
+	if err := c.List(ctx, list); err != nil {
+		return nil, err
+	}
+
+	type target struct{ namespace, name string }
+	owners := make(map[target][]string)
+	for i := range list.Items {
+		sopsSecret := &list.Items[i]
+		if sopsSecret.Spec.UseGenerateName {
+			continue
+		}
+		t := target{secretNamespace(sopsSecret), fixedSecretName(sopsSecret)}
+		name := sopsSecret.Name
+		if crossNamespace(sopsSecret) {
+			name = sopsSecret.Namespace + "/" + sopsSecret.Name
+		}
+		owners[t] = append(owners[t], name)
+	}
+
+	var collisions []NameCollision
+	for t, names := range owners {
+		if len(names) < 2 {
+			continue
+		}
+		slices.Sort(names)
+		collisions = append(collisions, NameCollision{Namespace: t.namespace, SecretName: t.name, SopsSecrets: names})
+	}
+	slices.SortFunc(collisions, func(a, b NameCollision) int {
+		return cmp.Or(cmp.Compare(a.Namespace, b.Namespace), cmp.Compare(a.SecretName, b.SecretName))
+	})
+	return collisions, nil
+}
+
+// NameCollisionChecker looks for Secret name collisions at startup and then
+// every Interval. Each collision is logged and exported as the
+// sopssecret_name_collisions metric. This is a safety net for clusters that
+// run without the admission webhook.
+type NameCollisionChecker struct {
+	Client   client.Reader
+	Interval time.Duration
+}
+
+// Start runs the check until ctx is canceled. It implements manager.Runnable.
+func (c *NameCollisionChecker) Start(ctx context.Context) error {
+	ticker := time.NewTicker(c.Interval)
+	defer ticker.Stop()
+	for {
+		c.check(ctx)
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// check runs FindNameCollisions once and publishes the result. A failed list
+// keeps the previous result.
+func (c *NameCollisionChecker) check(ctx context.Context) {
+	log := logf.FromContext(ctx).WithName("name-collisions")
+	collisions, err := FindNameCollisions(ctx, c.Client)
+	if err != nil {
+		log.Error(err, "Failed to list SopsSecrets")
+		return
+	}
+
+	nameCollisions.Reset()
+	for _, collision := range collisions {
+		log.Info("SopsSecrets write to the same Secret", "namespace", collision.Namespace,
+			"secret", collision.SecretName, "sopsSecrets", collision.SopsSecrets)
+		nameCollisions.WithLabelValues(collision.Namespace, collision.SecretName).Set(float64(len(collision.SopsSecrets)))
+	}
+}
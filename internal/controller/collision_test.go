@@ -0,0 +1,143 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/go-logr/logr/funcr"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	secretsv1alpha1 "github.com/scalaric/sops-operator/api/v1alpha1"
+)
+
+func newCollisionClient(t *testing.T, sopsSecrets ...*secretsv1alpha1.SopsSecret) client.Client {
+	t.Helper()
+	s := runtime.NewScheme()
+	if err := secretsv1alpha1.AddToScheme(s); err != nil {
+		t.Fatalf("AddToScheme() error = %v", err)
+	}
+	objects := make([]client.Object, len(sopsSecrets))
+	for i, sopsSecret := range sopsSecrets {
+		objects[i] = sopsSecret
+	}
+	return fake.NewClientBuilder().WithScheme(s).WithObjects(objects...).Build()
+}
+
+func collisionSopsSecret(namespace, name, secretName string, generateName bool) *secretsv1alpha1.SopsSecret {
+	return &secretsv1alpha1.SopsSecret{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec:       secretsv1alpha1.SopsSecretSpec{SecretName: secretName, UseGenerateName: generateName},
+	}
+}
+
+func TestFindNameCollisions(t *testing.T) {
+	tests := []struct {
+		name        string
+		sopsSecrets []*secretsv1alpha1.SopsSecret
+		want        []NameCollision
+	}{
+		{
+			name: "distinct names",
+			sopsSecrets: []*secretsv1alpha1.SopsSecret{
+				collisionSopsSecret("prod", "db", "", false),
+				collisionSopsSecret("prod", "api", "api-credentials", false),
+				// The same name in another namespace is a different Secret
+				collisionSopsSecret("dev", "db", "", false),
+				// Generated names never collide
+				collisionSopsSecret("prod", "db-generated", "db", true),
+			},
+		},
+		{
+			name: "colliding names",
+			sopsSecrets: []*secretsv1alpha1.SopsSecret{
+				collisionSopsSecret("prod", "db", "", false),
+				collisionSopsSecret("prod", "db-v2", "db", false),
+				collisionSopsSecret("prod", "legacy", "shared", false),
+				collisionSopsSecret("prod", "modern", "shared", false),
+				collisionSopsSecret("prod", "other", "shared", false),
+				collisionSopsSecret("dev", "db", "", false),
+			},
+			want: []NameCollision{
+				{Namespace: "prod", SecretName: "db", SopsSecrets: []string{"db", "db-v2"}},
+				{Namespace: "prod", SecretName: "shared", SopsSecrets: []string{"legacy", "modern", "other"}},
+			},
+		},
+		{
+			name: "target namespace",
+			sopsSecrets: func() []*secretsv1alpha1.SopsSecret {
+				cross := collisionSopsSecret("secrets", "db", "", false)
+				cross.Spec.TargetNamespace = "prod"
+				return []*secretsv1alpha1.SopsSecret{cross, collisionSopsSecret("prod", "db", "", false)}
+			}(),
+			want: []NameCollision{
+				{Namespace: "prod", SecretName: "db", SopsSecrets: []string{"db", "secrets/db"}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FindNameCollisions(context.Background(), newCollisionClient(t, tt.sopsSecrets...))
+			if err != nil {
+				t.Fatalf("FindNameCollisions() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FindNameCollisions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNameCollisionCheckerCheck(t *testing.T) {
+	var out strings.Builder
+	ctx := logr.NewContext(context.Background(), funcr.New(func(prefix, args string) {
+		out.WriteString(prefix + " " + args + "\n")
+	}, funcr.Options{}))
+
+	c := &NameCollisionChecker{Client: newCollisionClient(t,
+		collisionSopsSecret("prod", "db", "", false),
+		collisionSopsSecret("prod", "db-v2", "db", false),
+	)}
+	c.check(ctx)
+	if got := testutil.ToFloat64(nameCollisions.WithLabelValues("prod", "db")); got != 2 {
+		t.Errorf("sopssecret_name_collisions{prod,db} = %v, want 2", got)
+	}
+	if logged := out.String(); !strings.Contains(logged, "SopsSecrets write to the same Secret") ||
+		!strings.Contains(logged, `"db-v2"`) {
+		t.Errorf("collision not logged:\n%s", logged)
+	}
+
+	// Resolving the collision clears its series
+	out.Reset()
+	c.Client = newCollisionClient(t, collisionSopsSecret("prod", "db", "", false))
+	c.check(ctx)
+	if got := testutil.CollectAndCount(nameCollisions); got != 0 {
+		t.Errorf("sopssecret_name_collisions has %d series, want 0", got)
+	}
+	if logged := out.String(); logged != "" {
+		t.Errorf("unexpected log output:\n%s", logged)
+	}
+}
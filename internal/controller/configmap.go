@@ -0,0 +1,131 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	secretsv1alpha1 "github.com/scalaric/sops-operator/api/v1alpha1"
+)
+
+const (
+	// keyListSuffix is appended to the Secret name for the key list ConfigMap.
+	keyListSuffix = "-keys"
+
+	// keyListKey holds the newline-separated key names in the key list ConfigMap.
+	keyListKey = "keys"
+)
+
+// reconcileConfigMap writes spec.configMapData to a ConfigMap named like the
+// Secret, or removes that ConfigMap once configMapData is empty.
+func (r *SopsSecretReconciler) reconcileConfigMap(ctx context.Context, sopsSecret *secretsv1alpha1.SopsSecret) error {
+	name := r.secretNamePrefix(sopsSecret)
+	if len(sopsSecret.Spec.ConfigMapData) == 0 {
+		return r.deleteOwnedConfigMap(ctx, sopsSecret, name)
+	}
+	return r.writeConfigMap(ctx, sopsSecret, name, sopsSecret.Spec.ConfigMapData)
+}
+
+// reconcileKeyList publishes the sorted key names of secret, never their
+// values, in the <secret>-keys ConfigMap when spec.publishKeyList is set, and
+// removes that ConfigMap otherwise.
+func (r *SopsSecretReconciler) reconcileKeyList(ctx context.Context, sopsSecret *secretsv1alpha1.SopsSecret, secret *corev1.Secret) error {
+	name := keyListConfigMapName(r.secretNamePrefix(sopsSecret))
+	if !sopsSecret.Spec.PublishKeyList {
+		return r.deleteOwnedConfigMap(ctx, sopsSecret, name)
+	}
+	return r.writeConfigMap(ctx, sopsSecret, name, map[string]string{
+		keyListKey: strings.Join(sortedKeys(secret.Data), "\n"),
+	})
+}
+
+// keyListConfigMapName returns the name of the key list ConfigMap for a Secret.
+func keyListConfigMapName(secretName string) string {
+	return secretName + keyListSuffix
+}
+
+// deleteConfigMaps removes the ConfigMaps owned by the SopsSecret, if any.
+func (r *SopsSecretReconciler) deleteConfigMaps(ctx context.Context, sopsSecret *secretsv1alpha1.SopsSecret) error {
+	name := r.secretNamePrefix(sopsSecret)
+	if err := r.deleteOwnedConfigMap(ctx, sopsSecret, name); err != nil {
+		return err
+	}
+	return r.deleteOwnedConfigMap(ctx, sopsSecret, keyListConfigMapName(name))
+}
+
+// writeConfigMap creates or updates the ConfigMap name owned by the SopsSecret
+// with data. It refuses to take over a ConfigMap the SopsSecret does not own.
+func (r *SopsSecretReconciler) writeConfigMap(
+	ctx context.Context, sopsSecret *secretsv1alpha1.SopsSecret, name string, data map[string]string,
+) error {
+	configMap := &corev1.ConfigMap{}
+	err := r.Get(ctx, types.NamespacedName{Namespace: sopsSecret.Namespace, Name: name}, configMap)
+	if apierrors.IsNotFound(err) {
+		configMap = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: sopsSecret.Namespace,
+				Labels: map[string]string{
+					managedByLabel:  "sops-operator",
+					sopsSecretLabel: sopsSecret.Name,
+				},
+				Annotations: map[string]string{
+					sourceAnnotation: fmt.Sprintf("%s/%s", sopsSecret.Namespace, sopsSecret.Name),
+				},
+			},
+			Data: data,
+		}
+		if err := controllerutil.SetControllerReference(sopsSecret, configMap, r.Scheme); err != nil {
+			return err
+		}
+		return r.Create(ctx, configMap)
+	}
+	if err != nil {
+		return err
+	}
+	if !metav1.IsControlledBy(configMap, sopsSecret) {
+		return fmt.Errorf("configmap %s exists and is not managed by this SopsSecret", name)
+	}
+	configMap.Data = data
+	return r.Update(ctx, configMap)
+}
+
+// deleteOwnedConfigMap removes the ConfigMap name if the SopsSecret owns it.
+// A ConfigMap of the same name that the SopsSecret does not own is left alone.
+func (r *SopsSecretReconciler) deleteOwnedConfigMap(ctx context.Context, sopsSecret *secretsv1alpha1.SopsSecret, name string) error {
+	configMap := &corev1.ConfigMap{}
+	err := r.Get(ctx, types.NamespacedName{Namespace: sopsSecret.Namespace, Name: name}, configMap)
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if !metav1.IsControlledBy(configMap, sopsSecret) {
+		return nil
+	}
+	return client.IgnoreNotFound(r.Delete(ctx, configMap))
+}
@@ -0,0 +1,67 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	secretsv1alpha1 "github.com/scalaric/sops-operator/api/v1alpha1"
+)
+
+// acceptSecretsFromAnnotation on a namespace lists, separated by commas, the
+// namespaces whose SopsSecrets may write Secrets into it. "*" accepts every
+// namespace. The operator's ClusterRole may write Secrets in every namespace,
+// so without this opt-in anyone allowed to create a SopsSecret could write
+// Secrets into namespaces they cannot access themselves, such as kube-system.
+const acceptSecretsFromAnnotation = "secrets.scalaric.io/accept-secrets-from"
+
+// errCrossNamespaceRefused marks a Secret that may not be written into
+// another namespace.
+var errCrossNamespaceRefused = errors.New("cross-namespace write refused")
+
+// crossNamespaceAllowed returns an error wrapping errCrossNamespaceRefused
+// unless the operator runs with AllowCrossNamespace and namespace ns accepts
+// Secrets from the namespace of sopsSecret in its accept-secrets-from
+// annotation.
+func (r *SopsSecretReconciler) crossNamespaceAllowed(
+	ctx context.Context, sopsSecret *secretsv1alpha1.SopsSecret, ns string,
+) error {
+	if !r.AllowCrossNamespace {
+		return fmt.Errorf("%w: writing Secrets into other namespaces is disabled in the operator", errCrossNamespaceRefused)
+	}
+	namespace := &corev1.Namespace{}
+	if err := r.Get(ctx, client.ObjectKey{Name: ns}, namespace); err != nil {
+		if apierrors.IsNotFound(err) {
+			return fmt.Errorf("%w: namespace %s does not exist", errCrossNamespaceRefused, ns)
+		}
+		return err
+	}
+	for accepted := range strings.SplitSeq(namespace.Annotations[acceptSecretsFromAnnotation], ",") {
+		if accepted = strings.TrimSpace(accepted); accepted == "*" || accepted == sopsSecret.Namespace {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: namespace %s does not accept Secrets from namespace %s in its %s annotation",
+		errCrossNamespaceRefused, ns, sopsSecret.Namespace, acceptSecretsFromAnnotation)
+}
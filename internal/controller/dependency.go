@@ -0,0 +1,128 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	secretsv1alpha1 "github.com/scalaric/sops-operator/api/v1alpha1"
+)
+
+const (
+	// dependsOnAnnotation names an object in the same namespace that must be
+	// ready before the SopsSecret is reconciled. The value is "name" or
+	// "SopsSecret/name" for another SopsSecret, or "Secret/name" for a Secret.
+	dependsOnAnnotation = "secrets.scalaric.io/depends-on"
+
+	// dependencyRequeueInterval is how often a waiting SopsSecret re-checks its
+	// dependency, in addition to the watch on the dependency itself.
+	dependencyRequeueInterval = 30 * time.Second
+
+	kindSopsSecret = "SopsSecret"
+	kindSecret     = "Secret"
+)
+
+// dependency is a parsed depends-on annotation.
+type dependency struct {
+	Kind string
+	Name string
+}
+
+func (d dependency) String() string {
+	return d.Kind + "/" + d.Name
+}
+
+// parseDependency parses the value of the depends-on annotation.
+func parseDependency(value string) (dependency, error) {
+	kind, name, found := strings.Cut(strings.TrimSpace(value), "/")
+	if !found {
+		kind, name = kindSopsSecret, kind
+	}
+	if name == "" {
+		return dependency{}, fmt.Errorf("invalid %s annotation %q: missing name", dependsOnAnnotation, value)
+	}
+	switch kind {
+	case kindSopsSecret, kindSecret:
+		return dependency{Kind: kind, Name: name}, nil
+	default:
+		return dependency{}, fmt.Errorf("invalid %s annotation %q: kind must be %s or %s",
+			dependsOnAnnotation, value, kindSopsSecret, kindSecret)
+	}
+}
+
+// dependencyReady reports whether dep is ready. A SopsSecret is ready when its
+// Ready condition is True for the current generation, a Secret when it exists.
+// The returned message explains why a dependency is not ready.
+func (r *SopsSecretReconciler) dependencyReady(ctx context.Context, namespace string, dep dependency) (bool, string, error) {
+	key := types.NamespacedName{Name: dep.Name, Namespace: namespace}
+
+	var obj client.Object = &corev1.Secret{}
+	if dep.Kind == kindSopsSecret {
+		obj = &secretsv1alpha1.SopsSecret{}
+	}
+	if err := r.Get(ctx, key, obj); err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, fmt.Sprintf("Dependency %s does not exist", dep), nil
+		}
+		return false, "", err
+	}
+
+	if dependent, ok := obj.(*secretsv1alpha1.SopsSecret); ok {
+		cond := meta.FindStatusCondition(dependent.Status.Conditions, secretsv1alpha1.ConditionTypeReady)
+		if cond == nil || cond.Status != metav1.ConditionTrue || cond.ObservedGeneration != dependent.Generation {
+			return false, fmt.Sprintf("Dependency %s is not ready", dep), nil
+		}
+	}
+	return true, "", nil
+}
+
+// dependentsOf returns a map function that enqueues the SopsSecrets in the
+// object's namespace whose depends-on annotation names it.
+func (r *SopsSecretReconciler) dependentsOf(kind string) handler.MapFunc {
+	return func(ctx context.Context, obj client.Object) []reconcile.Request {
+		list := &secretsv1alpha1.SopsSecretList{}
+		if err := r.List(ctx, list, client.InNamespace(obj.GetNamespace())); err != nil {
+			return nil
+		}
+
+		var requests []reconcile.Request
+		for _, item := range list.Items {
+			value, ok := item.Annotations[dependsOnAnnotation]
+			if !ok {
+				continue
+			}
+			dep, err := parseDependency(value)
+			if err != nil || dep.Kind != kind || dep.Name != obj.GetName() {
+				continue
+			}
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&item)})
+		}
+		return requests
+	}
+}
@@ -0,0 +1,57 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	secretsv1alpha1 "github.com/scalaric/sops-operator/api/v1alpha1"
+)
+
+// pendingRequeueInterval is how often a pending SopsSecret retries its
+// decrypt during the initial grace period.
+const pendingRequeueInterval = 5 * time.Second
+
+// initialGraceRemaining returns how much of InitialGracePeriod is left for a
+// SopsSecret that was never decrypted. A GitOps sync may apply it before the
+// key Secret it needs, so such failures are retried quietly for a while.
+func (r *SopsSecretReconciler) initialGraceRemaining(sopsSecret *secretsv1alpha1.SopsSecret) (time.Duration, bool) {
+	if r.InitialGracePeriod <= 0 || sopsSecret.Status.LastDecryptedHash != "" {
+		return 0, false
+	}
+	remaining := sopsSecret.CreationTimestamp.Add(r.InitialGracePeriod).Sub(r.now())
+	return remaining, remaining > 0
+}
+
+// reconcilePending records a decrypt failure within the initial grace period
+// as Pending, without a warning event, and retries it.
+func (r *SopsSecretReconciler) reconcilePending(
+	ctx context.Context, sopsSecret *secretsv1alpha1.SopsSecret, cause error, remaining time.Duration,
+) (ctrl.Result, error) {
+	logf.FromContext(ctx).V(1).Info("Decrypt failed within the initial grace period, retrying",
+		"error", cause.Error(), "remaining", remaining)
+	msg := fmt.Sprintf("Retrying for %s after creation: %v", r.InitialGracePeriod, cause)
+	r.setCondition(sopsSecret, secretsv1alpha1.ConditionTypePending, metav1.ConditionTrue, ReasonPending, msg)
+	r.setCondition(sopsSecret, secretsv1alpha1.ConditionTypeReady, metav1.ConditionFalse, ReasonPending, msg)
+	return r.updateStatusAndRequeue(ctx, sopsSecret, min(remaining, pendingRequeueInterval))
+}
@@ -0,0 +1,60 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// keyChangeSummary describes the keys added and removed between the previous
+// and current sorted key names, e.g. "2 keys added (a, b), 1 key removed (c)". It
+// reports false when nothing changed or no previous keys were recorded.
+func keyChangeSummary(previous, current []string) (string, bool) {
+	if len(previous) == 0 {
+		return "", false
+	}
+	var added, removed []string
+	for _, key := range current {
+		if !slices.Contains(previous, key) {
+			added = append(added, key)
+		}
+	}
+	for _, key := range previous {
+		if !slices.Contains(current, key) {
+			removed = append(removed, key)
+		}
+	}
+
+	var parts []string
+	if len(added) > 0 {
+		parts = append(parts, fmt.Sprintf("%d %s added (%s)", len(added), keysNoun(len(added)), strings.Join(added, ", ")))
+	}
+	if len(removed) > 0 {
+		parts = append(parts, fmt.Sprintf("%d %s removed (%s)", len(removed), keysNoun(len(removed)), strings.Join(removed, ", ")))
+	}
+	return strings.Join(parts, ", "), len(parts) > 0
+}
+
+// keysNoun returns "key" or "keys" for n keys.
+func keysNoun(n int) string {
+	if n == 1 {
+		return "key"
+	}
+	return "keys"
+}
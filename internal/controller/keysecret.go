@@ -0,0 +1,100 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	secretsv1alpha1 "github.com/scalaric/sops-operator/api/v1alpha1"
+	"github.com/scalaric/sops-operator/pkg/sops"
+)
+
+const (
+	// ageKeySecretAnnotation names a Secret in the same namespace holding the
+	// AGE private key for this SopsSecret, as "name/key". The key defaults to
+	// defaultAgeKeySecretKey when only the name is given.
+	ageKeySecretAnnotation = "secrets.scalaric.io/age-key-secret"
+
+	defaultAgeKeySecretKey = "age.agekey"
+)
+
+// keySource returns where the keys decryptorFor picks for the SopsSecret
+// come from, for status.keySource.
+func (r *SopsSecretReconciler) keySource(sopsSecret *secretsv1alpha1.SopsSecret, plaintext bool) string {
+	if plaintext {
+		return ""
+	}
+	if _, ok := sopsSecret.Annotations[ageKeySecretAnnotation]; ok {
+		return secretsv1alpha1.KeySourceAnnotation
+	}
+	return r.GlobalKeySource
+}
+
+// decryptorFor returns the decryptor for the SopsSecret: a passthrough for
+// allowed plaintext, one built from the key referenced by the age-key-secret
+// annotation, or the operator's decryptor.
+func (r *SopsSecretReconciler) decryptorFor(
+	ctx context.Context, sopsSecret *secretsv1alpha1.SopsSecret, plaintext bool,
+) (sops.DecryptorInterface, error) {
+	if plaintext {
+		return plaintextDecryptor{}, nil
+	}
+	ref, ok := sopsSecret.Annotations[ageKeySecretAnnotation]
+	if !ok {
+		return r.Decryptor, nil
+	}
+
+	name, key, found := strings.Cut(strings.TrimSpace(ref), "/")
+	if !found {
+		key = defaultAgeKeySecretKey
+	}
+	if name == "" || key == "" {
+		return nil, fmt.Errorf("invalid %s annotation %q: expected name or name/key", ageKeySecretAnnotation, ref)
+	}
+
+	keySecret := &corev1.Secret{}
+	if err := r.Get(ctx, types.NamespacedName{Namespace: sopsSecret.Namespace, Name: name}, keySecret); err != nil {
+		return nil, fmt.Errorf("failed to get AGE key Secret %s: %w", name, err)
+	}
+	keys := parseKeyData(keySecret.Data[key])
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("AGE key Secret %s has no key in %q", name, key)
+	}
+
+	newDecryptor := r.NewDecryptor
+	if newDecryptor == nil {
+		newDecryptor = func(ageKeys []string) sops.DecryptorInterface { return sops.NewDecryptor(ageKeys) }
+	}
+	return newDecryptor(keys), nil
+}
+
+// parseKeyData splits an AGE key file into keys, dropping empty lines and comments.
+func parseKeyData(data []byte) []string {
+	var keys []string
+	for line := range strings.SplitSeq(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" && !strings.HasPrefix(line, "#") {
+			keys = append(keys, line)
+		}
+	}
+	return keys
+}
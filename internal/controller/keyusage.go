@@ -0,0 +1,81 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	secretsv1alpha1 "github.com/scalaric/sops-operator/api/v1alpha1"
+	"github.com/scalaric/sops-operator/pkg/sops"
+)
+
+// KeyUsagePath is where the key usage endpoint is served on the metrics server.
+const KeyUsagePath = "/debug/key-usage"
+
+// SopsSecretsUsingKey returns the SopsSecrets whose inline document is
+// encrypted to the key with the given identifier (AGE recipient, PGP
+// fingerprint, KMS ARN, ...), sorted by namespace and name. An empty
+// namespace searches all namespaces. Documents read from encryptedFromFile
+// are not inspected.
+func SopsSecretsUsingKey(ctx context.Context, c client.Reader, namespace, keyID string) ([]types.NamespacedName, error) {
+	list := &secretsv1alpha1.SopsSecretList{}
+	if err := c.List(ctx, list, client.InNamespace(namespace)); err != nil {
+		return nil, err
+	}
+
+	matches := []types.NamespacedName{}
+	for i := range list.Items {
+		sopsSecret := &list.Items[i]
+		metadata, err := sops.ParseSopsMetadata([]byte(sopsSecret.Spec.SopsSecret))
+		if err != nil {
+			continue
+		}
+		if metadata.UsesKey(keyID) {
+			matches = append(matches, client.ObjectKeyFromObject(sopsSecret))
+		}
+	}
+	slices.SortFunc(matches, func(a, b types.NamespacedName) int {
+		return strings.Compare(a.String(), b.String())
+	})
+	return matches, nil
+}
+
+// NewKeyUsageHandler serves SopsSecretsUsingKey as JSON. The key is passed in
+// the key query parameter, an optional namespace parameter narrows the search.
+func NewKeyUsageHandler(c client.Reader) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		keyID := req.URL.Query().Get("key")
+		if keyID == "" {
+			http.Error(w, "missing key query parameter", http.StatusBadRequest)
+			return
+		}
+		matches, err := SopsSecretsUsingKey(req.Context(), c, req.URL.Query().Get("namespace"), keyID)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(matches)
+	})
+}
@@ -0,0 +1,130 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	secretsv1alpha1 "github.com/scalaric/sops-operator/api/v1alpha1"
+)
+
+const (
+	retiredRecipient = "age1retired"
+	currentRecipient = "age1current"
+	kmsARN           = "arn:aws:kms:eu-west-1:111122223333:key/test"
+)
+
+func newKeyUsageClient(t *testing.T) client.Client {
+	t.Helper()
+	s := runtime.NewScheme()
+	if err := secretsv1alpha1.AddToScheme(s); err != nil {
+		t.Fatalf("AddToScheme() error = %v", err)
+	}
+
+	sopsSecret := func(namespace, name, document string) *secretsv1alpha1.SopsSecret {
+		return &secretsv1alpha1.SopsSecret{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec:       secretsv1alpha1.SopsSecretSpec{SopsSecret: document},
+		}
+	}
+	return fake.NewClientBuilder().WithScheme(s).WithObjects(
+		sopsSecret("prod", "retired", "sops:\n    age:\n        - recipient: "+retiredRecipient+"\n    mac: test\n"),
+		sopsSecret("dev", "retired-group", "sops:\n    key_groups:\n        - age:\n            - recipient: "+retiredRecipient+"\n    mac: test\n"),
+		sopsSecret("prod", "current", "sops:\n    age:\n        - recipient: "+currentRecipient+"\n    mac: test\n"),
+		sopsSecret("prod", "kms", "sops:\n    kms:\n        - arn: "+kmsARN+"\n    mac: test\n"),
+		sopsSecret("prod", "file", ""),
+	).Build()
+}
+
+func TestSopsSecretsUsingKey(t *testing.T) {
+	c := newKeyUsageClient(t)
+
+	tests := []struct {
+		name      string
+		namespace string
+		keyID     string
+		want      []types.NamespacedName
+	}{
+		{
+			name:  "age recipient across namespaces",
+			keyID: retiredRecipient,
+			want:  []types.NamespacedName{{Namespace: "dev", Name: "retired-group"}, {Namespace: "prod", Name: "retired"}},
+		},
+		{
+			name:      "age recipient in one namespace",
+			namespace: "prod",
+			keyID:     retiredRecipient,
+			want:      []types.NamespacedName{{Namespace: "prod", Name: "retired"}},
+		},
+		{
+			name:  "kms arn",
+			keyID: kmsARN,
+			want:  []types.NamespacedName{{Namespace: "prod", Name: "kms"}},
+		},
+		{
+			name:  "unused key",
+			keyID: "age1unused",
+			want:  []types.NamespacedName{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := SopsSecretsUsingKey(context.Background(), c, tt.namespace, tt.keyID)
+			if err != nil {
+				t.Fatalf("SopsSecretsUsingKey() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SopsSecretsUsingKey() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestKeyUsageHandler(t *testing.T) {
+	handler := NewKeyUsageHandler(newKeyUsageClient(t))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, KeyUsagePath+"?key="+currentRecipient, nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusOK)
+	}
+	var got []types.NamespacedName
+	if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+		t.Fatalf("invalid response %q: %v", rec.Body.String(), err)
+	}
+	if want := []types.NamespacedName{{Namespace: "prod", Name: "current"}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("response = %v, want %v", got, want)
+	}
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, KeyUsagePath, nil))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("status without key = %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+}
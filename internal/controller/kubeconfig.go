@@ -0,0 +1,57 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"errors"
+	"fmt"
+
+	"k8s.io/client-go/tools/clientcmd"
+
+	secretsv1alpha1 "github.com/scalaric/sops-operator/api/v1alpha1"
+	"github.com/scalaric/sops-operator/pkg/sops"
+)
+
+// kubeconfigKeys are the Secret keys checked by spec.validateKubeconfig, in
+// the order they are looked up.
+var kubeconfigKeys = []string{"kubeconfig", "value"}
+
+// validateKubeconfig checks that the kubeconfig in the decrypted document
+// loads and has a usable current context. Load errors may quote the
+// plaintext, so only the key and structural problems are reported.
+func validateKubeconfig(sopsSecret *secretsv1alpha1.SopsSecret, decrypted *sops.DecryptedData) error {
+	for _, key := range kubeconfigKeys {
+		value, ok := decrypted.Data[key]
+		if !ok {
+			continue
+		}
+		if sopsSecret.Spec.Format != secretsv1alpha1.FormatCRD {
+			if unwrapped := wrappedString(key, value); unwrapped != "" {
+				value = []byte(unwrapped)
+			}
+		}
+		config, err := clientcmd.Load(value)
+		if err != nil {
+			return fmt.Errorf("key %s does not hold a kubeconfig", key)
+		}
+		if err := clientcmd.ConfirmUsable(*config, ""); err != nil {
+			return fmt.Errorf("key %s does not hold a usable kubeconfig: %w", key, err)
+		}
+		return nil
+	}
+	return errors.New("document has neither a kubeconfig nor a value key")
+}
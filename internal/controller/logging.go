@@ -0,0 +1,138 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"sort"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/scalaric/sops-operator/pkg/sops"
+)
+
+// RedactedValue replaces raw secret bytes in log output.
+const RedactedValue = "[REDACTED]"
+
+// NewRedactingLogger wraps logger so that values which may carry plaintext
+// (decrypted data, Secrets, raw byte maps) never reach the underlying sink.
+// Decrypted data and byte maps are logged as their key names, Secrets as
+// namespace/name. This is a safety net, callers should still log key names
+// via redactedKeys rather than passing values.
+func NewRedactingLogger(logger logr.Logger) logr.Logger {
+	sink := logger.GetSink()
+	if sink == nil {
+		return logger
+	}
+	// Account for the extra frame added by redactingSink
+	if cd, ok := sink.(logr.CallDepthLogSink); ok {
+		sink = cd.WithCallDepth(1)
+	}
+	return logr.New(&redactingSink{sink: sink})
+}
+
+// redactingSink is a logr.LogSink that scrubs sensitive values before
+// delegating to the wrapped sink.
+type redactingSink struct {
+	sink logr.LogSink
+}
+
+var (
+	_ logr.LogSink          = &redactingSink{}
+	_ logr.CallDepthLogSink = &redactingSink{}
+)
+
+// Init is a no-op, the wrapped sink was initialized by its own logger.
+func (s *redactingSink) Init(logr.RuntimeInfo) {}
+
+func (s *redactingSink) Enabled(level int) bool {
+	return s.sink.Enabled(level)
+}
+
+func (s *redactingSink) Info(level int, msg string, keysAndValues ...any) {
+	s.sink.Info(level, msg, redactValues(keysAndValues)...)
+}
+
+func (s *redactingSink) Error(err error, msg string, keysAndValues ...any) {
+	s.sink.Error(err, msg, redactValues(keysAndValues)...)
+}
+
+func (s *redactingSink) WithValues(keysAndValues ...any) logr.LogSink {
+	return &redactingSink{sink: s.sink.WithValues(redactValues(keysAndValues)...)}
+}
+
+func (s *redactingSink) WithName(name string) logr.LogSink {
+	return &redactingSink{sink: s.sink.WithName(name)}
+}
+
+func (s *redactingSink) WithCallDepth(depth int) logr.LogSink {
+	if cd, ok := s.sink.(logr.CallDepthLogSink); ok {
+		return &redactingSink{sink: cd.WithCallDepth(depth)}
+	}
+	return s
+}
+
+// redactValues returns a copy of keysAndValues with sensitive values replaced.
+func redactValues(keysAndValues []any) []any {
+	redacted := make([]any, len(keysAndValues))
+	for i, v := range keysAndValues {
+		redacted[i] = redactValue(v)
+	}
+	return redacted
+}
+
+func redactValue(v any) any {
+	switch val := v.(type) {
+	case sops.DecryptedData:
+		return redactedKeys(val)
+	case *sops.DecryptedData:
+		if val == nil {
+			return nil
+		}
+		return redactedKeys(*val)
+	case corev1.Secret:
+		return client.ObjectKeyFromObject(&val).String()
+	case *corev1.Secret:
+		if val == nil {
+			return nil
+		}
+		return client.ObjectKeyFromObject(val).String()
+	case map[string][]byte:
+		return sortedKeys(val)
+	case []byte:
+		return RedactedValue
+	default:
+		return v
+	}
+}
+
+// redactedKeys returns the sorted key names of decrypted data, for logging
+// in place of the values.
+func redactedKeys(data sops.DecryptedData) []string {
+	return data.Keys()
+}
+
+// sortedKeys returns the keys of m in sorted order.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,90 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr/funcr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/scalaric/sops-operator/pkg/sops"
+)
+
+func TestRedactingLogger(t *testing.T) {
+	const plaintext = "hunter2-plaintext"
+
+	decrypted := sops.DecryptedData{
+		Data:       map[string][]byte{"password": []byte(plaintext), "username": []byte("admin")},
+		StringData: map[string]string{"password": plaintext, "username": "admin"},
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "prod"},
+		Data:       map[string][]byte{"password": []byte(plaintext)},
+	}
+
+	var out strings.Builder
+	logger := NewRedactingLogger(funcr.New(func(prefix, args string) {
+		out.WriteString(prefix + " " + args + "\n")
+	}, funcr.Options{}))
+
+	logger.Info("decrypted", "data", decrypted)
+	logger.Info("decrypted pointer", "data", &decrypted)
+	logger.Info("secret", "secret", secret, "value", *secret)
+	logger.Info("raw", "data", secret.Data, "bytes", []byte(plaintext))
+	logger.Error(errors.New("boom"), "failed", "data", decrypted)
+	logger.WithValues("data", decrypted).WithName("child").Info("with values")
+
+	logged := out.String()
+	if strings.Contains(logged, plaintext) || strings.Contains(logged, "admin") {
+		t.Fatalf("log output leaked plaintext:\n%s", logged)
+	}
+	for _, want := range []string{`["password" "username"]`, "prod/db", RedactedValue} {
+		if !strings.Contains(logged, want) {
+			t.Errorf("log output missing %q:\n%s", want, logged)
+		}
+	}
+}
+
+func TestRedactingLoggerPassesThroughOtherValues(t *testing.T) {
+	var out strings.Builder
+	logger := NewRedactingLogger(funcr.New(func(prefix, args string) {
+		out.WriteString(args)
+	}, funcr.Options{}))
+
+	logger.Info("plain", "name", "my-secret", "count", 3)
+
+	if got := out.String(); !strings.Contains(got, `"name"="my-secret"`) || !strings.Contains(got, `"count"=3`) {
+		t.Errorf("unexpected log output: %s", got)
+	}
+}
+
+func TestRedactedKeys(t *testing.T) {
+	data := sops.DecryptedData{
+		Data:       map[string][]byte{"b": []byte("2"), "a": []byte("1")},
+		StringData: map[string]string{"a": "1", "c": "3"},
+	}
+
+	want := []string{"a", "b", "c"}
+	if got := redactedKeys(data); !reflect.DeepEqual(got, want) {
+		t.Errorf("redactedKeys() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,51 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	secretsv1alpha1 "github.com/scalaric/sops-operator/api/v1alpha1"
+	"github.com/scalaric/sops-operator/pkg/sops"
+)
+
+// checkMAC sets the WeakMacWarning condition when the document was written by
+// a sops release older than MinSopsVersion or its MAC covers only the
+// encrypted values. It never blocks the Secret from being written.
+func (r *SopsSecretReconciler) checkMAC(sopsSecret *secretsv1alpha1.SopsSecret, payload []byte) {
+	var problems []string
+	if r.MinSopsVersion != "" {
+		if metadata, err := sops.ParseSopsMetadata(payload); err == nil {
+			if metadata.VersionBefore(r.MinSopsVersion) {
+				problems = append(problems, fmt.Sprintf("was written by sops %s, older than %s",
+					metadata.Version, r.MinSopsVersion))
+			}
+			if metadata.MACOnlyEncrypted {
+				problems = append(problems, "has a MAC that only covers the encrypted values")
+			}
+		}
+	}
+	if len(problems) == 0 {
+		r.removeCondition(sopsSecret, secretsv1alpha1.ConditionTypeWeakMACWarning)
+		return
+	}
+	r.setCondition(sopsSecret, secretsv1alpha1.ConditionTypeWeakMACWarning, metav1.ConditionTrue, "WeakMAC",
+		fmt.Sprintf("Document %s, re-encrypt it with a current sops", strings.Join(problems, " and ")))
+}
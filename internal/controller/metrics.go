@@ -0,0 +1,86 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// conditionStatuses are the values a condition status can take, each exported
+// as its own series so alerts can match on a single status.
+var conditionStatuses = []metav1.ConditionStatus{metav1.ConditionTrue, metav1.ConditionFalse, metav1.ConditionUnknown}
+
+// conditionGauge reports the current status of each SopsSecret condition.
+var conditionGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "sopssecret_condition",
+	Help: "Status of SopsSecret conditions, 1 for the status a condition is in and 0 for the others.",
+}, []string{"namespace", "name", "type", "status"})
+
+// reconcileTriggers counts reconciles by namespace and inferred trigger.
+var reconcileTriggers = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "sopssecret_reconcile_trigger_total",
+	Help: "Total number of SopsSecret reconciles by inferred trigger: initial, event, periodic or backoff.",
+}, []string{"namespace", "reason"})
+
+// decryptAttempts counts decrypt attempts by namespace and outcome.
+var decryptAttempts = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "sopssecret_decrypt_total",
+	Help: "Total number of SopsSecret decrypt attempts by outcome: success or failure.",
+}, []string{"namespace", "outcome"})
+
+// nameCollisions reports Secret names that more than one SopsSecret writes to.
+var nameCollisions = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "sopssecret_name_collisions",
+	Help: "Number of SopsSecrets writing to the same Secret, for Secret names with more than one.",
+}, []string{"namespace", "secret"})
+
+func init() {
+	metrics.Registry.MustRegister(conditionGauge, reconcileTriggers, decryptAttempts, nameCollisions)
+}
+
+// metricNamespace returns the namespace label value for counters. It is
+// empty with DisableNamespaceLabels, which Prometheus treats as no label.
+func (r *SopsSecretReconciler) metricNamespace(namespace string) string {
+	if r.DisableNamespaceLabels {
+		return ""
+	}
+	return namespace
+}
+
+// recordCondition sets the condition series of a SopsSecret to status.
+func recordCondition(key types.NamespacedName, condType string, status metav1.ConditionStatus) {
+	for _, s := range conditionStatuses {
+		value := 0.0
+		if s == status {
+			value = 1
+		}
+		conditionGauge.WithLabelValues(key.Namespace, key.Name, condType, string(s)).Set(value)
+	}
+}
+
+// forgetCondition removes the series of a condition that is no longer set.
+func forgetCondition(key types.NamespacedName, condType string) {
+	conditionGauge.DeletePartialMatch(prometheus.Labels{"namespace": key.Namespace, "name": key.Name, "type": condType})
+}
+
+// forgetConditions removes all condition series of a deleted SopsSecret.
+func forgetConditions(key types.NamespacedName) {
+	conditionGauge.DeletePartialMatch(prometheus.Labels{"namespace": key.Namespace, "name": key.Name})
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	secretsv1alpha1 "github.com/scalaric/sops-operator/api/v1alpha1"
+)
+
+// migrateOwnerReferences rewrites owner references to the SopsSecret whose
+// apiVersion is not the one the scheme currently serves, e.g. after the API
+// moved from v1alpha1 to a newer version. References are matched by UID, the
+// same way metav1.IsControlledBy does. It reports whether obj changed.
+func (r *SopsSecretReconciler) migrateOwnerReferences(obj metav1.Object, sopsSecret *secretsv1alpha1.SopsSecret) (bool, error) {
+	gvk, err := apiutil.GVKForObject(sopsSecret, r.Scheme)
+	if err != nil {
+		return false, err
+	}
+	apiVersion := gvk.GroupVersion().String()
+
+	refs := obj.GetOwnerReferences()
+	changed := false
+	for i := range refs {
+		if refs[i].UID == sopsSecret.UID && refs[i].Kind == gvk.Kind && refs[i].APIVersion != apiVersion {
+			refs[i].APIVersion = apiVersion
+			changed = true
+		}
+	}
+	if changed {
+		obj.SetOwnerReferences(refs)
+	}
+	return changed, nil
+}
+
+// adoptSecret takes ownership of a Secret that has no controller but carries
+// the sopssecret label of this SopsSecret, e.g. one restored from a backup
+// without its owner reference. It reports whether the Secret was adopted.
+func (r *SopsSecretReconciler) adoptSecret(secret *corev1.Secret, sopsSecret *secretsv1alpha1.SopsSecret) (bool, error) {
+	if ownerReferenceMode(sopsSecret) != secretsv1alpha1.OwnerReferenceController || secret.Namespace != sopsSecret.Namespace {
+		return false, nil
+	}
+	if metav1.GetControllerOf(secret) != nil || secret.Labels[sopsSecretLabel] != sopsSecret.Name {
+		return false, nil
+	}
+	if err := controllerutil.SetControllerReference(sopsSecret, secret, r.Scheme); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// ownerReferenceMode returns spec.ownerReferenceMode, defaulting to Controller.
+func ownerReferenceMode(sopsSecret *secretsv1alpha1.SopsSecret) secretsv1alpha1.OwnerReferenceMode {
+	if sopsSecret.Spec.OwnerReferenceMode == "" {
+		return secretsv1alpha1.OwnerReferenceController
+	}
+	return sopsSecret.Spec.OwnerReferenceMode
+}
+
+// ownsSecret reports whether secret is managed by sopsSecret. Without a
+// controller reference, in the NonController and None modes, a Secret with no
+// controller that carries the sopssecret label of sopsSecret is managed. A
+// Secret in another namespace is managed when its labels name sopsSecret.
+func ownsSecret(secret *corev1.Secret, sopsSecret *secretsv1alpha1.SopsSecret) bool {
+	if secret.Namespace != sopsSecret.Namespace {
+		return metav1.GetControllerOf(secret) == nil && ownsTargetSecret(secret, sopsSecret)
+	}
+	if metav1.IsControlledBy(secret, sopsSecret) {
+		return true
+	}
+	return ownerReferenceMode(sopsSecret) != secretsv1alpha1.OwnerReferenceController &&
+		metav1.GetControllerOf(secret) == nil && secret.Labels[sopsSecretLabel] == sopsSecret.Name
+}
+
+// setOwnerReference replaces the owner references of secret to sopsSecret
+// with the one spec.ownerReferenceMode asks for. A Secret in another
+// namespace gets none.
+func (r *SopsSecretReconciler) setOwnerReference(secret *corev1.Secret, sopsSecret *secretsv1alpha1.SopsSecret) error {
+	releaseSecret(secret, sopsSecret)
+	if secret.Namespace != sopsSecret.Namespace {
+		return nil
+	}
+	switch ownerReferenceMode(sopsSecret) {
+	case secretsv1alpha1.OwnerReferenceNonController:
+		return controllerutil.SetOwnerReference(sopsSecret, secret, r.Scheme)
+	case secretsv1alpha1.OwnerReferenceNone:
+		return nil
+	default:
+		return controllerutil.SetControllerReference(sopsSecret, secret, r.Scheme)
+	}
+}
+
+// releaseSecret removes the owner references of secret to sopsSecret. It
+// reports whether secret changed.
+func releaseSecret(secret *corev1.Secret, sopsSecret *secretsv1alpha1.SopsSecret) bool {
+	refs := secret.GetOwnerReferences()
+	kept := slices.DeleteFunc(slices.Clone(refs), func(ref metav1.OwnerReference) bool {
+		return ref.UID == sopsSecret.UID
+	})
+	if len(kept) == len(refs) {
+		return false
+	}
+	secret.SetOwnerReferences(kept)
+	return true
+}
@@ -0,0 +1,43 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	secretsv1alpha1 "github.com/scalaric/sops-operator/api/v1alpha1"
+	"github.com/scalaric/sops-operator/pkg/sops"
+)
+
+// plaintextAllowed reports whether the payload is used without decryption:
+// spec.allowPlaintext is set and the document has no sops block.
+func plaintextAllowed(sopsSecret *secretsv1alpha1.SopsSecret, payload []byte) bool {
+	return sopsSecret.Spec.AllowPlaintext && !sops.HasSopsMetadata(payload)
+}
+
+// plaintextDecryptor passes an unencrypted document through as decrypted data.
+type plaintextDecryptor struct{}
+
+var _ sops.DecryptorInterface = plaintextDecryptor{}
+
+func (plaintextDecryptor) Decrypt(document []byte) (*sops.DecryptedData, error) {
+	return sops.ParsePlaintext(document)
+}
+
+func (plaintextDecryptor) DecryptWithContext(_ context.Context, document []byte) (*sops.DecryptedData, error) {
+	return sops.ParsePlaintext(document)
+}
@@ -0,0 +1,86 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	secretsv1alpha1 "github.com/scalaric/sops-operator/api/v1alpha1"
+)
+
+// readyRequirement is a sub-condition that keeps a SopsSecret from being
+// Ready while it has the failing status.
+type readyRequirement struct {
+	condType string
+	failing  metav1.ConditionStatus
+
+	// decryptFailure marks a failed decrypt, which does not count while
+	// Degraded reports that the Secret of the last successful decrypt is kept
+	decryptFailure bool
+}
+
+// readyRequirements lists the sub-conditions Ready is derived from, roughly
+// in the order the reconcile checks them, so the first failing one is the
+// cause the others follow from.
+var readyRequirements = []readyRequirement{
+	{secretsv1alpha1.ConditionTypeTargetNamespaceRefused, metav1.ConditionTrue, false},
+	{secretsv1alpha1.ConditionTypeExpired, metav1.ConditionTrue, false},
+	{secretsv1alpha1.ConditionTypeSourceMissing, metav1.ConditionTrue, false},
+	{secretsv1alpha1.ConditionTypeChunksComplete, metav1.ConditionFalse, false},
+	{secretsv1alpha1.ConditionTypeWaitingForDependency, metav1.ConditionTrue, false},
+	{secretsv1alpha1.ConditionTypePending, metav1.ConditionTrue, false},
+	{secretsv1alpha1.ConditionTypeBackendPolicyViolation, metav1.ConditionTrue, false},
+	{secretsv1alpha1.ConditionTypeLockedDataChanged, metav1.ConditionTrue, false},
+	{secretsv1alpha1.ConditionTypeTooDeeplyNested, metav1.ConditionTrue, true},
+	{secretsv1alpha1.ConditionTypeDecrypted, metav1.ConditionFalse, true},
+	{secretsv1alpha1.ConditionTypeEncryptedKeyUnsupported, metav1.ConditionTrue, false},
+	{secretsv1alpha1.ConditionTypeInvalidJSON, metav1.ConditionTrue, false},
+	{secretsv1alpha1.ConditionTypeInvalidKubeconfig, metav1.ConditionTrue, false},
+	{secretsv1alpha1.ConditionTypeSchemaInvalid, metav1.ConditionTrue, false},
+	{secretsv1alpha1.ConditionTypeVerificationFailed, metav1.ConditionTrue, false},
+	{secretsv1alpha1.ConditionTypeReflectionFailed, metav1.ConditionTrue, false},
+}
+
+// failingRequirement returns the first sub-condition in readyRequirements
+// that fails.
+func failingRequirement(conditions []metav1.Condition) *metav1.Condition {
+	degraded := meta.IsStatusConditionTrue(conditions, secretsv1alpha1.ConditionTypeDegraded)
+	for _, req := range readyRequirements {
+		if req.decryptFailure && degraded {
+			continue
+		}
+		if cond := meta.FindStatusCondition(conditions, req.condType); cond != nil && cond.Status == req.failing {
+			return cond
+		}
+	}
+	return nil
+}
+
+// summarizeReady sets Ready to False when a sub-condition fails, with the
+// reason of the first failing one and its message. Ready=False set for a
+// cause without a sub-condition of its own is kept.
+func (r *SopsSecretReconciler) summarizeReady(sopsSecret *secretsv1alpha1.SopsSecret) {
+	cond := failingRequirement(sopsSecret.Status.Conditions)
+	if cond == nil || !meta.IsStatusConditionTrue(sopsSecret.Status.Conditions, secretsv1alpha1.ConditionTypeReady) {
+		return
+	}
+	r.setCondition(sopsSecret, secretsv1alpha1.ConditionTypeReady, metav1.ConditionFalse,
+		cond.Reason, fmt.Sprintf("%s: %s", cond.Type, cond.Message))
+}
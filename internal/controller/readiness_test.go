@@ -0,0 +1,135 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	secretsv1alpha1 "github.com/scalaric/sops-operator/api/v1alpha1"
+)
+
+func TestSummarizeReady(t *testing.T) {
+	condition := func(condType string, status metav1.ConditionStatus, reason string) metav1.Condition {
+		return metav1.Condition{Type: condType, Status: status, Reason: reason, Message: reason + " message"}
+	}
+	ready := condition(secretsv1alpha1.ConditionTypeReady, metav1.ConditionTrue, "Success")
+
+	tests := []struct {
+		name       string
+		conditions []metav1.Condition
+		wantStatus metav1.ConditionStatus
+		wantReason string
+	}{
+		{
+			name: "all sub-conditions pass",
+			conditions: []metav1.Condition{
+				ready,
+				condition(secretsv1alpha1.ConditionTypeDecrypted, metav1.ConditionTrue, "Success"),
+				condition(secretsv1alpha1.ConditionTypeChunksComplete, metav1.ConditionTrue, "Complete"),
+				condition(secretsv1alpha1.ConditionTypeValueFormatWarning, metav1.ConditionTrue, "Whitespace"),
+			},
+			wantStatus: metav1.ConditionTrue,
+			wantReason: "Success",
+		},
+		{
+			name: "failing sub-condition",
+			conditions: []metav1.Condition{
+				ready,
+				condition(secretsv1alpha1.ConditionTypeSchemaInvalid, metav1.ConditionTrue, ReasonSchemaInvalid),
+			},
+			wantStatus: metav1.ConditionFalse,
+			wantReason: ReasonSchemaInvalid,
+		},
+		{
+			name: "worst of several failing sub-conditions",
+			conditions: []metav1.Condition{
+				ready,
+				condition(secretsv1alpha1.ConditionTypeVerificationFailed, metav1.ConditionTrue, ReasonVerificationFailed),
+				condition(secretsv1alpha1.ConditionTypeInvalidJSON, metav1.ConditionTrue, ReasonInvalidJSON),
+				condition(secretsv1alpha1.ConditionTypeDecrypted, metav1.ConditionFalse, "DecryptFailed"),
+			},
+			wantStatus: metav1.ConditionFalse,
+			wantReason: "DecryptFailed",
+		},
+		{
+			name: "incomplete chunks",
+			conditions: []metav1.Condition{
+				ready,
+				condition(secretsv1alpha1.ConditionTypeChunksComplete, metav1.ConditionFalse, "ChunkNotFound"),
+			},
+			wantStatus: metav1.ConditionFalse,
+			wantReason: "ChunkNotFound",
+		},
+		{
+			name: "stale Secret kept after a failed decrypt",
+			conditions: []metav1.Condition{
+				condition(secretsv1alpha1.ConditionTypeReady, metav1.ConditionTrue, "Stale"),
+				condition(secretsv1alpha1.ConditionTypeDecrypted, metav1.ConditionFalse, "DecryptFailed"),
+				condition(secretsv1alpha1.ConditionTypeDegraded, metav1.ConditionTrue, "DecryptFailed"),
+			},
+			wantStatus: metav1.ConditionTrue,
+			wantReason: "Stale",
+		},
+		{
+			name: "Ready=False without a failing sub-condition is kept",
+			conditions: []metav1.Condition{
+				condition(secretsv1alpha1.ConditionTypeReady, metav1.ConditionFalse, ReasonTooManyKeys),
+			},
+			wantStatus: metav1.ConditionFalse,
+			wantReason: ReasonTooManyKeys,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &SopsSecretReconciler{}
+			sopsSecret := &secretsv1alpha1.SopsSecret{
+				ObjectMeta: metav1.ObjectMeta{Name: "summary", Namespace: "default"},
+				Status:     secretsv1alpha1.SopsSecretStatus{Conditions: tt.conditions},
+			}
+
+			r.summarizeReady(sopsSecret)
+
+			got := meta.FindStatusCondition(sopsSecret.Status.Conditions, secretsv1alpha1.ConditionTypeReady)
+			if got.Status != tt.wantStatus || got.Reason != tt.wantReason {
+				t.Errorf("Ready = %s/%s, want %s/%s", got.Status, got.Reason, tt.wantStatus, tt.wantReason)
+			}
+		})
+	}
+}
+
+func TestSummarizeReadyMessage(t *testing.T) {
+	r := &SopsSecretReconciler{}
+	sopsSecret := &secretsv1alpha1.SopsSecret{
+		ObjectMeta: metav1.ObjectMeta{Name: "summary", Namespace: "default"},
+		Status: secretsv1alpha1.SopsSecretStatus{Conditions: []metav1.Condition{
+			{Type: secretsv1alpha1.ConditionTypeReady, Status: metav1.ConditionTrue, Reason: "Success"},
+			{Type: secretsv1alpha1.ConditionTypeInvalidJSON, Status: metav1.ConditionTrue, Reason: ReasonInvalidJSON,
+				Message: "Values of keys config are not valid JSON"},
+		}},
+	}
+
+	r.summarizeReady(sopsSecret)
+
+	got := meta.FindStatusCondition(sopsSecret.Status.Conditions, secretsv1alpha1.ConditionTypeReady)
+	if want := "InvalidJSON: Values of keys config are not valid JSON"; got.Message != want {
+		t.Errorf("Ready message = %q, want %q", got.Message, want)
+	}
+}
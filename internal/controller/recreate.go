@@ -0,0 +1,70 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"bytes"
+	"context"
+	"maps"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	secretsv1alpha1 "github.com/scalaric/sops-operator/api/v1alpha1"
+)
+
+// recreateAnnotation set to "true" on a SopsSecret deletes the managed Secret
+// and writes it again from scratch, once per time the annotation is set.
+const recreateAnnotation = "secrets.scalaric.io/recreate"
+
+// recreateRequested reports whether the recreate annotation asks for a
+// recreate that status.recreateAcknowledged does not yet record.
+func recreateRequested(sopsSecret *secretsv1alpha1.SopsSecret) bool {
+	return sopsSecret.Annotations[recreateAnnotation] == "true" && !sopsSecret.Status.RecreateAcknowledged
+}
+
+// deleteForRecreate deletes the managed Secret so that it is created again
+// from scratch. A Secret the SopsSecret does not own is left alone. It
+// reports whether the Secret was deleted.
+func (r *SopsSecretReconciler) deleteForRecreate(
+	ctx context.Context, sopsSecret *secretsv1alpha1.SopsSecret, secret *corev1.Secret,
+) (bool, error) {
+	if !ownsSecret(secret, sopsSecret) {
+		return false, nil
+	}
+	if err := r.Delete(ctx, secret); client.IgnoreNotFound(err) != nil {
+		return false, err
+	}
+	logf.FromContext(ctx).Info("Deleted Secret to recreate it", "name", secret.Name)
+	r.Recorder.Eventf(sopsSecret, secret, corev1.EventTypeNormal, ReasonSecretRecreated, "Delete",
+		"Deleted Secret %s to recreate it from scratch", secret.Name)
+	return true, nil
+}
+
+// immutableSecretChanged reports whether the existing Secret is immutable and
+// differs from the desired one in its data or in being immutable, which the
+// API server only allows by replacing the Secret.
+func immutableSecretChanged(existing, desired *corev1.Secret) bool {
+	if existing.Immutable == nil || !*existing.Immutable {
+		return false
+	}
+	if desired.Immutable == nil || !*desired.Immutable {
+		return true
+	}
+	return !maps.EqualFunc(existing.Data, desired.Data, bytes.Equal)
+}
@@ -0,0 +1,212 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	secretsv1alpha1 "github.com/scalaric/sops-operator/api/v1alpha1"
+)
+
+// Labels identifying copies of a managed Secret reflected into other
+// namespaces. Owner references cannot cross namespaces, so the copies are
+// tracked by these labels and cleaned up by the finalizer. The sopssecret
+// label is not copied, so a SopsSecret of the same name in the target
+// namespace never adopts a copy.
+const (
+	reflectedFromNameLabel      = "secrets.scalaric.io/reflected-from-name"
+	reflectedFromNamespaceLabel = "secrets.scalaric.io/reflected-from-namespace"
+)
+
+// reflectionLabels selects the copies reflected from a SopsSecret.
+func reflectionLabels(sopsSecret *secretsv1alpha1.SopsSecret) client.MatchingLabels {
+	return client.MatchingLabels{
+		reflectedFromNameLabel:      sopsSecret.Name,
+		reflectedFromNamespaceLabel: sopsSecret.Namespace,
+	}
+}
+
+// reflectNamespaces returns the sorted, distinct namespaces of
+// spec.reflectToNamespaces, without the namespace of the Secret itself.
+func reflectNamespaces(sopsSecret *secretsv1alpha1.SopsSecret) []string {
+	namespaces := slices.DeleteFunc(slices.Clone(sopsSecret.Spec.ReflectToNamespaces), func(ns string) bool {
+		return ns == "" || ns == secretNamespace(sopsSecret)
+	})
+	slices.Sort(namespaces)
+	return slices.Compact(namespaces)
+}
+
+// reflectionError lists the namespaces a Secret could not be reflected into
+// or removed from.
+type reflectionError struct {
+	errs []error
+}
+
+func (e *reflectionError) Error() string {
+	msgs := make([]string, len(e.errs))
+	for i, err := range e.errs {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+func (e *reflectionError) Unwrap() []error {
+	return e.errs
+}
+
+// reconcileReflections copies secret into every namespace of
+// spec.reflectToNamespaces and deletes copies in namespaces that are no longer
+// listed. A Secret in a target namespace that is not a copy of this
+// SopsSecret is never modified. A namespace that fails does not stop the
+// others; the failures are returned together as a *reflectionError.
+func (r *SopsSecretReconciler) reconcileReflections(
+	ctx context.Context, sopsSecret *secretsv1alpha1.SopsSecret, secret *corev1.Secret,
+) error {
+	log := logf.FromContext(ctx)
+	wanted := reflectNamespaces(sopsSecret)
+
+	var errs []error
+	for _, ns := range wanted {
+		if err := r.reflectSecret(ctx, sopsSecret, secret, ns); err != nil {
+			errs = append(errs, fmt.Errorf("namespace %s: %w", ns, err))
+		}
+	}
+
+	// Remove copies from namespaces that were dropped, or left behind under an
+	// earlier Secret name
+	reflections := &corev1.SecretList{}
+	if err := r.List(ctx, reflections, reflectionLabels(sopsSecret)); err != nil {
+		errs = append(errs, fmt.Errorf("list reflected Secrets: %w", err))
+		return &reflectionError{errs: errs}
+	}
+	for i := range reflections.Items {
+		stale := &reflections.Items[i]
+		if stale.Name == secret.Name && slices.Contains(wanted, stale.Namespace) {
+			continue
+		}
+		if err := r.Delete(ctx, stale); client.IgnoreNotFound(err) != nil {
+			errs = append(errs, fmt.Errorf("namespace %s: %w", stale.Namespace, err))
+			continue
+		}
+		log.Info("Deleted reflected Secret", "name", stale.Name, "namespace", stale.Namespace)
+	}
+	if len(errs) > 0 {
+		return &reflectionError{errs: errs}
+	}
+	return nil
+}
+
+// reflectSecret creates or updates the copy of secret in namespace ns, if
+// ns accepts Secrets from the namespace of sopsSecret.
+func (r *SopsSecretReconciler) reflectSecret(
+	ctx context.Context, sopsSecret *secretsv1alpha1.SopsSecret, secret *corev1.Secret, ns string,
+) error {
+	if err := r.crossNamespaceAllowed(ctx, sopsSecret, ns); err != nil {
+		return err
+	}
+	reflected := reflectedSecret(sopsSecret, secret, ns)
+	existing := &corev1.Secret{}
+	err := r.Get(ctx, types.NamespacedName{Namespace: ns, Name: secret.Name}, existing)
+	if apierrors.IsNotFound(err) {
+		if err := r.Create(ctx, reflected); err != nil {
+			return err
+		}
+		logf.FromContext(ctx).Info("Reflected Secret", "name", secret.Name, "namespace", ns)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if !isReflectionOf(existing, sopsSecret) {
+		return fmt.Errorf("secret %s/%s exists and is not reflected from this SopsSecret", ns, secret.Name)
+	}
+	existing.Labels = reflected.Labels
+	existing.Annotations = reflected.Annotations
+	existing.Type = reflected.Type
+	existing.Data = reflected.Data
+	return r.Update(ctx, existing)
+}
+
+// setReflectionFailed records the namespaces that failed in err in the
+// ReflectionFailed condition and emits a warning event.
+func (r *SopsSecretReconciler) setReflectionFailed(sopsSecret *secretsv1alpha1.SopsSecret, err error) {
+	msg := fmt.Sprintf("Failed to reflect Secret: %v", err)
+	r.setCondition(sopsSecret, secretsv1alpha1.ConditionTypeReflectionFailed, metav1.ConditionTrue,
+		ReasonReflectionFailed, msg)
+	r.Recorder.Eventf(sopsSecret, nil, corev1.EventTypeWarning, ReasonReflectionFailed, "Reflect", "%s", msg)
+}
+
+// deleteReflections removes all copies reflected from the SopsSecret.
+func (r *SopsSecretReconciler) deleteReflections(ctx context.Context, sopsSecret *secretsv1alpha1.SopsSecret) error {
+	reflections := &corev1.SecretList{}
+	if err := r.List(ctx, reflections, reflectionLabels(sopsSecret)); err != nil {
+		return err
+	}
+	for i := range reflections.Items {
+		if err := r.Delete(ctx, &reflections.Items[i]); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isReflectionOf reports whether secret is a copy reflected from sopsSecret.
+func isReflectionOf(secret *corev1.Secret, sopsSecret *secretsv1alpha1.SopsSecret) bool {
+	return secret.Labels[reflectedFromNameLabel] == sopsSecret.Name &&
+		secret.Labels[reflectedFromNamespaceLabel] == sopsSecret.Namespace
+}
+
+// reflectedSecret returns the copy of secret for namespace ns.
+func reflectedSecret(sopsSecret *secretsv1alpha1.SopsSecret, secret *corev1.Secret, ns string) *corev1.Secret {
+	labels := maps.Clone(secret.Labels)
+	if labels == nil {
+		labels = make(map[string]string)
+	}
+	delete(labels, sopsSecretLabel)
+	labels[managedByLabel] = "sops-operator"
+	maps.Copy(labels, reflectionLabels(sopsSecret))
+
+	annotations := maps.Clone(secret.Annotations)
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	delete(annotations, managedLabelsAnnotation)
+	delete(annotations, managedAnnotationsAnnotation)
+	annotations[sourceAnnotation] = fmt.Sprintf("%s/%s", sopsSecret.Namespace, sopsSecret.Name)
+
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        secret.Name,
+			Namespace:   ns,
+			Labels:      labels,
+			Annotations: annotations,
+		},
+		Type: secret.Type,
+		Data: maps.Clone(secret.Data),
+	}
+}
@@ -0,0 +1,171 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	openapierrors "k8s.io/kube-openapi/pkg/validation/errors"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+	"k8s.io/kube-openapi/pkg/validation/strfmt"
+	"k8s.io/kube-openapi/pkg/validation/validate"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	secretsv1alpha1 "github.com/scalaric/sops-operator/api/v1alpha1"
+	"github.com/scalaric/sops-operator/pkg/sops"
+)
+
+// defaultSchemaKey is the ConfigMap key read when spec.schemaRef has no key.
+const defaultSchemaKey = "schema.json"
+
+// schemaFailures describes schema validation failures by their code. The
+// validator's own messages may quote the offending value, which is plaintext.
+var schemaFailures = map[int32]string{
+	openapierrors.InvalidTypeCode:       "has the wrong type",
+	openapierrors.RequiredFailCode:      "is required",
+	openapierrors.TooLongFailCode:       "is too long",
+	openapierrors.TooShortFailCode:      "is too short",
+	openapierrors.PatternFailCode:       "does not match the pattern",
+	openapierrors.EnumFailCode:          "is not one of the allowed values",
+	openapierrors.MaxFailCode:           "is above the maximum",
+	openapierrors.MinFailCode:           "is below the minimum",
+	openapierrors.UnallowedPropertyCode: "is not allowed",
+}
+
+// loadSchema reads the JSON schema referenced by spec.schemaRef and returns
+// it with the resourceVersion of its ConfigMap.
+func (r *SopsSecretReconciler) loadSchema(
+	ctx context.Context, sopsSecret *secretsv1alpha1.SopsSecret,
+) (*spec.Schema, string, error) {
+	ref := sopsSecret.Spec.SchemaRef
+	key := ref.Key
+	if key == "" {
+		key = defaultSchemaKey
+	}
+
+	configMap := &corev1.ConfigMap{}
+	err := r.Get(ctx, types.NamespacedName{Namespace: sopsSecret.Namespace, Name: ref.Name}, configMap)
+	if apierrors.IsNotFound(err) {
+		return nil, "", fmt.Errorf("schema ConfigMap %s not found", ref.Name)
+	}
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to get schema ConfigMap %s: %w", ref.Name, err)
+	}
+	raw, ok := configMap.Data[key]
+	if !ok {
+		return nil, "", fmt.Errorf("schema ConfigMap %s has no key %s", ref.Name, key)
+	}
+	schema := &spec.Schema{}
+	if err := json.Unmarshal([]byte(raw), schema); err != nil {
+		return nil, "", fmt.Errorf("schema in ConfigMap %s key %s is not valid JSON: %w", ref.Name, key, err)
+	}
+	return schema, configMap.ResourceVersion, nil
+}
+
+// schemaUnchanged reports whether the schema the data was last validated
+// against is still current. Without spec.schemaRef there is no schema to
+// change once the status records none.
+func (r *SopsSecretReconciler) schemaUnchanged(ctx context.Context, sopsSecret *secretsv1alpha1.SopsSecret) bool {
+	ref := sopsSecret.Spec.SchemaRef
+	if ref == nil {
+		return sopsSecret.Status.SchemaResourceVersion == ""
+	}
+	configMap := &corev1.ConfigMap{}
+	err := r.Get(ctx, types.NamespacedName{Namespace: sopsSecret.Namespace, Name: ref.Name}, configMap)
+	return err == nil && configMap.ResourceVersion == sopsSecret.Status.SchemaResourceVersion
+}
+
+// schemaViolations validates the decrypted data against schema and returns
+// the failures, sorted. Each failure names the field and the kind of failure
+// only. YAML-wrapped values are validated as the values they wrap, so a
+// nested map or a number is checked as such.
+func schemaViolations(
+	schema *spec.Schema, sopsSecret *secretsv1alpha1.SopsSecret, decrypted *sops.DecryptedData,
+) ([]string, error) {
+	document := make(map[string]any, len(decrypted.Data))
+	for key, value := range decrypted.Data {
+		document[key] = string(value)
+		if sopsSecret.Spec.Format == secretsv1alpha1.FormatCRD {
+			continue
+		}
+		var raw map[string]any
+		if err := yaml.Unmarshal(value, &raw); err == nil {
+			if v, ok := raw[key]; ok {
+				document[key] = v
+			}
+		}
+	}
+	// Validate the JSON form, the validator expects JSON numbers and maps
+	encoded, err := json.Marshal(document)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode decrypted data for schema validation: %w", err)
+	}
+	var data any
+	if err := json.Unmarshal(encoded, &data); err != nil {
+		return nil, fmt.Errorf("failed to encode decrypted data for schema validation: %w", err)
+	}
+
+	result := validate.NewSchemaValidator(schema, nil, "", strfmt.Default).Validate(data)
+	var violations []string
+	for _, err := range result.Errors {
+		violations = append(violations, schemaViolation(err))
+	}
+	sort.Strings(violations)
+	return violations, nil
+}
+
+// schemaViolation describes a validation error without the offending value.
+func schemaViolation(err error) string {
+	var name string
+	code := int32(0)
+	if e, ok := err.(*openapierrors.Validation); ok {
+		name, code = e.Name, e.Code()
+	}
+	if name == "" || name == "." {
+		name = "document"
+	}
+	failure, ok := schemaFailures[code]
+	if !ok {
+		failure = "does not match the schema"
+	}
+	return name + " " + failure
+}
+
+// sopsSecretsUsingSchema enqueues the SopsSecrets in the ConfigMap's
+// namespace whose spec.schemaRef names it.
+func (r *SopsSecretReconciler) sopsSecretsUsingSchema(ctx context.Context, obj client.Object) []reconcile.Request {
+	list := &secretsv1alpha1.SopsSecretList{}
+	if err := r.List(ctx, list, client.InNamespace(obj.GetNamespace())); err != nil {
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, item := range list.Items {
+		if item.Spec.SchemaRef != nil && item.Spec.SchemaRef.Name == obj.GetName() {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&item)})
+		}
+	}
+	return requests
+}
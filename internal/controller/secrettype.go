@@ -0,0 +1,75 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// requiredSecretKeys are the keys the API server requires for the built-in
+// Secret types.
+var requiredSecretKeys = map[corev1.SecretType][]string{
+	corev1.SecretTypeTLS:              {corev1.TLSCertKey, corev1.TLSPrivateKeyKey},
+	corev1.SecretTypeDockerConfigJson: {corev1.DockerConfigJsonKey},
+	corev1.SecretTypeDockercfg:        {corev1.DockerConfigKey},
+	corev1.SecretTypeSSHAuth:          {corev1.SSHAuthPrivateKey},
+}
+
+// validateSecretType checks that data satisfies the API server's rules for
+// secretType, so that a type change does not delete a Secret that cannot be
+// created again. Only keys are named, values may be plaintext.
+func validateSecretType(secretType corev1.SecretType, data map[string][]byte) error {
+	var missing []string
+	for _, key := range requiredSecretKeys[secretType] {
+		if _, ok := data[key]; !ok {
+			missing = append(missing, key)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("type %s requires keys %s", secretType, strings.Join(missing, ", "))
+	}
+
+	switch secretType {
+	case corev1.SecretTypeDockerConfigJson, corev1.SecretTypeDockercfg:
+		key := requiredSecretKeys[secretType][0]
+		if !json.Valid(data[key]) {
+			return fmt.Errorf("type %s requires key %s to hold JSON", secretType, key)
+		}
+	case corev1.SecretTypeBasicAuth:
+		_, hasUsername := data[corev1.BasicAuthUsernameKey]
+		_, hasPassword := data[corev1.BasicAuthPasswordKey]
+		if !hasUsername && !hasPassword {
+			return fmt.Errorf("type %s requires key %s or %s", secretType,
+				corev1.BasicAuthUsernameKey, corev1.BasicAuthPasswordKey)
+		}
+	}
+	return nil
+}
+
+// secretTypeChanged reports whether the existing Secret has a different type
+// than the desired one. An empty type is the API server's default, Opaque.
+func secretTypeChanged(existing, desired *corev1.Secret) bool {
+	existingType := existing.Type
+	if existingType == "" {
+		existingType = corev1.SecretTypeOpaque
+	}
+	return existingType != desired.Type
+}
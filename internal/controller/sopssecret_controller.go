@@ -17,10 +17,17 @@ limitations under the License.
 package controller
 
 import (
+	"bytes"
 	"context"
 	"crypto/sha256"
 	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io/fs"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	"gopkg.in/yaml.v3"
@@ -33,8 +40,10 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/events"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
 	secretsv1alpha1 "github.com/scalaric/sops-operator/api/v1alpha1"
@@ -44,13 +53,76 @@ import (
 const (
 	finalizerName = "secrets.scalaric.io/finalizer"
 
+	// secretDeletionRequeueInterval is how often reconcileDelete checks whether
+	// a managed Secret held by foreign finalizers has been removed.
+	secretDeletionRequeueInterval = 5 * time.Second
+
+	// DefaultReconcileInterval is how often a SopsSecret is reconciled after a
+	// successful write when neither ReconcileInterval nor
+	// spec.reconcileInterval is set.
+	DefaultReconcileInterval = 5 * time.Minute
+
+	// maxNestedDecryptLevels is how many levels of sops documents stored as
+	// values spec.recursiveDecrypt decrypts below the outer document.
+	maxNestedDecryptLevels = 3
+
+	// Labels and annotations set on managed Secrets
+	managedByLabel   = "app.kubernetes.io/managed-by"
+	sopsSecretLabel  = "secrets.scalaric.io/sopssecret"
+	sourceAnnotation = "secrets.scalaric.io/source"
+
+	// Annotations recording the SopsSecret generation and resourceVersion the
+	// managed Secret was written from
+	sourceGenerationAnnotation      = "secrets.scalaric.io/source-generation"
+	sourceResourceVersionAnnotation = "secrets.scalaric.io/source-resource-version"
+
+	// Annotations recording which label and annotation keys the operator set on
+	// a managed Secret, so keys dropped from the spec can be removed on update
+	// without touching keys added by users or other controllers.
+	managedLabelsAnnotation      = "secrets.scalaric.io/managed-labels"
+	managedAnnotationsAnnotation = "secrets.scalaric.io/managed-annotations"
+
+	// keyOrderAnnotation lists the keys of a managed Secret in the order of
+	// the decrypted document, for consumers such as env-file generators that
+	// care about it
+	keyOrderAnnotation = "secrets.scalaric.io/key-order"
+
 	// Event reasons
-	ReasonDecrypted      = "Decrypted"
-	ReasonDecryptFailed  = "DecryptFailed"
-	ReasonSecretCreated  = "SecretCreated"
-	ReasonSecretUpdated  = "SecretUpdated"
-	ReasonSecretDeleted  = "SecretDeleted"
-	ReasonValidationFail = "ValidationFailed"
+	ReasonDecrypted          = "Decrypted"
+	ReasonDecryptFailed      = "DecryptFailed"
+	ReasonSecretCreated      = "SecretCreated"
+	ReasonSecretUpdated      = "SecretUpdated"
+	ReasonSecretDeleted      = "SecretDeleted"
+	ReasonValidationFail     = "ValidationFailed"
+	ReasonValueTooLarge      = "ValueTooLarge"
+	ReasonSourceFailed       = "SourceFailed"
+	ReasonSourceMissing      = "SourceMissing"
+	ReasonStatusNotPersisted = "StatusNotPersisted"
+	ReasonWaiting            = "WaitingForDependency"
+	ReasonTransformFailed    = "TransformFailed"
+	ReasonTooManyKeys        = "TooManyKeys"
+	ReasonKeySecretFailed    = "KeySecretFailed"
+	ReasonBackupFailed       = "BackupFailed"
+	ReasonInvalidJSON        = "InvalidJSON"
+	ReasonInvalidKubeconfig  = "InvalidKubeconfig"
+	ReasonSecretAdopted      = "SecretAdopted"
+	ReasonSecretExpired      = "SecretExpired"
+	ReasonSecretRecreated    = "SecretRecreated"
+	ReasonInvalidSecretType  = "InvalidSecretType"
+	ReasonSchemaInvalid      = "SchemaInvalid"
+	ReasonSchemaUnavailable  = "SchemaUnavailable"
+	ReasonKeysChanged        = "KeysChanged"
+	ReasonPolicyViolation    = "BackendPolicyViolation"
+	ReasonEncryptedKeys      = "EncryptedKeyUnsupported"
+	ReasonPending            = "Pending"
+	ReasonVerificationFailed = "VerificationFailed"
+	ReasonInvalidSchedule    = "InvalidRotationSchedule"
+	ReasonTooDeeplyNested    = "TooDeeplyNested"
+	ReasonVaultSinkFailed    = "VaultSinkFailed"
+	ReasonSecretNotManaged   = "SecretNotManaged"
+	ReasonReflectionFailed   = "ReflectionFailed"
+	ReasonTargetRefused      = "TargetNamespaceRefused"
+	ReasonDataLocked         = "DataLocked"
 )
 
 // SopsSecretReconciler reconciles a SopsSecret object
@@ -59,27 +131,174 @@ type SopsSecretReconciler struct {
 	Scheme    *runtime.Scheme
 	Recorder  events.EventRecorder
 	Decryptor sops.DecryptorInterface
+
+	// LegacyMetadataKeys maps label/annotation keys used by earlier operator
+	// versions to their current names. Owned Secrets still carrying an old key
+	// are rewritten on reconcile so label-based lookups keep matching.
+	LegacyMetadataKeys map[string]string
+
+	// MaxValueBytes is the default per-value size limit for decrypted data.
+	// Zero disables the check. spec.maxValueBytes takes precedence.
+	MaxValueBytes int64
+
+	// MaxKeysPerSecret is the maximum number of keys a Secret may hold.
+	// Zero disables the check.
+	MaxKeysPerSecret int
+
+	// EncryptedFileDirs lists the directories spec.encryptedFromFile may read
+	// from. Empty disables file sources.
+	EncryptedFileDirs []string
+
+	// FilePollInterval is how often a SopsSecret with spec.encryptedFromFile
+	// is reconciled to pick up changes to the file. Zero leaves it to the
+	// periodic resync.
+	FilePollInterval time.Duration
+
+	// SkipPreValidation disables the check for a sops metadata block with a MAC
+	// before decrypting, leaving sops to reject invalid documents.
+	SkipPreValidation bool
+
+	// ValueTransformer rewrites every value before the Secret is written.
+	// Nil stores the decrypted values unchanged.
+	ValueTransformer SecretValueTransformer
+
+	// Verifier checks decrypted data before the Secret is written. Nil
+	// accepts all data.
+	Verifier SecretVerifier
+
+	// FailFastOnStartup exits the operator when at least FailFastRatio of the
+	// first FailFastSamples decrypts fail within FailFastWindow of the first
+	// one. Zero values use the defaults.
+	FailFastOnStartup bool
+	FailFastSamples   int
+	FailFastRatio     float64
+	FailFastWindow    time.Duration
+
+	// NewDecryptor builds the decryptor for a SopsSecret that names its own
+	// AGE key Secret. Nil uses sops.NewDecryptor.
+	NewDecryptor func(ageKeys []string) sops.DecryptorInterface
+
+	// Encryptor re-encrypts documents for spec.backupRecipient. Nil disables
+	// backups.
+	Encryptor sops.EncryptorInterface
+
+	// VaultSink writes spec.vaultSink. Nil disables the Vault sink.
+	VaultSink SecretSink
+
+	// ConditionStabilizationWindow is how long failures must persist before
+	// Ready changes from True to False. Zero applies failures immediately.
+	ConditionStabilizationWindow time.Duration
+
+	// AuditLogger receives an event for every decrypt attempt. Nil disables
+	// auditing.
+	AuditLogger AuditLogger
+
+	// InitialGracePeriod is how long after its creation a SopsSecret that was
+	// never decrypted retries failures quietly as Pending, before they are
+	// reported. Zero reports them at once.
+	InitialGracePeriod time.Duration
+
+	// GlobalKeySource is recorded in status.keySource for documents decrypted
+	// with Decryptor, see secretsv1alpha1.KeySourceGlobalEnv.
+	GlobalKeySource string
+
+	// ShutdownGracePeriod is how long a reconcile in flight when the manager
+	// stops may go on to finish its decrypt and writes. Zero stops it at once.
+	ShutdownGracePeriod time.Duration
+
+	// VerboseErrors records the sops invocation of a failed decrypt in
+	// status.lastErrorDetail. The detail may name key recipients.
+	VerboseErrors bool
+
+	// MinSopsVersion warns about documents written by older sops releases or
+	// with discouraged MAC settings in the WeakMacWarning condition. Empty
+	// disables the check.
+	MinSopsVersion string
+
+	// DisableNamespaceLabels leaves the namespace label of the reconcile and
+	// decrypt counters empty, to bound their cardinality in large clusters.
+	DisableNamespaceLabels bool
+
+	// ReconcileInterval is how often a SopsSecret is reconciled after a
+	// successful write. Zero uses DefaultReconcileInterval.
+	// spec.reconcileInterval takes precedence.
+	ReconcileInterval time.Duration
+
+	// ServerSideApply writes the managed Secret with server-side apply as the
+	// sops-operator field manager, so the operator only owns the fields it
+	// sets. Secrets with spec.useGenerateName are still created.
+	ServerSideApply bool
+
+	// AllowCrossNamespace lets SopsSecrets write Secrets into other
+	// namespaces that accept them in their accept-secrets-from annotation.
+	AllowCrossNamespace bool
+
+	// FailureBackoffBase is how soon a SopsSecret that is not Ready, or only
+	// Ready with a stale Secret, is retried. The delay doubles with every
+	// further failure in status.failureCount. Zero retries failures after the
+	// reconcile interval.
+	FailureBackoffBase time.Duration
+
+	// FailureBackoffMax caps the failure backoff. Zero caps it at the
+	// reconcile interval.
+	FailureBackoffMax time.Duration
+
+	startup startupCheck
+
+	// clock replaces time.Now in tests
+	clock func() time.Time
+
+	// triggers infers why each reconcile was scheduled
+	triggers triggerTracker
 }
 
 // +kubebuilder:rbac:groups=secrets.scalaric.io,resources=sopssecrets,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=secrets.scalaric.io,resources=sopssecrets/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=secrets.scalaric.io,resources=sopssecrets/finalizers,verbs=update
 // +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+// +kubebuilder:rbac:groups=events.k8s.io,resources=events,verbs=create;patch
+// +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
 
-func (r *SopsSecretReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+func (r *SopsSecretReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, err error) {
 	log := logf.FromContext(ctx)
+	ctx, cancel := drainContext(ctx, r.ShutdownGracePeriod)
+	defer cancel()
+
+	// Attribute the reconcile to its likely trigger to explain reconcile rates
+	started := r.now()
+	trigger := r.triggers.infer(req.NamespacedName, started)
+	reconcileTriggers.WithLabelValues(r.metricNamespace(req.Namespace), trigger).Inc()
+	log.V(1).Info("Reconcile triggered", "trigger", trigger)
+	gone := false
+	defer func() {
+		if gone {
+			r.triggers.forget(req.NamespacedName)
+			return
+		}
+		r.triggers.record(req.NamespacedName, started, result, err)
+	}()
 
 	// Fetch the SopsSecret
 	sopsSecret := &secretsv1alpha1.SopsSecret{}
 	if err := r.Get(ctx, req.NamespacedName, sopsSecret); err != nil {
 		if apierrors.IsNotFound(err) {
+			forgetConditions(req.NamespacedName)
+			gone = true
 			return ctrl.Result{}, nil
 		}
 		log.Error(err, "Failed to get SopsSecret")
 		return ctrl.Result{}, err
 	}
 
+	// Nothing reports changes to an encryptedFromFile source, poll it
+	defer func() {
+		if err == nil {
+			result.RequeueAfter = r.fileRequeue(sopsSecret, result.RequeueAfter)
+		}
+	}()
+
 	// Handle deletion
 	if !sopsSecret.DeletionTimestamp.IsZero() {
 		return r.reconcileDelete(ctx, sopsSecret)
@@ -100,23 +319,230 @@ func (r *SopsSecretReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		return ctrl.Result{}, nil
 	}
 
+	// Only write into another namespace that accepts Secrets from this one
+	targetWasRefused := meta.IsStatusConditionTrue(sopsSecret.Status.Conditions,
+		secretsv1alpha1.ConditionTypeTargetNamespaceRefused)
+	if crossNamespace(sopsSecret) {
+		err := r.crossNamespaceAllowed(ctx, sopsSecret, secretNamespace(sopsSecret))
+		if errors.Is(err, errCrossNamespaceRefused) {
+			msg := fmt.Sprintf("Refused to write Secret to namespace %s: %v", secretNamespace(sopsSecret), err)
+			r.setCondition(sopsSecret, secretsv1alpha1.ConditionTypeTargetNamespaceRefused, metav1.ConditionTrue,
+				ReasonTargetRefused, msg)
+			r.setCondition(sopsSecret, secretsv1alpha1.ConditionTypeReady, metav1.ConditionFalse,
+				ReasonTargetRefused, msg)
+			r.Recorder.Eventf(sopsSecret, nil, corev1.EventTypeWarning, ReasonTargetRefused, "Write", "%s", msg)
+			return r.updateStatus(ctx, sopsSecret)
+		}
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+	r.removeCondition(sopsSecret, secretsv1alpha1.ConditionTypeTargetNamespaceRefused)
+
+	// Hold back until the dependency named in the depends-on annotation is ready
+	wasWaiting := meta.IsStatusConditionTrue(sopsSecret.Status.Conditions,
+		secretsv1alpha1.ConditionTypeWaitingForDependency)
+	if value, ok := sopsSecret.Annotations[dependsOnAnnotation]; ok {
+		dep, err := parseDependency(value)
+		if err != nil {
+			r.setCondition(sopsSecret, secretsv1alpha1.ConditionTypeReady, metav1.ConditionFalse,
+				"ValidationFailed", err.Error())
+			r.Recorder.Eventf(sopsSecret, nil, corev1.EventTypeWarning, ReasonValidationFail, "Validate", "%s", err.Error())
+			return r.updateStatus(ctx, sopsSecret)
+		}
+
+		ready, msg, err := r.dependencyReady(ctx, sopsSecret.Namespace, dep)
+		if err != nil {
+			log.Error(err, "Failed to check dependency", "dependency", dep.String())
+			return ctrl.Result{}, err
+		}
+		if !ready {
+			log.Info("Waiting for dependency", "dependency", dep.String())
+			r.setCondition(sopsSecret, secretsv1alpha1.ConditionTypeWaitingForDependency, metav1.ConditionTrue,
+				"DependencyNotReady", msg)
+			r.setCondition(sopsSecret, secretsv1alpha1.ConditionTypeReady, metav1.ConditionFalse,
+				ReasonWaiting, msg)
+			if !wasWaiting {
+				r.Recorder.Eventf(sopsSecret, nil, corev1.EventTypeNormal, ReasonWaiting, "Reconcile", "%s", msg)
+			}
+			return r.updateStatusAndRequeue(ctx, sopsSecret, dependencyRequeueInterval)
+		}
+		r.setCondition(sopsSecret, secretsv1alpha1.ConditionTypeWaitingForDependency, metav1.ConditionFalse,
+			"DependencyReady", fmt.Sprintf("Dependency %s is ready", dep))
+	} else {
+		r.removeCondition(sopsSecret, secretsv1alpha1.ConditionTypeWaitingForDependency)
+	}
+
+	// The recreate annotation stays acknowledged until it is removed, so that
+	// it can be set again for another recreate
+	recreate := recreateRequested(sopsSecret)
+	if sopsSecret.Status.RecreateAcknowledged && sopsSecret.Annotations[recreateAnnotation] != "true" {
+		sopsSecret.Status.RecreateAcknowledged = false
+		if err := r.writeStatus(ctx, sopsSecret); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	// Load the encrypted document
+	sourceWasMissing := meta.IsStatusConditionTrue(sopsSecret.Status.Conditions,
+		secretsv1alpha1.ConditionTypeSourceMissing)
+	payload, err := r.encryptedPayload(ctx, sopsSecret)
+	if errors.Is(err, fs.ErrNotExist) {
+		return r.reconcileSourceMissing(ctx, sopsSecret, err)
+	}
+	var chunkErr *chunkError
+	if errors.As(err, &chunkErr) {
+		r.setCondition(sopsSecret, secretsv1alpha1.ConditionTypeChunksComplete, metav1.ConditionFalse,
+			chunkErr.reason, chunkErr.message)
+	}
+	if err != nil {
+		r.setCondition(sopsSecret, secretsv1alpha1.ConditionTypeReady, metav1.ConditionFalse,
+			ReasonSourceFailed, err.Error())
+		r.Recorder.Eventf(sopsSecret, nil, corev1.EventTypeWarning, ReasonSourceFailed, "Read", "%s", err.Error())
+		return r.updateStatus(ctx, sopsSecret)
+	}
+
+	r.removeCondition(sopsSecret, secretsv1alpha1.ConditionTypeSourceMissing)
+	if chunks := len(sopsSecret.Spec.EncryptedFromChunks); chunks > 0 {
+		r.setCondition(sopsSecret, secretsv1alpha1.ConditionTypeChunksComplete, metav1.ConditionTrue,
+			"Assembled", fmt.Sprintf("Assembled the document from %d chunks", chunks))
+	} else {
+		r.removeCondition(sopsSecret, secretsv1alpha1.ConditionTypeChunksComplete)
+	}
+
+	// Documents encrypted with backends their namespace does not allow are
+	// never decrypted
+	policyWasViolated := meta.IsStatusConditionTrue(sopsSecret.Status.Conditions,
+		secretsv1alpha1.ConditionTypeBackendPolicyViolation)
+	violation, err := r.backendPolicyViolation(ctx, sopsSecret, payload)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if violation != "" {
+		r.setCondition(sopsSecret, secretsv1alpha1.ConditionTypeBackendPolicyViolation, metav1.ConditionTrue,
+			ReasonPolicyViolation, violation)
+		r.setCondition(sopsSecret, secretsv1alpha1.ConditionTypeReady, metav1.ConditionFalse,
+			ReasonPolicyViolation, violation)
+		r.Recorder.Eventf(sopsSecret, nil, corev1.EventTypeWarning, ReasonPolicyViolation, "Validate",
+			"%s", violation)
+		return r.updateStatus(ctx, sopsSecret)
+	}
+	r.removeCondition(sopsSecret, secretsv1alpha1.ConditionTypeBackendPolicyViolation)
+
+	var schedule *cronSchedule
+	if sopsSecret.Spec.RotationSchedule != "" {
+		schedule, err = parseCronSchedule(sopsSecret.Spec.RotationSchedule)
+		if err != nil {
+			msg := fmt.Sprintf("Invalid rotationSchedule %q: %v", sopsSecret.Spec.RotationSchedule, err)
+			r.setCondition(sopsSecret, secretsv1alpha1.ConditionTypeReady, metav1.ConditionFalse,
+				ReasonInvalidSchedule, msg)
+			r.Recorder.Eventf(sopsSecret, nil, corev1.EventTypeWarning, ReasonInvalidSchedule, "Validate", "%s", msg)
+			return r.updateStatus(ctx, sopsSecret)
+		}
+	}
+	rotationDue := r.rotationDue(sopsSecret, schedule)
+	if rotationDue {
+		log.Info("Scheduled rotation is due, decrypting again", "schedule", sopsSecret.Spec.RotationSchedule)
+	}
+
 	// Calculate hash of encrypted data
-	hash := calculateHash(sopsSecret.Spec.SopsSecret)
+	hash := calculateHash(string(payload))
+
+	// A document locked by spec.lockData is not decrypted again once it
+	// differs from the last decrypt. The webhook rejects such spec changes,
+	// this also holds without it and for file and chunk sources
+	dataWasLocked := meta.IsStatusConditionTrue(sopsSecret.Status.Conditions,
+		secretsv1alpha1.ConditionTypeLockedDataChanged)
+	if sopsSecret.Spec.LockData && sopsSecret.Status.LastDecryptedHash != "" &&
+		sopsSecret.Status.LastDecryptedHash != hash {
+		msg := "The encrypted document changed while spec.lockData locks it, the Secret keeps the data of the last decrypt"
+		r.setCondition(sopsSecret, secretsv1alpha1.ConditionTypeLockedDataChanged, metav1.ConditionTrue,
+			ReasonDataLocked, msg)
+		r.setCondition(sopsSecret, secretsv1alpha1.ConditionTypeReady, metav1.ConditionFalse,
+			ReasonDataLocked, msg)
+		r.Recorder.Eventf(sopsSecret, nil, corev1.EventTypeWarning, ReasonDataLocked, "Validate", "%s", msg)
+		return r.updateStatus(ctx, sopsSecret)
+	}
+	r.removeCondition(sopsSecret, secretsv1alpha1.ConditionTypeLockedDataChanged)
+
+	// Check if we need to re-decrypt. A SopsSecret that was waiting for a
+	// dependency or its source, or that violated the backend policy, always
+	// goes through a full reconcile to refresh its status, as does one whose
+	// target namespace refused it, whose locked document had changed, whose
+	// rotation schedule fired or whose reflected copies failed. Toggling
+	// spec.suspend bumps the generation but leaves the spec hash alone, so
+	// unsuspending does not run sops again. A changed schema validates the
+	// data again.
+	reflectionFailed := meta.IsStatusConditionTrue(sopsSecret.Status.Conditions,
+		secretsv1alpha1.ConditionTypeReflectionFailed)
+	currentSpecHash := specHash(sopsSecret.Spec)
+	specUnchanged := sopsSecret.Status.ObservedGeneration == sopsSecret.Generation ||
+		sopsSecret.Status.ObservedSpecHash == currentSpecHash
+	if !recreate && !wasWaiting && !targetWasRefused && !sourceWasMissing && !policyWasViolated &&
+		!dataWasLocked && !reflectionFailed && !rotationDue &&
+		sopsSecret.Status.LastDecryptedHash == hash && specUnchanged && r.schemaUnchanged(ctx, sopsSecret) {
+		// The Secret stays deleted after its TTL until the SopsSecret changes
+		if expiry, ok := secretExpiry(sopsSecret); ok && !r.now().Before(expiry) {
+			return r.reconcileExpired(ctx, sopsSecret)
+		}
 
-	// Check if we need to re-decrypt
-	if sopsSecret.Status.LastDecryptedHash == hash &&
-		sopsSecret.Status.ObservedGeneration == sopsSecret.Generation {
 		// No changes, verify secret still exists
 		secretName := r.getSecretName(sopsSecret)
 		existingSecret := &corev1.Secret{}
-		err := r.Get(ctx, types.NamespacedName{
-			Name:      secretName,
-			Namespace: sopsSecret.Namespace,
-		}, existingSecret)
+		err := r.getManagedSecret(ctx, sopsSecret, existingSecret)
 
+		if err == nil && sopsSecret.Status.ObservedGeneration != sopsSecret.Generation {
+			// Only spec.suspend changed, record the new generation as observed
+			if ownsSecret(existingSecret, sopsSecret) {
+				metav1.SetMetaDataAnnotation(&existingSecret.ObjectMeta, sourceGenerationAnnotation,
+					strconv.FormatInt(sopsSecret.Generation, 10))
+				if err := r.Update(ctx, existingSecret); err != nil {
+					return ctrl.Result{}, err
+				}
+			}
+			sopsSecret.Status.ObservedGeneration = sopsSecret.Generation
+			sopsSecret.Status.ObservedSpecHash = currentSpecHash
+			return r.updateStatusAndRequeue(ctx, sopsSecret, 0)
+		}
 		if err == nil {
-			// Secret exists and no changes, nothing to do
-			return ctrl.Result{}, nil
+			// Secret exists and no changes, only bring legacy metadata keys and
+			// owner references up to date
+			if !ownsSecret(existingSecret, sopsSecret) {
+				return ctrl.Result{RequeueAfter: r.rotationRequeue(sopsSecret, r.expiryRequeue(sopsSecret, 0))}, nil
+			}
+			metadataMigrated := r.migrateLegacyMetadata(existingSecret)
+			ownerMigrated, err := r.migrateOwnerReferences(existingSecret, sopsSecret)
+			if err != nil {
+				return ctrl.Result{}, err
+			}
+			if metadataMigrated || ownerMigrated {
+				if err := r.Update(ctx, existingSecret, client.FieldOwner(fieldManager)); err != nil {
+					log.Error(err, "Failed to migrate Secret metadata")
+					return ctrl.Result{}, err
+				}
+				log.Info("Migrated legacy metadata on Secret", "name", secretName)
+			}
+			// Repair copies that were changed or deleted in the target namespaces
+			if len(sopsSecret.Spec.ReflectToNamespaces) > 0 {
+				if err := r.reconcileReflections(ctx, sopsSecret, existingSecret); err != nil {
+					log.Error(err, "Failed to reflect Secret", "name", secretName)
+					r.setReflectionFailed(sopsSecret, err)
+					return r.updateStatus(ctx, sopsSecret)
+				}
+			}
+			// Repair aliases that were changed or deleted
+			if len(sopsSecret.Spec.Aliases) > 0 {
+				if err := r.reconcileAliases(ctx, sopsSecret, existingSecret); err != nil {
+					log.Error(err, "Failed to write alias Secrets", "name", secretName)
+					return ctrl.Result{}, err
+				}
+			}
+			// Record the UID of a Secret written before status.secretUID existed
+			if sopsSecret.Status.SecretUID != existingSecret.UID {
+				sopsSecret.Status.SecretUID = existingSecret.UID
+				return r.updateStatusAndRequeue(ctx, sopsSecret, 0)
+			}
+			return ctrl.Result{RequeueAfter: r.rotationRequeue(sopsSecret, r.expiryRequeue(sopsSecret, 0))}, nil
 		}
 		if !apierrors.IsNotFound(err) {
 			return ctrl.Result{}, err
@@ -124,8 +550,18 @@ func (r *SopsSecretReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		// Secret was deleted, need to recreate
 	}
 
+	// Plaintext documents are passed through when allowed, and flagged so
+	// they can be audited
+	plaintext := plaintextAllowed(sopsSecret, payload)
+	if plaintext {
+		r.setCondition(sopsSecret, secretsv1alpha1.ConditionTypePlaintext, metav1.ConditionTrue,
+			"PlaintextAllowed", "Document has no sops metadata and is used without decryption")
+	} else {
+		r.removeCondition(sopsSecret, secretsv1alpha1.ConditionTypePlaintext)
+	}
+
 	// Validate encrypted YAML
-	if err := sops.ValidateEncryptedYAML([]byte(sopsSecret.Spec.SopsSecret)); err != nil {
+	if err := r.preValidate(payload); err != nil && !plaintext {
 		r.setCondition(sopsSecret, secretsv1alpha1.ConditionTypeDecrypted, metav1.ConditionFalse,
 			"ValidationFailed", fmt.Sprintf("Invalid SOPS YAML: %v", err))
 		r.setCondition(sopsSecret, secretsv1alpha1.ConditionTypeReady, metav1.ConditionFalse,
@@ -135,70 +571,428 @@ func (r *SopsSecretReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 	}
 
 	// Decrypt the secret
-	decrypted, err := r.Decryptor.Decrypt([]byte(sopsSecret.Spec.SopsSecret))
+	decryptor, err := r.decryptorFor(ctx, sopsSecret, plaintext)
+	if err != nil {
+		r.audit(ctx, sopsSecret, plaintext, err)
+	}
+	if remaining, ok := r.initialGraceRemaining(sopsSecret); err != nil && ok {
+		return r.reconcilePending(ctx, sopsSecret, err, remaining)
+	}
+	r.removeCondition(sopsSecret, secretsv1alpha1.ConditionTypePending)
+	if err != nil {
+		log.Error(err, "Failed to load AGE key Secret")
+		r.setCondition(sopsSecret, secretsv1alpha1.ConditionTypeDecrypted, metav1.ConditionFalse,
+			ReasonKeySecretFailed, err.Error())
+		r.setCondition(sopsSecret, secretsv1alpha1.ConditionTypeReady, metav1.ConditionFalse,
+			ReasonKeySecretFailed, "Failed to load the AGE key Secret")
+		r.Recorder.Eventf(sopsSecret, nil, corev1.EventTypeWarning, ReasonKeySecretFailed, "Decrypt", "%s", err.Error())
+		return r.updateStatus(ctx, sopsSecret)
+	}
+	payload, decrypted, err := r.decryptPayload(ctx, sopsSecret, decryptor, payload)
+	r.audit(ctx, sopsSecret, plaintext, err)
+	if err != nil && ctx.Err() != nil {
+		// The shutdown grace period ran out, leave the SopsSecret to the next run
+		return ctrl.Result{}, err
+	}
+	if remaining, ok := r.initialGraceRemaining(sopsSecret); err != nil && ok {
+		return r.reconcilePending(ctx, sopsSecret, err, remaining)
+	}
+	hash = calculateHash(string(payload))
+	r.recordStartupDecrypt(ctx, err)
+	document := decrypted
+	if err == nil && sopsSecret.Spec.RecursiveDecrypt {
+		decrypted, err = sops.DecryptNested(ctx, decryptor, decrypted, maxNestedDecryptLevels)
+	}
+	if err == nil && sopsSecret.Spec.Format == secretsv1alpha1.FormatCRD {
+		decrypted, err = sops.FromSecretManifest(decrypted)
+	} else if err == nil && sopsSecret.Spec.ComplexValueFormat == secretsv1alpha1.ComplexValueJSON {
+		decrypted, err = sops.ComplexValuesAsJSON(decrypted)
+	}
+	if err == nil && sopsSecret.Spec.OmitNullValues && sopsSecret.Spec.Format != secretsv1alpha1.FormatCRD {
+		decrypted = sops.OmitNullValues(decrypted)
+	}
 	if err != nil {
 		log.Error(err, "Failed to decrypt SopsSecret")
+		reason := ReasonDecryptFailed
+		if sops.IsTooDeeplyNested(err) {
+			reason = ReasonTooDeeplyNested
+			r.setCondition(sopsSecret, secretsv1alpha1.ConditionTypeTooDeeplyNested, metav1.ConditionTrue,
+				reason, err.Error())
+		} else {
+			r.removeCondition(sopsSecret, secretsv1alpha1.ConditionTypeTooDeeplyNested)
+		}
 		r.setCondition(sopsSecret, secretsv1alpha1.ConditionTypeDecrypted, metav1.ConditionFalse,
-			"DecryptFailed", err.Error())
+			reason, err.Error())
+		sopsSecret.Status.LastErrorDetail = ""
+		if r.VerboseErrors {
+			sopsSecret.Status.LastErrorDetail = sops.ErrorDetail(err)
+		}
+		// The managed Secret is never touched on a decrypt failure. If it holds
+		// the result of an earlier decrypt, it stays Ready and the failure is
+		// reported as Degraded.
+		if lastGood := sopsSecret.Status.SecretName; lastGood != "" &&
+			meta.IsStatusConditionTrue(sopsSecret.Status.Conditions, secretsv1alpha1.ConditionTypeReady) {
+			r.setCondition(sopsSecret, secretsv1alpha1.ConditionTypeDegraded, metav1.ConditionTrue,
+				reason, err.Error())
+			r.setCondition(sopsSecret, secretsv1alpha1.ConditionTypeReady, metav1.ConditionTrue,
+				"Stale", fmt.Sprintf("Secret %s is kept from the last successful decrypt", lastGood))
+		} else {
+			r.setCondition(sopsSecret, secretsv1alpha1.ConditionTypeReady, metav1.ConditionFalse,
+				reason, "Failed to decrypt SOPS data")
+		}
+		r.Recorder.Eventf(sopsSecret, nil, corev1.EventTypeWarning, reason, "Decrypt", "%s", err.Error())
+		return r.updateStatus(ctx, sopsSecret)
+	}
+	r.removeCondition(sopsSecret, secretsv1alpha1.ConditionTypeDegraded)
+	r.removeCondition(sopsSecret, secretsv1alpha1.ConditionTypeTooDeeplyNested)
+	sopsSecret.Status.LastErrorDetail = ""
+
+	// Refuse to write keys sops left encrypted, they would become Secret keys
+	// named after their ciphertext
+	if keys := sops.EncryptedKeys(decrypted); len(keys) > 0 {
+		msg := fmt.Sprintf("Document has %d encrypted %s, encrypting map keys is not supported",
+			len(keys), keysNoun(len(keys)))
+		r.setCondition(sopsSecret, secretsv1alpha1.ConditionTypeEncryptedKeyUnsupported, metav1.ConditionTrue,
+			ReasonEncryptedKeys, msg)
 		r.setCondition(sopsSecret, secretsv1alpha1.ConditionTypeReady, metav1.ConditionFalse,
-			"DecryptFailed", "Failed to decrypt SOPS data")
-		r.Recorder.Eventf(sopsSecret, nil, corev1.EventTypeWarning, ReasonDecryptFailed, "Decrypt", "%s", err.Error())
+			ReasonEncryptedKeys, msg)
+		r.Recorder.Eventf(sopsSecret, nil, corev1.EventTypeWarning, ReasonEncryptedKeys, "Validate", "%s", msg)
 		return r.updateStatus(ctx, sopsSecret)
 	}
+	r.removeCondition(sopsSecret, secretsv1alpha1.ConditionTypeEncryptedKeyUnsupported)
 
+	log.V(1).Info("Decrypted SopsSecret", "keys", redactedKeys(*decrypted))
+	r.lintValues(sopsSecret, decrypted)
+	r.checkMAC(sopsSecret, payload)
 	r.setCondition(sopsSecret, secretsv1alpha1.ConditionTypeDecrypted, metav1.ConditionTrue,
 		"Success", "Successfully decrypted SOPS data")
 	r.Recorder.Eventf(sopsSecret, nil, corev1.EventTypeNormal, ReasonDecrypted, "Decrypt", "Successfully decrypted SOPS data")
 
 	// Create or update the Kubernetes Secret
-	secret := r.buildSecret(sopsSecret, decrypted)
+	secret, err := r.buildSecret(ctx, sopsSecret, decrypted)
+	if err != nil {
+		log.Error(err, "Failed to transform Secret values")
+		r.setCondition(sopsSecret, secretsv1alpha1.ConditionTypeReady, metav1.ConditionFalse,
+			ReasonTransformFailed, err.Error())
+		r.Recorder.Eventf(sopsSecret, nil, corev1.EventTypeWarning, ReasonTransformFailed, "Transform", "%s", err.Error())
+		return r.updateStatus(ctx, sopsSecret)
+	}
+
+	// Refuse to write documents with more keys than allowed
+	if r.MaxKeysPerSecret > 0 && len(secret.Data) > r.MaxKeysPerSecret {
+		msg := fmt.Sprintf("Document has %d keys, more than the limit of %d", len(secret.Data), r.MaxKeysPerSecret)
+		r.setCondition(sopsSecret, secretsv1alpha1.ConditionTypeReady, metav1.ConditionFalse,
+			ReasonTooManyKeys, msg)
+		r.Recorder.Eventf(sopsSecret, nil, corev1.EventTypeWarning, ReasonTooManyKeys, "Validate", "%s", msg)
+		return r.updateStatus(ctx, sopsSecret)
+	}
+
+	// Refuse to write json-typed values that consumers cannot parse. The
+	// message names the keys only, parse errors may quote the plaintext.
+	if keys := invalidJSONKeys(sopsSecret, decrypted); len(keys) > 0 {
+		msg := fmt.Sprintf("Values for keys %s are not valid JSON", strings.Join(keys, ", "))
+		r.setCondition(sopsSecret, secretsv1alpha1.ConditionTypeInvalidJSON, metav1.ConditionTrue,
+			ReasonInvalidJSON, msg)
+		r.setCondition(sopsSecret, secretsv1alpha1.ConditionTypeReady, metav1.ConditionFalse,
+			ReasonInvalidJSON, msg)
+		r.Recorder.Eventf(sopsSecret, nil, corev1.EventTypeWarning, ReasonInvalidJSON, "Validate", "%s", msg)
+		return r.updateStatus(ctx, sopsSecret)
+	}
+	r.removeCondition(sopsSecret, secretsv1alpha1.ConditionTypeInvalidJSON)
+
+	// Refuse to write a kubeconfig the downstream controller cannot use
+	if sopsSecret.Spec.ValidateKubeconfig {
+		if err := validateKubeconfig(sopsSecret, decrypted); err != nil {
+			r.setCondition(sopsSecret, secretsv1alpha1.ConditionTypeInvalidKubeconfig, metav1.ConditionTrue,
+				ReasonInvalidKubeconfig, err.Error())
+			r.setCondition(sopsSecret, secretsv1alpha1.ConditionTypeReady, metav1.ConditionFalse,
+				ReasonInvalidKubeconfig, err.Error())
+			r.Recorder.Eventf(sopsSecret, nil, corev1.EventTypeWarning, ReasonInvalidKubeconfig, "Validate", "%s", err.Error())
+			return r.updateStatus(ctx, sopsSecret)
+		}
+	}
+	r.removeCondition(sopsSecret, secretsv1alpha1.ConditionTypeInvalidKubeconfig)
+
+	// Refuse to write data that does not match the referenced schema
+	var schemaVersion string
+	if sopsSecret.Spec.SchemaRef != nil {
+		schema, version, err := r.loadSchema(ctx, sopsSecret)
+		if err != nil {
+			r.setCondition(sopsSecret, secretsv1alpha1.ConditionTypeReady, metav1.ConditionFalse,
+				ReasonSchemaUnavailable, err.Error())
+			r.Recorder.Eventf(sopsSecret, nil, corev1.EventTypeWarning, ReasonSchemaUnavailable, "Validate", "%s", err.Error())
+			return r.updateStatus(ctx, sopsSecret)
+		}
+		violations, err := schemaViolations(schema, sopsSecret, decrypted)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if len(violations) > 0 {
+			msg := fmt.Sprintf("Decrypted data does not match the schema: %s", strings.Join(violations, ", "))
+			r.setCondition(sopsSecret, secretsv1alpha1.ConditionTypeSchemaInvalid, metav1.ConditionTrue,
+				ReasonSchemaInvalid, msg)
+			r.setCondition(sopsSecret, secretsv1alpha1.ConditionTypeReady, metav1.ConditionFalse,
+				ReasonSchemaInvalid, msg)
+			r.Recorder.Eventf(sopsSecret, nil, corev1.EventTypeWarning, ReasonSchemaInvalid, "Validate", "%s", msg)
+			return r.updateStatus(ctx, sopsSecret)
+		}
+		schemaVersion = version
+	}
+	r.removeCondition(sopsSecret, secretsv1alpha1.ConditionTypeSchemaInvalid)
+
+	// Refuse to write values that exceed the configured size limit
+	if limit := r.maxValueBytes(sopsSecret); limit > 0 {
+		if keys := oversizedKeys(secret.Data, limit); len(keys) > 0 {
+			msg := fmt.Sprintf("Values for keys %s exceed the limit of %d bytes", strings.Join(keys, ", "), limit)
+			r.setCondition(sopsSecret, secretsv1alpha1.ConditionTypeReady, metav1.ConditionFalse,
+				ReasonValueTooLarge, msg)
+			r.Recorder.Eventf(sopsSecret, nil, corev1.EventTypeWarning, ReasonValueTooLarge, "Validate", "%s", msg)
+			return r.updateStatus(ctx, sopsSecret)
+		}
+	}
+
+	// Refuse to write data the verifier rejects, the Secret keeps the last
+	// data that passed
+	if err := r.verify(ctx, decrypted); err != nil {
+		msg := fmt.Sprintf("Verification of the decrypted data failed: %v", err)
+		r.setCondition(sopsSecret, secretsv1alpha1.ConditionTypeVerificationFailed, metav1.ConditionTrue,
+			ReasonVerificationFailed, msg)
+		r.setCondition(sopsSecret, secretsv1alpha1.ConditionTypeReady, metav1.ConditionFalse,
+			ReasonVerificationFailed, msg)
+		r.Recorder.Eventf(sopsSecret, nil, corev1.EventTypeWarning, ReasonVerificationFailed, "Verify", "%s", msg)
+		return r.updateStatus(ctx, sopsSecret)
+	}
+	r.removeCondition(sopsSecret, secretsv1alpha1.ConditionTypeVerificationFailed)
 
 	// Set owner reference
-	if err := controllerutil.SetControllerReference(sopsSecret, secret, r.Scheme); err != nil {
+	if err := r.setOwnerReference(secret, sopsSecret); err != nil {
 		log.Error(err, "Failed to set owner reference")
 		return ctrl.Result{}, err
 	}
 
-	// Create or update the secret
+	// Create or update the secret. A generated Secret is never updated with a
+	// new payload, a new generation is created instead.
+	previousName := sopsSecret.Status.SecretName
+	var secretUID types.UID
 	existingSecret := &corev1.Secret{}
-	err = r.Get(ctx, types.NamespacedName{
-		Name:      secret.Name,
-		Namespace: secret.Namespace,
-	}, existingSecret)
+	if sopsSecret.Spec.UseGenerateName && sopsSecret.Status.LastDecryptedHash != hash {
+		err = apierrors.NewNotFound(corev1.Resource("secrets"), secret.Name)
+	} else {
+		err = r.getManagedSecret(ctx, sopsSecret, existingSecret)
+	}
+	// Never take over a Secret in another namespace, it may belong to
+	// another team
+	if err == nil && crossNamespace(sopsSecret) && !ownsSecret(existingSecret, sopsSecret) {
+		msg := fmt.Sprintf("Secret %s/%s exists and is not managed by this SopsSecret",
+			existingSecret.Namespace, existingSecret.Name)
+		r.setCondition(sopsSecret, secretsv1alpha1.ConditionTypeReady, metav1.ConditionFalse,
+			ReasonSecretNotManaged, msg)
+		r.Recorder.Eventf(sopsSecret, existingSecret, corev1.EventTypeWarning, ReasonSecretNotManaged, "Update", "%s", msg)
+		return r.updateStatus(ctx, sopsSecret)
+	}
+	// The type of a Secret is immutable, a type change replaces the Secret.
+	// Check the data fits the new type first, or the old Secret is lost.
+	typeChanged := err == nil && secretTypeChanged(existingSecret, secret)
+	if typeChanged {
+		if err := validateSecretType(secret.Type, secret.Data); err != nil {
+			msg := fmt.Sprintf("Cannot change Secret %s from type %s: %s", secret.Name, existingSecret.Type, err)
+			r.setCondition(sopsSecret, secretsv1alpha1.ConditionTypeReady, metav1.ConditionFalse,
+				ReasonInvalidSecretType, msg)
+			r.Recorder.Eventf(sopsSecret, existingSecret, corev1.EventTypeWarning, ReasonInvalidSecretType, "Validate", "%s", msg)
+			return r.updateStatus(ctx, sopsSecret)
+		}
+	}
+	// Neither can the data of an immutable Secret
+	immutableChanged := err == nil && immutableSecretChanged(existingSecret, secret)
+	if err == nil && (recreate || typeChanged || immutableChanged) {
+		// Replace the existing Secret with a new one instead of updating it
+		deleted, deleteErr := r.deleteForRecreate(ctx, sopsSecret, existingSecret)
+		if deleteErr != nil {
+			log.Error(deleteErr, "Failed to delete Secret for recreate")
+			return ctrl.Result{}, deleteErr
+		}
+		if deleted {
+			existingSecret = &corev1.Secret{}
+			err = apierrors.NewNotFound(corev1.Resource("secrets"), secret.Name)
+		}
+	}
 
 	if apierrors.IsNotFound(err) {
 		// Create new secret
-		if err := r.Create(ctx, secret); err != nil {
+		if sopsSecret.Spec.UseGenerateName {
+			// The API server picks the name, Create fills it in
+			secret.Name = ""
+			secret.GenerateName = r.secretNamePrefix(sopsSecret) + "-"
+		}
+		data := secret.Data
+		if sopsSecret.Spec.StringData {
+			moveToStringData(secret)
+		}
+		if r.ServerSideApply && !sopsSecret.Spec.UseGenerateName {
+			err = r.applySecret(ctx, secret)
+		} else {
+			err = r.Create(ctx, secret, client.FieldOwner(fieldManager))
+		}
+		if err != nil {
 			log.Error(err, "Failed to create Secret")
 			return ctrl.Result{}, err
 		}
+		// Keep working with the values as the API server stores them
+		secret.Data, secret.StringData = data, nil
 		log.Info("Created Secret", "name", secret.Name)
+		secretUID = secret.UID
 		r.Recorder.Eventf(sopsSecret, secret, corev1.EventTypeNormal, ReasonSecretCreated, "Create",
 			"Created Secret %s", secret.Name)
 	} else if err != nil {
 		return ctrl.Result{}, err
 	} else {
-		// Update existing secret
-		existingSecret.Data = secret.Data
-		existingSecret.Labels = secret.Labels
-		existingSecret.Annotations = secret.Annotations
-		existingSecret.Type = secret.Type
+		// Adopt a labeled Secret without an owner before the labels are merged
+		adopted, err := r.adoptSecret(existingSecret, sopsSecret)
+		if err != nil {
+			log.Error(err, "Failed to adopt Secret")
+			return ctrl.Result{}, err
+		}
 
-		if err := r.Update(ctx, existingSecret); err != nil {
+		if r.ServerSideApply {
+			// Apply only the fields the operator sets, the fields of other
+			// field managers are left alone. Owner references and the fields
+			// the operator wrote before with Update are taken over first, so
+			// the apply can change and remove them
+			var ownerMigrated bool
+			if ownerMigrated, err = r.migrateOwnerReferences(existingSecret, sopsSecret); err != nil {
+				return ctrl.Result{}, err
+			}
+			if ownerMigrated {
+				if err := r.Update(ctx, existingSecret, client.FieldOwner(fieldManager)); err != nil {
+					log.Error(err, "Failed to migrate owner references of Secret")
+					return ctrl.Result{}, err
+				}
+			}
+			if err := r.upgradeManagedFields(ctx, existingSecret); err != nil {
+				log.Error(err, "Failed to upgrade managed fields of Secret")
+				return ctrl.Result{}, err
+			}
+			desired := secret.DeepCopy()
+			desired.Name = existingSecret.Name
+			if sopsSecret.Spec.StringData {
+				moveToStringData(desired)
+			}
+			if !ownsSecret(existingSecret, sopsSecret) {
+				desired.OwnerReferences = nil
+			}
+			err = r.applySecret(ctx, desired)
+		} else {
+			// Update existing secret, keeping labels and annotations set by others
+			existingSecret.Data = secret.Data
+			existingSecret.StringData = nil
+			if sopsSecret.Spec.StringData {
+				moveToStringData(existingSecret)
+			}
+			existingSecret.Labels = mergeMetadata(existingSecret.Labels, secret.Labels,
+				managedKeys(existingSecret, managedLabelsAnnotation))
+			existingSecret.Annotations = mergeMetadata(existingSecret.Annotations, secret.Annotations,
+				managedKeys(existingSecret, managedAnnotationsAnnotation))
+			existingSecret.Type = secret.Type
+			existingSecret.Immutable = secret.Immutable
+			if _, err := r.migrateOwnerReferences(existingSecret, sopsSecret); err != nil {
+				return ctrl.Result{}, err
+			}
+			if ownsSecret(existingSecret, sopsSecret) {
+				if err := r.setOwnerReference(existingSecret, sopsSecret); err != nil {
+					log.Error(err, "Failed to set owner reference")
+					return ctrl.Result{}, err
+				}
+			}
+			err = r.Update(ctx, existingSecret, client.FieldOwner(fieldManager))
+		}
+		if err != nil {
 			log.Error(err, "Failed to update Secret")
 			return ctrl.Result{}, err
 		}
 		log.Info("Updated Secret", "name", secret.Name)
+		secretUID = existingSecret.UID
 		r.Recorder.Eventf(sopsSecret, existingSecret, corev1.EventTypeNormal, ReasonSecretUpdated, "Update",
 			"Updated Secret %s", secret.Name)
+		if adopted {
+			msg := fmt.Sprintf("Adopted existing Secret %s", secret.Name)
+			log.Info("Adopted Secret", "name", secret.Name)
+			r.Recorder.Eventf(sopsSecret, existingSecret, corev1.EventTypeNormal, ReasonSecretAdopted, "Adopt", "%s", msg)
+			r.setCondition(sopsSecret, secretsv1alpha1.ConditionTypeAdopted, metav1.ConditionTrue,
+				ReasonSecretAdopted, msg)
+		}
+	}
+
+	// Remove the Secret of the previous generation
+	if sopsSecret.Spec.UseGenerateName && previousName != "" && previousName != secret.Name {
+		if err := r.deletePreviousSecret(ctx, sopsSecret, previousName); err != nil {
+			log.Error(err, "Failed to delete previous Secret", "name", previousName)
+			return ctrl.Result{}, err
+		}
+	}
+
+	// Write the plaintext configuration that travels with the Secret
+	if err := r.reconcileConfigMap(ctx, sopsSecret); err != nil {
+		log.Error(err, "Failed to reconcile ConfigMap", "name", r.secretNamePrefix(sopsSecret))
+		return ctrl.Result{}, err
+	}
+
+	// Publish the key names for discovery
+	if err := r.reconcileKeyList(ctx, sopsSecret, secret); err != nil {
+		log.Error(err, "Failed to reconcile key list ConfigMap", "name", keyListConfigMapName(r.secretNamePrefix(sopsSecret)))
+		return ctrl.Result{}, err
+	}
+
+	// Copy the Secret into the namespaces it is reflected to. A namespace that
+	// fails holds back neither the others nor the rest of the reconcile.
+	if err := r.reconcileReflections(ctx, sopsSecret, secret); err != nil {
+		log.Error(err, "Failed to reflect Secret", "name", secret.Name)
+		r.setReflectionFailed(sopsSecret, err)
+	} else {
+		r.removeCondition(sopsSecret, secretsv1alpha1.ConditionTypeReflectionFailed)
+	}
+
+	// Write the Secret under the names listed in spec.aliases
+	if err := r.reconcileAliases(ctx, sopsSecret, secret); err != nil {
+		log.Error(err, "Failed to write alias Secrets", "name", secret.Name)
+		return ctrl.Result{}, err
+	}
+
+	// Mirror the Secret to Vault
+	if sopsSecret.Spec.VaultSink != nil {
+		if err := r.writeVaultSink(ctx, sopsSecret, secret.Data); err != nil {
+			log.Error(err, "Failed to write Vault secret", "path", sopsSecret.Spec.VaultSink.Path)
+			r.Recorder.Eventf(sopsSecret, nil, corev1.EventTypeWarning, ReasonVaultSinkFailed, "Write", "%s", err.Error())
+			return ctrl.Result{}, err
+		}
+	}
+
+	// Back up the document, a failure does not hold back the Secret
+	if sopsSecret.Spec.BackupRecipient != "" {
+		if err := r.writeBackup(ctx, sopsSecret, document); err != nil {
+			log.Error(err, "Failed to write backup", "name", backupSecretName(sopsSecret))
+			r.Recorder.Eventf(sopsSecret, nil, corev1.EventTypeWarning, ReasonBackupFailed, "Backup", "%s", err.Error())
+		}
 	}
 
 	// Update status
-	now := metav1.Now()
+	now := metav1.NewTime(r.now())
 	sopsSecret.Status.SecretName = secret.Name
+	sopsSecret.Status.SecretUID = secretUID
+	sopsSecret.Status.SchemaResourceVersion = schemaVersion
+	keys := sortedKeys(secret.Data)
+	if msg, changed := keyChangeSummary(sopsSecret.Status.ManagedKeys, keys); changed {
+		r.Recorder.Eventf(sopsSecret, secret, corev1.EventTypeNormal, ReasonKeysChanged, "Update", "%s", msg)
+	}
+	sopsSecret.Status.ManagedKeys = keys
+	sopsSecret.Status.KeySource = r.keySource(sopsSecret, plaintext)
 	sopsSecret.Status.LastDecryptedHash = hash
 	sopsSecret.Status.LastDecryptedTime = &now
 	sopsSecret.Status.ObservedGeneration = sopsSecret.Generation
+	sopsSecret.Status.ObservedSpecHash = currentSpecHash
+	if recreate {
+		sopsSecret.Status.RecreateAcknowledged = true
+	}
+	r.removeCondition(sopsSecret, secretsv1alpha1.ConditionTypeExpired)
 	r.setCondition(sopsSecret, secretsv1alpha1.ConditionTypeReady, metav1.ConditionTrue,
 		"Success", fmt.Sprintf("Secret %s is up to date", secret.Name))
 
@@ -212,36 +1006,119 @@ func (r *SopsSecretReconciler) reconcileDelete(ctx context.Context, sopsSecret *
 		// Delete the managed secret if it exists
 		secretName := r.getSecretName(sopsSecret)
 		secret := &corev1.Secret{}
-		err := r.Get(ctx, types.NamespacedName{
-			Name:      secretName,
-			Namespace: sopsSecret.Namespace,
-		}, secret)
+		err := r.getManagedSecret(ctx, sopsSecret, secret)
 
 		if err == nil {
-			// Check if we own this secret
-			if metav1.IsControlledBy(secret, sopsSecret) {
-				if err := r.Delete(ctx, secret); err != nil && !apierrors.IsNotFound(err) {
+			// Secrets without a controller reference to the SopsSecret are retained
+			retain := ownerReferenceMode(sopsSecret) != secretsv1alpha1.OwnerReferenceController
+			if retain && ownsSecret(secret, sopsSecret) {
+				// Garbage collection deletes a Secret once all its owners are
+				// gone, controller or not, so drop the reference to retain it
+				if releaseSecret(secret, sopsSecret) {
+					if err := r.Update(ctx, secret); client.IgnoreNotFound(err) != nil {
+						return ctrl.Result{}, err
+					}
+				}
+				log.Info("Retained managed Secret", "name", secretName)
+			} else if metav1.IsControlledBy(secret, sopsSecret) || crossNamespace(sopsSecret) && ownsSecret(secret, sopsSecret) {
+				if secret.DeletionTimestamp.IsZero() {
+					if err := r.Delete(ctx, secret); err != nil && !apierrors.IsNotFound(err) {
+						return ctrl.Result{}, err
+					}
+					log.Info("Deleted managed Secret", "name", secretName)
+					r.Recorder.Eventf(sopsSecret, secret, corev1.EventTypeNormal, ReasonSecretDeleted, "Delete",
+						"Deleted Secret %s", secretName)
+				}
+
+				// Other controllers may hold finalizers on the Secret. Keep ours
+				// until the Secret is really gone so cleanup stays ordered.
+				gone, err := r.secretGone(ctx, secret)
+				if err != nil {
 					return ctrl.Result{}, err
 				}
-				log.Info("Deleted managed Secret", "name", secretName)
-				r.Recorder.Eventf(sopsSecret, secret, corev1.EventTypeNormal, ReasonSecretDeleted, "Delete",
-					"Deleted Secret %s", secretName)
+				if !gone {
+					log.Info("Waiting for managed Secret to be removed", "name", secretName)
+					return ctrl.Result{RequeueAfter: secretDeletionRequeueInterval}, nil
+				}
 			}
 		} else if !apierrors.IsNotFound(err) {
 			return ctrl.Result{}, err
 		}
 
+		if err := r.deleteConfigMaps(ctx, sopsSecret); err != nil {
+			return ctrl.Result{}, err
+		}
+		if err := r.deleteReflections(ctx, sopsSecret); err != nil {
+			return ctrl.Result{}, err
+		}
+		if err := r.deleteAliases(ctx, sopsSecret); err != nil {
+			return ctrl.Result{}, err
+		}
+		if sink := sopsSecret.Spec.VaultSink; sink != nil && sink.DeleteOnCleanup && r.VaultSink != nil {
+			if err := r.VaultSink.Delete(ctx, sopsSecret); err != nil {
+				log.Error(err, "Failed to delete Vault secret", "path", sink.Path)
+				r.Recorder.Eventf(sopsSecret, nil, corev1.EventTypeWarning, ReasonVaultSinkFailed, "Delete", "%s", err.Error())
+				return ctrl.Result{}, err
+			}
+			log.Info("Deleted Vault secret", "path", sink.Path)
+		}
+
 		// Remove finalizer
 		controllerutil.RemoveFinalizer(sopsSecret, finalizerName)
 		if err := r.Update(ctx, sopsSecret); err != nil {
 			return ctrl.Result{}, err
 		}
+		forgetConditions(client.ObjectKeyFromObject(sopsSecret))
 	}
 
 	return ctrl.Result{}, nil
 }
 
-func (r *SopsSecretReconciler) buildSecret(sopsSecret *secretsv1alpha1.SopsSecret, decrypted *sops.DecryptedData) *corev1.Secret {
+// reconcileSourceMissing applies spec.sourceDeletionPolicy after the source of
+// the encrypted document has disappeared.
+func (r *SopsSecretReconciler) reconcileSourceMissing(ctx context.Context, sopsSecret *secretsv1alpha1.SopsSecret, sourceErr error) (ctrl.Result, error) {
+	log := logf.FromContext(ctx)
+
+	msg := fmt.Sprintf("Source is missing, keeping the last written Secret: %v", sourceErr)
+	if sopsSecret.Spec.SourceDeletionPolicy == secretsv1alpha1.SourceDeletionDelete {
+		secret := &corev1.Secret{}
+		err := r.getManagedSecret(ctx, sopsSecret, secret)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return ctrl.Result{}, err
+		}
+		if err == nil && ownsSecret(secret, sopsSecret) && secret.DeletionTimestamp.IsZero() {
+			if err := r.Delete(ctx, secret); err != nil && !apierrors.IsNotFound(err) {
+				return ctrl.Result{}, err
+			}
+			log.Info("Deleted managed Secret after its source was removed", "name", secret.Name)
+			r.Recorder.Eventf(sopsSecret, secret, corev1.EventTypeNormal, ReasonSecretDeleted, "Delete",
+				"Deleted Secret %s after its source was removed", secret.Name)
+		}
+		msg = fmt.Sprintf("Source is missing, the managed Secret was deleted: %v", sourceErr)
+	}
+
+	if !meta.IsStatusConditionTrue(sopsSecret.Status.Conditions, secretsv1alpha1.ConditionTypeSourceMissing) {
+		r.Recorder.Eventf(sopsSecret, nil, corev1.EventTypeWarning, ReasonSourceMissing, "Read", "%s", msg)
+	}
+	r.setCondition(sopsSecret, secretsv1alpha1.ConditionTypeSourceMissing, metav1.ConditionTrue,
+		ReasonSourceMissing, msg)
+	r.setCondition(sopsSecret, secretsv1alpha1.ConditionTypeReady, metav1.ConditionFalse,
+		ReasonSourceMissing, msg)
+	return r.updateStatus(ctx, sopsSecret)
+}
+
+// secretGone reports whether the Secret no longer exists in the API server.
+func (r *SopsSecretReconciler) secretGone(ctx context.Context, secret *corev1.Secret) (bool, error) {
+	err := r.Get(ctx, client.ObjectKeyFromObject(secret), &corev1.Secret{})
+	if apierrors.IsNotFound(err) {
+		return true, nil
+	}
+	return false, err
+}
+
+func (r *SopsSecretReconciler) buildSecret(
+	ctx context.Context, sopsSecret *secretsv1alpha1.SopsSecret, decrypted *sops.DecryptedData,
+) (*corev1.Secret, error) {
 	secretName := r.getSecretName(sopsSecret)
 	secretType := sopsSecret.Spec.SecretType
 	if secretType == "" {
@@ -249,36 +1126,120 @@ func (r *SopsSecretReconciler) buildSecret(sopsSecret *secretsv1alpha1.SopsSecre
 	}
 
 	labels := make(map[string]string)
-	labels["app.kubernetes.io/managed-by"] = "sops-operator"
-	labels["secrets.scalaric.io/sopssecret"] = sopsSecret.Name
+	labels[managedByLabel] = "sops-operator"
+	labels[sopsSecretLabel] = sopsSecret.Name
+	if crossNamespace(sopsSecret) {
+		labels[sopsSecretNamespaceLabel] = sopsSecret.Namespace
+	}
 	for k, v := range sopsSecret.Spec.SecretLabels {
 		labels[k] = v
 	}
 
 	annotations := make(map[string]string)
-	annotations["secrets.scalaric.io/source"] = fmt.Sprintf("%s/%s", sopsSecret.Namespace, sopsSecret.Name)
+	annotations[sourceAnnotation] = fmt.Sprintf("%s/%s", sopsSecret.Namespace, sopsSecret.Name)
 	for k, v := range sopsSecret.Spec.SecretAnnotations {
 		annotations[k] = v
 	}
+	annotations[sourceGenerationAnnotation] = strconv.FormatInt(sopsSecret.Generation, 10)
+	annotations[sourceResourceVersionAnnotation] = sopsSecret.ResourceVersion
+	if order := keyOrder(decrypted); len(order) > 0 {
+		annotations[keyOrderAnnotation] = strings.Join(order, ",")
+	}
+	annotations[managedLabelsAnnotation] = strings.Join(sortedKeys(labels), ",")
+	annotations[managedAnnotationsAnnotation] = strings.Join(sortedKeys(annotations), ",")
 
 	// For non-Opaque secret types (e.g. kubernetes.io/dockerconfigjson, kubernetes.io/tls),
 	// use raw decrypted values instead of YAML-wrapped values. Kubernetes validates
 	// the data format for typed secrets, and YAML wrapping breaks that validation.
+	// Values from a Secret manifest are never wrapped.
 	data := decrypted.Data
-	if secretType != corev1.SecretTypeOpaque {
+	wrapped := sopsSecret.Spec.Format != secretsv1alpha1.FormatCRD
+	if secretType != corev1.SecretTypeOpaque && wrapped {
 		data = unwrapYAMLValues(decrypted)
+		wrapped = false
+	}
+	data, err := applyNamedTransforms(sopsSecret, data, wrapped)
+	if err != nil {
+		return nil, err
+	}
+	data, err = r.transformValues(ctx, data)
+	if err != nil {
+		return nil, err
 	}
 
-	return &corev1.Secret{
+	secret := &corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:        secretName,
-			Namespace:   sopsSecret.Namespace,
+			Namespace:   secretNamespace(sopsSecret),
 			Labels:      labels,
 			Annotations: annotations,
 		},
 		Type: secretType,
 		Data: data,
 	}
+	if sopsSecret.Spec.Immutable {
+		secret.Immutable = new(true)
+	}
+	return secret, nil
+}
+
+// keyOrder returns the keys of decrypted in document order, or nil when the
+// order is not known, as for Secret manifests. Keys missing from the order
+// are appended sorted.
+func keyOrder(decrypted *sops.DecryptedData) []string {
+	if len(decrypted.KeyOrder) == 0 {
+		return nil
+	}
+	order := make([]string, 0, len(decrypted.Data))
+	seen := make(map[string]bool, len(decrypted.Data))
+	for _, key := range decrypted.KeyOrder {
+		if _, ok := decrypted.Data[key]; ok && !seen[key] {
+			order = append(order, key)
+			seen[key] = true
+		}
+	}
+	for _, key := range sortedKeys(decrypted.Data) {
+		if !seen[key] {
+			order = append(order, key)
+		}
+	}
+	return order
+}
+
+// lintValues sets the ValueFormatWarning condition for values with leading or
+// trailing whitespace when spec.warnOnTrailingNewline is set. It never blocks
+// the Secret from being written.
+func (r *SopsSecretReconciler) lintValues(sopsSecret *secretsv1alpha1.SopsSecret, decrypted *sops.DecryptedData) {
+	var keys []string
+	if sopsSecret.Spec.WarnOnTrailingNewline {
+		for _, key := range sortedKeys(decrypted.Data) {
+			value := decrypted.Data[key]
+			if sopsSecret.Spec.Format != secretsv1alpha1.FormatCRD {
+				value = []byte(wrappedString(key, value))
+			}
+			if len(bytes.TrimSpace(value)) != len(value) {
+				keys = append(keys, key)
+			}
+		}
+	}
+	if len(keys) == 0 {
+		r.removeCondition(sopsSecret, secretsv1alpha1.ConditionTypeValueFormatWarning)
+		return
+	}
+	r.setCondition(sopsSecret, secretsv1alpha1.ConditionTypeValueFormatWarning, metav1.ConditionTrue,
+		"SurroundingWhitespace", fmt.Sprintf("Values for keys %s start or end with whitespace", strings.Join(keys, ", ")))
+}
+
+// wrappedString returns the string stored under key in a YAML-wrapped value,
+// or "" for other values. The wrapped form has its final newline trimmed, so
+// one is added back to keep the chomping of block scalars intact.
+func wrappedString(key string, wrapped []byte) string {
+	var raw map[string]any
+	if err := yaml.Unmarshal(append(bytes.Clone(wrapped), '\n'), &raw); err != nil {
+		return ""
+	}
+	value, _ := raw[key].(string)
+	return value
 }
 
 // unwrapYAMLValues extracts raw values from YAML-wrapped decrypted data.
@@ -303,14 +1264,168 @@ func unwrapYAMLValues(decrypted *sops.DecryptedData) map[string][]byte {
 	return data
 }
 
+// migrateLegacyMetadata renames labels and annotations listed in
+// LegacyMetadataKeys to their current keys. It reports whether the Secret changed.
+func (r *SopsSecretReconciler) migrateLegacyMetadata(secret *corev1.Secret) bool {
+	changed := false
+	for oldKey, newKey := range r.LegacyMetadataKeys {
+		if renameKey(secret.Labels, oldKey, newKey) {
+			changed = true
+		}
+		if renameKey(secret.Annotations, oldKey, newKey) {
+			changed = true
+		}
+	}
+	return changed
+}
+
+// renameKey moves m[oldKey] to m[newKey]. An existing value under newKey wins.
+func renameKey(m map[string]string, oldKey, newKey string) bool {
+	value, ok := m[oldKey]
+	if !ok {
+		return false
+	}
+	delete(m, oldKey)
+	if _, exists := m[newKey]; !exists {
+		m[newKey] = value
+	}
+	return true
+}
+
+// managedKeys returns the keys listed in the given tracking annotation of a
+// managed Secret. Secrets created before tracking was added have none, so
+// keys removed from the spec stay on them until the next change is tracked.
+func managedKeys(secret *corev1.Secret, annotation string) []string {
+	value := secret.Annotations[annotation]
+	if value == "" {
+		return nil
+	}
+	return strings.Split(value, ",")
+}
+
+// mergeMetadata applies desired on top of existing. Keys in previouslyManaged
+// that are no longer desired are removed, all other existing keys are kept.
+func mergeMetadata(existing, desired map[string]string, previouslyManaged []string) map[string]string {
+	merged := make(map[string]string, len(existing)+len(desired))
+	for k, v := range existing {
+		merged[k] = v
+	}
+	for _, k := range previouslyManaged {
+		if _, ok := desired[k]; !ok {
+			delete(merged, k)
+		}
+	}
+	for k, v := range desired {
+		merged[k] = v
+	}
+	return merged
+}
+
+// preValidate checks the encrypted document before it is handed to sops.
+func (r *SopsSecretReconciler) preValidate(payload []byte) error {
+	if r.SkipPreValidation {
+		return nil
+	}
+	return sops.ValidateEncryptedYAML(payload)
+}
+
+// maxValueBytes returns the per-value size limit for the SopsSecret.
+func (r *SopsSecretReconciler) maxValueBytes(sopsSecret *secretsv1alpha1.SopsSecret) int64 {
+	if sopsSecret.Spec.MaxValueBytes > 0 {
+		return sopsSecret.Spec.MaxValueBytes
+	}
+	return r.MaxValueBytes
+}
+
+// oversizedKeys returns the sorted keys whose values are larger than limit bytes.
+func oversizedKeys(data map[string][]byte, limit int64) []string {
+	var keys []string
+	for key, value := range data {
+		if int64(len(value)) > limit {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// invalidJSONKeys returns the sorted keys typed json in spec.keyTypes whose
+// decrypted values are not well-formed JSON. Nested values re-encoded by
+// complexValueFormat are raw JSON, scalar values of flat documents are checked
+// without their YAML wrapping. Typed keys missing from the document are ignored.
+func invalidJSONKeys(sopsSecret *secretsv1alpha1.SopsSecret, decrypted *sops.DecryptedData) []string {
+	var keys []string
+	for key, keyType := range sopsSecret.Spec.KeyTypes {
+		value, ok := decrypted.Data[key]
+		if !ok || keyType != secretsv1alpha1.KeyTypeJSON || json.Valid(value) {
+			continue
+		}
+		if sopsSecret.Spec.Format != secretsv1alpha1.FormatCRD && json.Valid([]byte(wrappedString(key, value))) {
+			continue
+		}
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
 func (r *SopsSecretReconciler) getSecretName(sopsSecret *secretsv1alpha1.SopsSecret) string {
+	if sopsSecret.Spec.UseGenerateName {
+		return sopsSecret.Status.SecretName
+	}
+	return r.secretNamePrefix(sopsSecret)
+}
+
+// secretNamePrefix returns the fixed Secret name, or the base of generated names.
+func (r *SopsSecretReconciler) secretNamePrefix(sopsSecret *secretsv1alpha1.SopsSecret) string {
+	return fixedSecretName(sopsSecret)
+}
+
+// fixedSecretName returns spec.secretName, defaulting to the SopsSecret name.
+func fixedSecretName(sopsSecret *secretsv1alpha1.SopsSecret) string {
 	if sopsSecret.Spec.SecretName != "" {
 		return sopsSecret.Spec.SecretName
 	}
 	return sopsSecret.Name
 }
 
+// getManagedSecret fetches the Secret the SopsSecret currently manages.
+func (r *SopsSecretReconciler) getManagedSecret(ctx context.Context, sopsSecret *secretsv1alpha1.SopsSecret, secret *corev1.Secret) error {
+	name := r.getSecretName(sopsSecret)
+	if name == "" {
+		// No generated Secret has been created yet
+		return apierrors.NewNotFound(corev1.Resource("secrets"), name)
+	}
+	return r.Get(ctx, types.NamespacedName{Name: name, Namespace: secretNamespace(sopsSecret)}, secret)
+}
+
+// deletePreviousSecret removes a Secret from an earlier generation if the
+// SopsSecret owns it.
+func (r *SopsSecretReconciler) deletePreviousSecret(ctx context.Context, sopsSecret *secretsv1alpha1.SopsSecret, name string) error {
+	previous := &corev1.Secret{}
+	err := r.Get(ctx, types.NamespacedName{Name: name, Namespace: secretNamespace(sopsSecret)}, previous)
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if !ownsSecret(previous, sopsSecret) {
+		return nil
+	}
+	if err := r.Delete(ctx, previous); err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	logf.FromContext(ctx).Info("Deleted previous Secret", "name", name)
+	r.Recorder.Eventf(sopsSecret, previous, corev1.EventTypeNormal, ReasonSecretDeleted, "Delete",
+		"Deleted previous Secret %s", name)
+	return nil
+}
+
 func (r *SopsSecretReconciler) setCondition(sopsSecret *secretsv1alpha1.SopsSecret, condType string, status metav1.ConditionStatus, reason, message string) {
+	if condType == secretsv1alpha1.ConditionTypeReady && !r.stabilizeReady(sopsSecret, status, r.now()) {
+		return
+	}
 	meta.SetStatusCondition(&sopsSecret.Status.Conditions, metav1.Condition{
 		Type:               condType,
 		Status:             status,
@@ -319,15 +1434,95 @@ func (r *SopsSecretReconciler) setCondition(sopsSecret *secretsv1alpha1.SopsSecr
 		Message:            message,
 		LastTransitionTime: metav1.Now(),
 	})
+	recordCondition(client.ObjectKeyFromObject(sopsSecret), condType, status)
+}
+
+// removeCondition drops a condition that no longer applies.
+func (r *SopsSecretReconciler) removeCondition(sopsSecret *secretsv1alpha1.SopsSecret, condType string) {
+	meta.RemoveStatusCondition(&sopsSecret.Status.Conditions, condType)
+	forgetCondition(client.ObjectKeyFromObject(sopsSecret), condType)
 }
 
 func (r *SopsSecretReconciler) updateStatus(ctx context.Context, sopsSecret *secretsv1alpha1.SopsSecret) (ctrl.Result, error) {
+	// Requeue to periodically verify secret, or sooner to retry a failure.
+	// The backoff depends on the summarized Ready condition.
+	r.summarizeReady(sopsSecret)
+	after := r.reconcileInterval(sopsSecret)
+	if backoff, ok := r.failureRequeue(sopsSecret); ok {
+		after = backoff
+	}
+	return r.writeStatusAndRequeue(ctx, sopsSecret, after)
+}
+
+// reconcileInterval returns how long to wait before reconciling the
+// SopsSecret again after a successful write.
+func (r *SopsSecretReconciler) reconcileInterval(sopsSecret *secretsv1alpha1.SopsSecret) time.Duration {
+	if interval := sopsSecret.Spec.ReconcileInterval; interval != nil && interval.Duration > 0 {
+		return interval.Duration
+	}
+	if r.ReconcileInterval > 0 {
+		return r.ReconcileInterval
+	}
+	return DefaultReconcileInterval
+}
+
+// updateStatusAndRequeue writes the status and requeues after the given interval.
+// Ready is lowered first if one of its sub-conditions fails.
+func (r *SopsSecretReconciler) updateStatusAndRequeue(ctx context.Context, sopsSecret *secretsv1alpha1.SopsSecret, after time.Duration) (ctrl.Result, error) {
+	r.summarizeReady(sopsSecret)
+	return r.writeStatusAndRequeue(ctx, sopsSecret, after)
+}
+
+// writeStatusAndRequeue writes the status, with Ready already summarized,
+// and requeues after the given interval. When the status cannot be persisted
+// because the CRD lacks the status subresource, the problem is reported
+// without failing the reconcile, since retrying with backoff cannot fix an
+// install issue.
+func (r *SopsSecretReconciler) writeStatusAndRequeue(ctx context.Context, sopsSecret *secretsv1alpha1.SopsSecret, after time.Duration) (ctrl.Result, error) {
+	if err := r.writeStatus(ctx, sopsSecret); err != nil {
+		if !errors.Is(err, errStatusNotPersisted) {
+			return ctrl.Result{}, err
+		}
+		logf.FromContext(ctx).Error(err, "SopsSecret status is not persisted, "+
+			"make sure the installed CRD has the status subresource enabled")
+		r.Recorder.Eventf(sopsSecret, nil, corev1.EventTypeWarning, ReasonStatusNotPersisted, "UpdateStatus",
+			"Status updates are not persisted, reinstall the SopsSecret CRD with the status subresource enabled")
+	}
+	after = r.stabilizationRequeue(sopsSecret, after, r.now())
+	return ctrl.Result{RequeueAfter: r.rotationRequeue(sopsSecret, r.expiryRequeue(sopsSecret, after))}, nil
+}
+
+// errStatusNotPersisted is returned by writeStatus when the API server did not
+// store the status, typically because the CRD has no status subresource.
+var errStatusNotPersisted = errors.New("status update was not persisted")
+
+// writeStatus updates the status subresource and checks that the API server
+// returned the status it was sent.
+func (r *SopsSecretReconciler) writeStatus(ctx context.Context, sopsSecret *secretsv1alpha1.SopsSecret) error {
+	sent := statusSummary(&sopsSecret.Status)
 	if err := r.Status().Update(ctx, sopsSecret); err != nil {
-		return ctrl.Result{}, err
+		// Without the subresource the status endpoint does not exist
+		if apierrors.IsNotFound(err) &&
+			r.Get(ctx, client.ObjectKeyFromObject(sopsSecret), &secretsv1alpha1.SopsSecret{}) == nil {
+			return fmt.Errorf("%w: %v", errStatusNotPersisted, err)
+		}
+		return err
 	}
+	if statusSummary(&sopsSecret.Status) != sent {
+		return errStatusNotPersisted
+	}
+	return nil
+}
 
-	// Requeue after 5 minutes to periodically verify secret
-	return ctrl.Result{RequeueAfter: 5 * time.Minute}, nil
+// statusSummary renders the parts of the status that must survive a round trip
+// through the API server. Timestamps are left out since they lose precision.
+func statusSummary(status *secretsv1alpha1.SopsSecretStatus) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s|%s|%d", status.SecretName, status.LastDecryptedHash, status.ObservedGeneration)
+	for _, c := range status.Conditions {
+		fmt.Fprintf(&b, "|%s=%s/%s", c.Type, c.Status, c.Reason)
+	}
+	return b.String()
 }
 
 func calculateHash(data string) string {
@@ -335,11 +1530,26 @@ func calculateHash(data string) string {
 	return hex.EncodeToString(hash[:])
 }
 
+// specHash hashes the spec without spec.suspend, which does not affect the
+// Secret. It is compared to status.observedSpecHash when the generation moved.
+func specHash(spec secretsv1alpha1.SopsSecretSpec) string {
+	spec.Suspend = false
+	// Marshaling a spec cannot fail, map keys are sorted
+	data, _ := json.Marshal(spec)
+	return calculateHash(string(data))
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *SopsSecretReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&secretsv1alpha1.SopsSecret{}).
+		For(&secretsv1alpha1.SopsSecret{}, builder.WithPredicates(sopsSecretChanged)).
 		Owns(&corev1.Secret{}).
+		Owns(&corev1.ConfigMap{}).
+		Watches(&secretsv1alpha1.SopsSecret{}, handler.EnqueueRequestsFromMapFunc(r.dependentsOf(kindSopsSecret))).
+		Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(r.dependentsOf(kindSecret))).
+		Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(sopsSecretOfTargetSecret)).
+		Watches(&corev1.ConfigMap{}, handler.EnqueueRequestsFromMapFunc(r.sopsSecretsUsingSchema)).
+		Watches(&corev1.Namespace{}, handler.EnqueueRequestsFromMapFunc(r.sopsSecretsInNamespace)).
 		Named("sopssecret").
 		Complete(r)
 }
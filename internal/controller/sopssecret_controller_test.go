@@ -18,13 +18,24 @@ package controller
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -33,6 +44,8 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	secretsv1alpha1 "github.com/scalaric/sops-operator/api/v1alpha1"
@@ -140,6 +153,26 @@ func (e *ErrorClient) Status() client.StatusWriter {
 	return e.Client.Status()
 }
 
+// DiscardingStatusClient simulates a CRD without the status subresource being
+// served by an API server that ignores status on update: the call succeeds
+// but the returned object carries the previously stored status.
+type DiscardingStatusClient struct {
+	client.Client
+}
+
+func (d *DiscardingStatusClient) Status() client.StatusWriter {
+	return &discardingStatusWriter{StatusWriter: d.Client.Status(), client: d.Client}
+}
+
+type discardingStatusWriter struct {
+	client.StatusWriter
+	client client.Client
+}
+
+func (d *discardingStatusWriter) Update(ctx context.Context, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+	return d.client.Get(ctx, client.ObjectKeyFromObject(obj), obj)
+}
+
 // DeletionTimestampClient wraps a client and sets DeletionTimestamp on Get
 // It also handles Update to clear DeletionTimestamp before updating (simulating K8s behavior)
 type DeletionTimestampClient struct {
@@ -269,7 +302,8 @@ sops:
 					},
 				}
 
-				secret := reconciler.buildSecret(sopsSecret, decrypted)
+				secret, err := reconciler.buildSecret(ctx, sopsSecret, decrypted)
+				Expect(err).NotTo(HaveOccurred())
 
 				Expect(secret.Name).To(Equal("my-sops-secret"))
 				Expect(secret.Namespace).To(Equal("default"))
@@ -298,7 +332,8 @@ sops:
 					},
 				}
 
-				secret := reconciler.buildSecret(sopsSecret, decrypted)
+				secret, err := reconciler.buildSecret(ctx, sopsSecret, decrypted)
+				Expect(err).NotTo(HaveOccurred())
 
 				Expect(secret.Type).To(Equal(corev1.SecretTypeTLS))
 			})
@@ -322,7 +357,8 @@ sops:
 					Data: map[string][]byte{},
 				}
 
-				secret := reconciler.buildSecret(sopsSecret, decrypted)
+				secret, err := reconciler.buildSecret(ctx, sopsSecret, decrypted)
+				Expect(err).NotTo(HaveOccurred())
 
 				Expect(secret.Labels["custom-label"]).To(Equal("custom-value"))
 				Expect(secret.Annotations["custom-annotation"]).To(Equal("custom-value"))
@@ -342,10 +378,40 @@ sops:
 					Data: map[string][]byte{},
 				}
 
-				secret := reconciler.buildSecret(sopsSecret, decrypted)
+				secret, err := reconciler.buildSecret(ctx, sopsSecret, decrypted)
+				Expect(err).NotTo(HaveOccurred())
 
 				Expect(secret.Name).To(Equal("custom-name"))
 			})
+
+			It("should serialize the same Secret to identical bytes", func() {
+				sopsSecret := &secretsv1alpha1.SopsSecret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "my-sops-secret",
+						Namespace: "default",
+					},
+					Spec: secretsv1alpha1.SopsSecretSpec{
+						SecretLabels:      map[string]string{"team": "a", "app": "b", "tier": "c"},
+						SecretAnnotations: map[string]string{"z": "1", "y": "2", "x": "3"},
+					},
+				}
+				decrypted := &sops.DecryptedData{Data: map[string][]byte{}}
+				for i := range 50 {
+					decrypted.Data[fmt.Sprintf("key-%02d", i)] = []byte(fmt.Sprintf("key-%02d: %d", i, i))
+				}
+
+				var first []byte
+				for range 20 {
+					secret, err := reconciler.buildSecret(ctx, sopsSecret, decrypted)
+					Expect(err).NotTo(HaveOccurred())
+					serialized, err := json.Marshal(secret)
+					Expect(err).NotTo(HaveOccurred())
+					if first == nil {
+						first = serialized
+					}
+					Expect(serialized).To(Equal(first))
+				}
+			})
 		})
 
 		Describe("setCondition", func() {
@@ -783,6 +849,114 @@ sops:
 			})
 		})
 
+		Describe("Legacy metadata migration", func() {
+			const payload = `test: value
+sops:
+    mac: test
+`
+			var sopsSecret *secretsv1alpha1.SopsSecret
+
+			BeforeEach(func() {
+				reconciler.LegacyMetadataKeys = map[string]string{
+					"sops-operator/sopssecret": sopsSecretLabel,
+					"sops-operator/source":     sourceAnnotation,
+				}
+				sopsSecret = &secretsv1alpha1.SopsSecret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:       "legacy-labels",
+						Namespace:  "default",
+						Finalizers: []string{finalizerName},
+						Generation: 1,
+						UID:        "legacy-uid",
+					},
+					Spec: secretsv1alpha1.SopsSecretSpec{SopsSecret: payload},
+					Status: secretsv1alpha1.SopsSecretStatus{
+						LastDecryptedHash:  calculateHash(payload),
+						ObservedGeneration: 1,
+						SecretName:         "legacy-labels",
+					},
+				}
+				Expect(reconciler.Client.Create(ctx, sopsSecret)).To(Succeed())
+			})
+
+			It("should rename legacy keys on an owned Secret", func() {
+				secret := &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "legacy-labels",
+						Namespace:   "default",
+						Labels:      map[string]string{"sops-operator/sopssecret": "legacy-labels", "team": "a"},
+						Annotations: map[string]string{"sops-operator/source": "default/legacy-labels"},
+					},
+					Data: map[string][]byte{"test": []byte("value")},
+				}
+				Expect(controllerutil.SetControllerReference(sopsSecret, secret, scheme.Scheme)).To(Succeed())
+				Expect(reconciler.Client.Create(ctx, secret)).To(Succeed())
+
+				result, err := reconciler.Reconcile(ctx, reconcile.Request{
+					NamespacedName: types.NamespacedName{Name: "legacy-labels", Namespace: "default"},
+				})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result).To(Equal(ctrl.Result{}))
+
+				migrated := &corev1.Secret{}
+				Expect(reconciler.Get(ctx, client.ObjectKeyFromObject(secret), migrated)).To(Succeed())
+				Expect(migrated.Labels).To(HaveKeyWithValue(sopsSecretLabel, "legacy-labels"))
+				Expect(migrated.Labels).NotTo(HaveKey("sops-operator/sopssecret"))
+				Expect(migrated.Labels).To(HaveKeyWithValue("team", "a"))
+				Expect(migrated.Annotations).To(HaveKeyWithValue(sourceAnnotation, "default/legacy-labels"))
+				Expect(migrated.Annotations).NotTo(HaveKey("sops-operator/source"))
+			})
+
+			It("should leave Secrets it does not control untouched", func() {
+				secret := &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "legacy-labels",
+						Namespace: "default",
+						Labels:    map[string]string{"sops-operator/sopssecret": "legacy-labels"},
+					},
+					Data: map[string][]byte{"test": []byte("value")},
+				}
+				Expect(reconciler.Client.Create(ctx, secret)).To(Succeed())
+
+				_, err := reconciler.Reconcile(ctx, reconcile.Request{
+					NamespacedName: types.NamespacedName{Name: "legacy-labels", Namespace: "default"},
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				unchanged := &corev1.Secret{}
+				Expect(reconciler.Get(ctx, client.ObjectKeyFromObject(secret), unchanged)).To(Succeed())
+				Expect(unchanged.Labels).To(HaveKey("sops-operator/sopssecret"))
+				Expect(unchanged.Labels).NotTo(HaveKey(sopsSecretLabel))
+			})
+		})
+
+		Describe("renameKey", func() {
+			It("should keep an existing value under the new key", func() {
+				m := map[string]string{"old": "stale", "new": "current"}
+				Expect(renameKey(m, "old", "new")).To(BeTrue())
+				Expect(m).To(Equal(map[string]string{"new": "current"}))
+			})
+
+			It("should report no change when the old key is absent", func() {
+				Expect(renameKey(nil, "old", "new")).To(BeFalse())
+			})
+		})
+
+		Describe("mergeMetadata", func() {
+			It("should only remove previously managed keys", func() {
+				existing := map[string]string{"managed": "old", "stale": "x", "foreign": "y"}
+				desired := map[string]string{"managed": "new"}
+
+				merged := mergeMetadata(existing, desired, []string{"managed", "stale"})
+				Expect(merged).To(Equal(map[string]string{"managed": "new", "foreign": "y"}))
+			})
+
+			It("should keep every existing key when nothing was tracked", func() {
+				merged := mergeMetadata(map[string]string{"stale": "x"}, map[string]string{"a": "b"}, nil)
+				Expect(merged).To(Equal(map[string]string{"stale": "x", "a": "b"}))
+			})
+		})
+
 		Describe("reconcileDelete with owned secret", func() {
 			It("should delete owned secret during reconcileDelete", func() {
 				sopsSecret := &secretsv1alpha1.SopsSecret{
@@ -826,6 +1000,60 @@ sops:
 				Expect(err).NotTo(HaveOccurred())
 				Expect(result).To(Equal(ctrl.Result{}))
 			})
+
+			It("should keep the finalizer until a Secret with foreign finalizers is gone", func() {
+				sopsSecret := &secretsv1alpha1.SopsSecret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:       "delete-foreign-finalizer",
+						Namespace:  "default",
+						Finalizers: []string{finalizerName},
+						UID:        "test-uid-456",
+					},
+					Spec: secretsv1alpha1.SopsSecretSpec{
+						SopsSecret: `test: value
+sops:
+    mac: test
+`,
+					},
+				}
+				Expect(reconciler.Client.Create(ctx, sopsSecret)).To(Succeed())
+
+				secret := &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:       "delete-foreign-finalizer",
+						Namespace:  "default",
+						Finalizers: []string{"example.com/backup"},
+					},
+					Data: map[string][]byte{"test": []byte("value")},
+				}
+				Expect(controllerutil.SetControllerReference(sopsSecret, secret, scheme.Scheme)).To(Succeed())
+				Expect(reconciler.Client.Create(ctx, secret)).To(Succeed())
+
+				result, err := reconciler.reconcileDelete(ctx, sopsSecret)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.RequeueAfter).To(Equal(secretDeletionRequeueInterval))
+
+				stored := &secretsv1alpha1.SopsSecret{}
+				Expect(reconciler.Get(ctx, client.ObjectKeyFromObject(sopsSecret), stored)).To(Succeed())
+				Expect(stored.Finalizers).To(ContainElement(finalizerName))
+
+				// A second pass while the Secret is terminating must not delete it again
+				result, err = reconciler.reconcileDelete(ctx, stored)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.RequeueAfter).To(Equal(secretDeletionRequeueInterval))
+
+				// The other controller releases the Secret
+				terminating := &corev1.Secret{}
+				Expect(reconciler.Get(ctx, client.ObjectKeyFromObject(secret), terminating)).To(Succeed())
+				Expect(terminating.DeletionTimestamp).NotTo(BeNil())
+				terminating.Finalizers = nil
+				Expect(reconciler.Update(ctx, terminating)).To(Succeed())
+
+				result, err = reconciler.reconcileDelete(ctx, stored)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result).To(Equal(ctrl.Result{}))
+				Expect(stored.Finalizers).NotTo(ContainElement(finalizerName))
+			})
 		})
 	})
 
@@ -1004,151 +1232,5170 @@ sops:
 				Expect(updated.Status.Conditions).NotTo(BeEmpty())
 			})
 		})
-	})
-
-	Context("Error handling with ErrorClient", func() {
-		var (
-			ctx context.Context
-		)
-
-		BeforeEach(func() {
-			ctx = context.Background()
-		})
-
-		Describe("Reconcile error paths", func() {
-			It("should return error when Get SopsSecret fails (non-NotFound)", func() {
-				fakeClient := fake.NewClientBuilder().
-					WithScheme(scheme.Scheme).
-					Build()
 
-				errorClient := &ErrorClient{
-					Client:   fakeClient,
-					GetError: fmt.Errorf("connection refused"),
-				}
-
-				reconciler := &SopsSecretReconciler{
-					Client:    errorClient,
-					Scheme:    scheme.Scheme,
-					Recorder:  &events.FakeRecorder{},
-					Decryptor: &MockDecryptor{},
-				}
-
-				req := reconcile.Request{
-					NamespacedName: types.NamespacedName{
-						Name:      "test-secret",
-						Namespace: "default",
-					},
+		Describe("Value size limit", func() {
+			BeforeEach(func() {
+				mockDecryptor.DecryptFunc = func(data []byte) (*sops.DecryptedData, error) {
+					return &sops.DecryptedData{
+						Data: map[string][]byte{
+							"token": []byte("short"),
+							"blob":  []byte("0123456789abcdef"),
+						},
+					}, nil
 				}
-
-				result, err := reconciler.Reconcile(ctx, req)
-
-				Expect(err).To(HaveOccurred())
-				Expect(err.Error()).To(ContainSubstring("connection refused"))
-				Expect(result).To(Equal(ctrl.Result{}))
 			})
 
-			It("should return error when adding finalizer fails", func() {
-				fakeClient := fake.NewClientBuilder().
-					WithScheme(scheme.Scheme).
-					WithStatusSubresource(&secretsv1alpha1.SopsSecret{}).
-					Build()
-
-				// Create the SopsSecret first
-				sopsSecret := &secretsv1alpha1.SopsSecret{
+			newSopsSecret := func(name string, maxValueBytes int64) *secretsv1alpha1.SopsSecret {
+				return &secretsv1alpha1.SopsSecret{
 					ObjectMeta: metav1.ObjectMeta{
-						Name:      "finalizer-error",
-						Namespace: "default",
+						Name:       name,
+						Namespace:  "default",
+						Finalizers: []string{finalizerName},
 					},
 					Spec: secretsv1alpha1.SopsSecretSpec{
-						SopsSecret: `test: value
+						SopsSecret: `token: ENC[test]
+blob: ENC[test]
 sops:
     mac: test
 `,
+						MaxValueBytes: maxValueBytes,
 					},
 				}
-				Expect(fakeClient.Create(ctx, sopsSecret)).To(Succeed())
+			}
 
-				errorClient := &ErrorClient{
-					Client:      fakeClient,
-					UpdateError: fmt.Errorf("update failed"),
-				}
+			It("should write the Secret when all values are under the limit", func() {
+				mockReconciler.MaxValueBytes = 16
+				sopsSecret := newSopsSecret("values-under-limit", 0)
+				Expect(mockReconciler.Create(ctx, sopsSecret)).To(Succeed())
 
-				reconciler := &SopsSecretReconciler{
-					Client:    errorClient,
-					Scheme:    scheme.Scheme,
-					Recorder:  &events.FakeRecorder{},
-					Decryptor: &MockDecryptor{},
-				}
+				_, err := mockReconciler.Reconcile(ctx, reconcile.Request{
+					NamespacedName: client.ObjectKeyFromObject(sopsSecret),
+				})
+				Expect(err).NotTo(HaveOccurred())
 
-				req := reconcile.Request{
-					NamespacedName: types.NamespacedName{
-						Name:      "finalizer-error",
-						Namespace: "default",
-					},
-				}
+				secret := &corev1.Secret{}
+				Expect(mockReconciler.Get(ctx, client.ObjectKeyFromObject(sopsSecret), secret)).To(Succeed())
+				Expect(secret.Data).To(HaveKey("blob"))
+			})
 
-				result, err := reconciler.Reconcile(ctx, req)
+			It("should not write the Secret when a value exceeds the spec limit", func() {
+				mockReconciler.MaxValueBytes = 1024
+				sopsSecret := newSopsSecret("values-over-limit", 8)
+				Expect(mockReconciler.Create(ctx, sopsSecret)).To(Succeed())
 
-				Expect(err).To(HaveOccurred())
-				Expect(err.Error()).To(ContainSubstring("update failed"))
-				Expect(result).To(Equal(ctrl.Result{}))
+				_, err := mockReconciler.Reconcile(ctx, reconcile.Request{
+					NamespacedName: client.ObjectKeyFromObject(sopsSecret),
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				secret := &corev1.Secret{}
+				err = mockReconciler.Get(ctx, client.ObjectKeyFromObject(sopsSecret), secret)
+				Expect(errors.IsNotFound(err)).To(BeTrue())
+
+				updated := &secretsv1alpha1.SopsSecret{}
+				Expect(mockReconciler.Get(ctx, client.ObjectKeyFromObject(sopsSecret), updated)).To(Succeed())
+				ready := meta.FindStatusCondition(updated.Status.Conditions, secretsv1alpha1.ConditionTypeReady)
+				Expect(ready).NotTo(BeNil())
+				Expect(ready.Status).To(Equal(metav1.ConditionFalse))
+				Expect(ready.Reason).To(Equal(ReasonValueTooLarge))
+				Expect(ready.Message).To(ContainSubstring("blob"))
+				Expect(ready.Message).NotTo(ContainSubstring("token"))
 			})
+		})
 
-			It("should return error when checking existing secret fails (non-NotFound)", func() {
-				fakeClient := fake.NewClientBuilder().
-					WithScheme(scheme.Scheme).
-					WithStatusSubresource(&secretsv1alpha1.SopsSecret{}).
-					Build()
+		Describe("Dependencies", func() {
+			BeforeEach(func() {
+				mockDecryptor.DecryptFunc = func(data []byte) (*sops.DecryptedData, error) {
+					return &sops.DecryptedData{
+						Data: map[string][]byte{"token": []byte("abc")},
+					}, nil
+				}
+			})
 
-				hash := calculateHash(`test: value
-sops:
-    mac: test
-`)
-				sopsSecret := &secretsv1alpha1.SopsSecret{
+			newSopsSecret := func(name, dependsOn string) *secretsv1alpha1.SopsSecret {
+				return &secretsv1alpha1.SopsSecret{
 					ObjectMeta: metav1.ObjectMeta{
-						Name:       "get-secret-error",
-						Namespace:  "default",
-						Finalizers: []string{finalizerName},
-						Generation: 1,
+						Name:        name,
+						Namespace:   "default",
+						Finalizers:  []string{finalizerName},
+						Annotations: map[string]string{dependsOnAnnotation: dependsOn},
 					},
 					Spec: secretsv1alpha1.SopsSecretSpec{
-						SopsSecret: `test: value
+						SopsSecret: `token: ENC[test]
 sops:
     mac: test
 `,
 					},
-					Status: secretsv1alpha1.SopsSecretStatus{
-						LastDecryptedHash:  hash,
-						ObservedGeneration: 1,
-					},
 				}
-				Expect(fakeClient.Create(ctx, sopsSecret)).To(Succeed())
+			}
 
-				errorClient := &ErrorClient{
-					Client:         fakeClient,
-					GetError:       fmt.Errorf("network timeout"),
-					GetErrorOnCall: 2, // Error on second Get (checking secret)
-				}
+			It("should wait for a SopsSecret dependency and proceed once it is ready", func() {
+				dependency := newSopsSecret("dep-base", "")
+				delete(dependency.Annotations, dependsOnAnnotation)
+				Expect(mockReconciler.Create(ctx, dependency)).To(Succeed())
 
-				reconciler := &SopsSecretReconciler{
-					Client:    errorClient,
-					Scheme:    scheme.Scheme,
-					Recorder:  &events.FakeRecorder{},
-					Decryptor: &MockDecryptor{},
-				}
+				dependent := newSopsSecret("dep-app", "dep-base")
+				Expect(mockReconciler.Create(ctx, dependent)).To(Succeed())
+				req := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(dependent)}
 
-				req := reconcile.Request{
-					NamespacedName: types.NamespacedName{
-						Name:      "get-secret-error",
-						Namespace: "default",
-					},
-				}
+				result, err := mockReconciler.Reconcile(ctx, req)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.RequeueAfter).To(Equal(dependencyRequeueInterval))
 
-				result, err := reconciler.Reconcile(ctx, req)
+				secret := &corev1.Secret{}
+				err = mockReconciler.Get(ctx, req.NamespacedName, secret)
+				Expect(errors.IsNotFound(err)).To(BeTrue())
 
-				Expect(err).To(HaveOccurred())
-				Expect(err.Error()).To(ContainSubstring("network timeout"))
-				Expect(result).To(Equal(ctrl.Result{}))
+				updated := &secretsv1alpha1.SopsSecret{}
+				Expect(mockReconciler.Get(ctx, req.NamespacedName, updated)).To(Succeed())
+				Expect(meta.IsStatusConditionTrue(updated.Status.Conditions,
+					secretsv1alpha1.ConditionTypeWaitingForDependency)).To(BeTrue())
+				ready := meta.FindStatusCondition(updated.Status.Conditions, secretsv1alpha1.ConditionTypeReady)
+				Expect(ready).NotTo(BeNil())
+				Expect(ready.Reason).To(Equal(ReasonWaiting))
+
+				// The dependency becomes ready
+				_, err = mockReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(dependency)})
+				Expect(err).NotTo(HaveOccurred())
+
+				result, err = mockReconciler.Reconcile(ctx, req)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.RequeueAfter).To(Equal(5 * time.Minute))
+				Expect(mockReconciler.Get(ctx, req.NamespacedName, secret)).To(Succeed())
+
+				Expect(mockReconciler.Get(ctx, req.NamespacedName, updated)).To(Succeed())
+				Expect(meta.IsStatusConditionFalse(updated.Status.Conditions,
+					secretsv1alpha1.ConditionTypeWaitingForDependency)).To(BeTrue())
+				Expect(meta.IsStatusConditionTrue(updated.Status.Conditions,
+					secretsv1alpha1.ConditionTypeReady)).To(BeTrue())
+			})
+
+			It("should wait until a Secret dependency exists", func() {
+				dependent := newSopsSecret("dep-on-secret", "Secret/tls-cert")
+				Expect(mockReconciler.Create(ctx, dependent)).To(Succeed())
+				req := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(dependent)}
+
+				result, err := mockReconciler.Reconcile(ctx, req)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.RequeueAfter).To(Equal(dependencyRequeueInterval))
+
+				Expect(mockReconciler.Create(ctx, &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: "tls-cert", Namespace: "default"},
+				})).To(Succeed())
+
+				result, err = mockReconciler.Reconcile(ctx, req)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.RequeueAfter).To(Equal(5 * time.Minute))
+				Expect(mockReconciler.Get(ctx, req.NamespacedName, &corev1.Secret{})).To(Succeed())
+			})
+
+			It("should reject an invalid depends-on annotation", func() {
+				dependent := newSopsSecret("dep-invalid", "ConfigMap/foo")
+				Expect(mockReconciler.Create(ctx, dependent)).To(Succeed())
+
+				_, err := mockReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(dependent)})
+				Expect(err).NotTo(HaveOccurred())
+
+				updated := &secretsv1alpha1.SopsSecret{}
+				Expect(mockReconciler.Get(ctx, client.ObjectKeyFromObject(dependent), updated)).To(Succeed())
+				ready := meta.FindStatusCondition(updated.Status.Conditions, secretsv1alpha1.ConditionTypeReady)
+				Expect(ready).NotTo(BeNil())
+				Expect(ready.Reason).To(Equal("ValidationFailed"))
+			})
+
+			It("should map a dependency to the SopsSecrets waiting on it", func() {
+				Expect(mockReconciler.Create(ctx, newSopsSecret("dep-a", "shared"))).To(Succeed())
+				Expect(mockReconciler.Create(ctx, newSopsSecret("dep-b", "Secret/shared"))).To(Succeed())
+				Expect(mockReconciler.Create(ctx, newSopsSecret("dep-c", "SopsSecret/other"))).To(Succeed())
+
+				shared := &secretsv1alpha1.SopsSecret{
+					ObjectMeta: metav1.ObjectMeta{Name: "shared", Namespace: "default"},
+				}
+				requests := mockReconciler.dependentsOf(kindSopsSecret)(ctx, shared)
+				Expect(requests).To(ConsistOf(reconcile.Request{
+					NamespacedName: types.NamespacedName{Name: "dep-a", Namespace: "default"},
+				}))
+
+				sharedSecret := &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: "shared", Namespace: "default"},
+				}
+				requests = mockReconciler.dependentsOf(kindSecret)(ctx, sharedSecret)
+				Expect(requests).To(ConsistOf(reconcile.Request{
+					NamespacedName: types.NamespacedName{Name: "dep-b", Namespace: "default"},
+				}))
+			})
+		})
+
+		Describe("Encrypted file source", func() {
+			const document = `token: ENC[test]
+sops:
+    mac: test
+`
+			var (
+				allowedDir string
+				seen       []byte
+			)
+
+			BeforeEach(func() {
+				allowedDir = GinkgoT().TempDir()
+				seen = nil
+				mockDecryptor.DecryptFunc = func(data []byte) (*sops.DecryptedData, error) {
+					seen = data
+					return &sops.DecryptedData{
+						Data: map[string][]byte{"token": []byte("abc")},
+					}, nil
+				}
+				mockReconciler.EncryptedFileDirs = []string{allowedDir}
+			})
+
+			newSopsSecret := func(name, path string) *secretsv1alpha1.SopsSecret {
+				return &secretsv1alpha1.SopsSecret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:       name,
+						Namespace:  "default",
+						Finalizers: []string{finalizerName},
+					},
+					Spec: secretsv1alpha1.SopsSecretSpec{
+						EncryptedFromFile: path,
+					},
+				}
+			}
+
+			expectSourceFailed := func(sopsSecret *secretsv1alpha1.SopsSecret, message string) {
+				_, err := mockReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(sopsSecret)})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(seen).To(BeNil())
+
+				updated := &secretsv1alpha1.SopsSecret{}
+				Expect(mockReconciler.Get(ctx, client.ObjectKeyFromObject(sopsSecret), updated)).To(Succeed())
+				ready := meta.FindStatusCondition(updated.Status.Conditions, secretsv1alpha1.ConditionTypeReady)
+				Expect(ready).NotTo(BeNil())
+				Expect(ready.Reason).To(Equal(ReasonSourceFailed))
+				Expect(ready.Message).To(ContainSubstring(message))
+			}
+
+			It("should decrypt the document read from an allowed file", func() {
+				path := filepath.Join(allowedDir, "secret.enc.yaml")
+				Expect(os.WriteFile(path, []byte(document), 0600)).To(Succeed())
+
+				sopsSecret := newSopsSecret("from-file", path)
+				Expect(mockReconciler.Create(ctx, sopsSecret)).To(Succeed())
+
+				_, err := mockReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(sopsSecret)})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(seen)).To(Equal(document))
+
+				secret := &corev1.Secret{}
+				Expect(mockReconciler.Get(ctx, client.ObjectKeyFromObject(sopsSecret), secret)).To(Succeed())
+				Expect(secret.Data["token"]).To(Equal([]byte("abc")))
+
+				updated := &secretsv1alpha1.SopsSecret{}
+				Expect(mockReconciler.Get(ctx, client.ObjectKeyFromObject(sopsSecret), updated)).To(Succeed())
+				Expect(updated.Status.LastDecryptedHash).To(Equal(calculateHash(document)))
+			})
+
+			It("should reject a file outside the allowed directories", func() {
+				path := filepath.Join(GinkgoT().TempDir(), "secret.enc.yaml")
+				Expect(os.WriteFile(path, []byte(document), 0600)).To(Succeed())
+
+				sopsSecret := newSopsSecret("outside-allowlist", path)
+				Expect(mockReconciler.Create(ctx, sopsSecret)).To(Succeed())
+				expectSourceFailed(sopsSecret, "outside the allowed directories")
+			})
+
+			It("should reject a symlink escaping the allowed directories", func() {
+				target := filepath.Join(GinkgoT().TempDir(), "secret.enc.yaml")
+				Expect(os.WriteFile(target, []byte(document), 0600)).To(Succeed())
+				link := filepath.Join(allowedDir, "link.yaml")
+				Expect(os.Symlink(target, link)).To(Succeed())
+
+				sopsSecret := newSopsSecret("symlink-escape", link)
+				Expect(mockReconciler.Create(ctx, sopsSecret)).To(Succeed())
+				expectSourceFailed(sopsSecret, "outside the allowed directories")
+			})
+
+			It("should reject file sources when no directories are allowed", func() {
+				mockReconciler.EncryptedFileDirs = nil
+				path := filepath.Join(allowedDir, "secret.enc.yaml")
+				Expect(os.WriteFile(path, []byte(document), 0600)).To(Succeed())
+
+				sopsSecret := newSopsSecret("file-disabled", path)
+				Expect(mockReconciler.Create(ctx, sopsSecret)).To(Succeed())
+				expectSourceFailed(sopsSecret, "--encrypted-file-dirs")
+			})
+		})
+
+		Describe("Metadata merge", func() {
+			BeforeEach(func() {
+				mockDecryptor.DecryptFunc = func(data []byte) (*sops.DecryptedData, error) {
+					return &sops.DecryptedData{
+						Data: map[string][]byte{"token": []byte("abc")},
+					}, nil
+				}
+			})
+
+			It("should keep foreign metadata and drop keys removed from the spec", func() {
+				sopsSecret := &secretsv1alpha1.SopsSecret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:       "merge-metadata",
+						Namespace:  "default",
+						Finalizers: []string{finalizerName},
+					},
+					Spec: secretsv1alpha1.SopsSecretSpec{
+						SopsSecret: `token: ENC[v1]
+sops:
+    mac: test
+`,
+						SecretLabels:      map[string]string{"team": "a"},
+						SecretAnnotations: map[string]string{"note": "first", "owner": "ops"},
+					},
+				}
+				Expect(mockReconciler.Create(ctx, sopsSecret)).To(Succeed())
+				req := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(sopsSecret)}
+
+				_, err := mockReconciler.Reconcile(ctx, req)
+				Expect(err).NotTo(HaveOccurred())
+
+				// Another controller annotates and labels the managed Secret
+				secret := &corev1.Secret{}
+				Expect(mockReconciler.Get(ctx, req.NamespacedName, secret)).To(Succeed())
+				secret.Labels["reflector"] = "enabled"
+				secret.Annotations["cert-manager.io/issuer"] = "letsencrypt"
+				Expect(mockReconciler.Update(ctx, secret)).To(Succeed())
+
+				// The user drops a label and an annotation from the spec
+				Expect(mockReconciler.Get(ctx, req.NamespacedName, sopsSecret)).To(Succeed())
+				sopsSecret.Spec.SopsSecret = `token: ENC[v2]
+sops:
+    mac: test
+`
+				sopsSecret.Spec.SecretLabels = nil
+				sopsSecret.Spec.SecretAnnotations = map[string]string{"owner": "platform"}
+				Expect(mockReconciler.Update(ctx, sopsSecret)).To(Succeed())
+
+				_, err = mockReconciler.Reconcile(ctx, req)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(mockReconciler.Get(ctx, req.NamespacedName, secret)).To(Succeed())
+				Expect(secret.Labels).To(HaveKeyWithValue("reflector", "enabled"))
+				Expect(secret.Labels).To(HaveKeyWithValue(managedByLabel, "sops-operator"))
+				Expect(secret.Labels).NotTo(HaveKey("team"))
+				Expect(secret.Annotations).To(HaveKeyWithValue("cert-manager.io/issuer", "letsencrypt"))
+				Expect(secret.Annotations).To(HaveKeyWithValue("owner", "platform"))
+				Expect(secret.Annotations).NotTo(HaveKey("note"))
+			})
+		})
+
+		Describe("Document format", func() {
+			BeforeEach(func() {
+				// A decrypted Secret manifest as returned by the flat parser
+				mockDecryptor.DecryptFunc = func(data []byte) (*sops.DecryptedData, error) {
+					wrapped := map[string]string{
+						"apiVersion": "apiVersion: v1",
+						"kind":       "kind: Secret",
+						"data":       "data:\n    username: YWRtaW4=",
+						"stringData": "stringData:\n    password: s3cret",
+					}
+					decrypted := &sops.DecryptedData{Data: map[string][]byte{}, StringData: wrapped}
+					for k, v := range wrapped {
+						decrypted.Data[k] = []byte(v)
+					}
+					return decrypted, nil
+				}
+			})
+
+			newSopsSecret := func(name string, format secretsv1alpha1.SopsSecretFormat) *secretsv1alpha1.SopsSecret {
+				return &secretsv1alpha1.SopsSecret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:       name,
+						Namespace:  "default",
+						Finalizers: []string{finalizerName},
+					},
+					Spec: secretsv1alpha1.SopsSecretSpec{
+						SopsSecret: `apiVersion: v1
+kind: Secret
+data:
+    username: ENC[test]
+stringData:
+    password: ENC[test]
+sops:
+    mac: test
+`,
+						Format: format,
+					},
+				}
+			}
+
+			It("should use every top-level key with the flat format", func() {
+				sopsSecret := newSopsSecret("format-flat", secretsv1alpha1.FormatFlat)
+				Expect(mockReconciler.Create(ctx, sopsSecret)).To(Succeed())
+
+				_, err := mockReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(sopsSecret)})
+				Expect(err).NotTo(HaveOccurred())
+
+				secret := &corev1.Secret{}
+				Expect(mockReconciler.Get(ctx, client.ObjectKeyFromObject(sopsSecret), secret)).To(Succeed())
+				Expect(secret.Data).To(HaveLen(4))
+				Expect(secret.Data).To(HaveKey("kind"))
+			})
+
+			It("should read data and stringData with the crd format", func() {
+				sopsSecret := newSopsSecret("format-crd", secretsv1alpha1.FormatCRD)
+				sopsSecret.Spec.SecretType = corev1.SecretTypeBasicAuth
+				Expect(mockReconciler.Create(ctx, sopsSecret)).To(Succeed())
+
+				_, err := mockReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(sopsSecret)})
+				Expect(err).NotTo(HaveOccurred())
+
+				secret := &corev1.Secret{}
+				Expect(mockReconciler.Get(ctx, client.ObjectKeyFromObject(sopsSecret), secret)).To(Succeed())
+				Expect(secret.Data).To(Equal(map[string][]byte{
+					"username": []byte("admin"),
+					"password": []byte("s3cret"),
+				}))
+			})
+
+			It("should fail when a crd document has no data", func() {
+				mockDecryptor.DecryptFunc = func(data []byte) (*sops.DecryptedData, error) {
+					return &sops.DecryptedData{Data: map[string][]byte{"token": []byte("token: abc")}}, nil
+				}
+				sopsSecret := newSopsSecret("format-crd-empty", secretsv1alpha1.FormatCRD)
+				Expect(mockReconciler.Create(ctx, sopsSecret)).To(Succeed())
+
+				_, err := mockReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(sopsSecret)})
+				Expect(err).NotTo(HaveOccurred())
+
+				updated := &secretsv1alpha1.SopsSecret{}
+				Expect(mockReconciler.Get(ctx, client.ObjectKeyFromObject(sopsSecret), updated)).To(Succeed())
+				decrypted := meta.FindStatusCondition(updated.Status.Conditions, secretsv1alpha1.ConditionTypeDecrypted)
+				Expect(decrypted).NotTo(BeNil())
+				Expect(decrypted.Status).To(Equal(metav1.ConditionFalse))
+				Expect(decrypted.Message).To(ContainSubstring("no data or stringData"))
+			})
+		})
+
+		Describe("Pre-validation", func() {
+			// A post-processed KMS-only document without a MAC, which the
+			// pre-check rejects but sops may still decrypt.
+			const document = `token: ENC[test]
+sops:
+    kms:
+        - arn: arn:aws:kms:eu-west-1:111122223333:key/test
+`
+			var decryptCalled bool
+
+			BeforeEach(func() {
+				decryptCalled = false
+				mockDecryptor.DecryptFunc = func(data []byte) (*sops.DecryptedData, error) {
+					decryptCalled = true
+					return &sops.DecryptedData{Data: map[string][]byte{"token": []byte("abc")}}, nil
+				}
+			})
+
+			newSopsSecret := func(name string) *secretsv1alpha1.SopsSecret {
+				return &secretsv1alpha1.SopsSecret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:       name,
+						Namespace:  "default",
+						Finalizers: []string{finalizerName},
+					},
+					Spec: secretsv1alpha1.SopsSecretSpec{SopsSecret: document},
+				}
+			}
+
+			It("should reject a document without a MAC by default", func() {
+				sopsSecret := newSopsSecret("prevalidate-default")
+				Expect(mockReconciler.Create(ctx, sopsSecret)).To(Succeed())
+
+				_, err := mockReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(sopsSecret)})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(decryptCalled).To(BeFalse())
+
+				updated := &secretsv1alpha1.SopsSecret{}
+				Expect(mockReconciler.Get(ctx, client.ObjectKeyFromObject(sopsSecret), updated)).To(Succeed())
+				ready := meta.FindStatusCondition(updated.Status.Conditions, secretsv1alpha1.ConditionTypeReady)
+				Expect(ready).NotTo(BeNil())
+				Expect(ready.Reason).To(Equal("ValidationFailed"))
+			})
+
+			It("should leave the document to sops when pre-validation is skipped", func() {
+				mockReconciler.SkipPreValidation = true
+				sopsSecret := newSopsSecret("prevalidate-skipped")
+				Expect(mockReconciler.Create(ctx, sopsSecret)).To(Succeed())
+
+				_, err := mockReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(sopsSecret)})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(decryptCalled).To(BeTrue())
+				Expect(mockReconciler.Get(ctx, client.ObjectKeyFromObject(sopsSecret), &corev1.Secret{})).To(Succeed())
+			})
+
+			It("should report the sops error for an invalid document when pre-validation is skipped", func() {
+				mockReconciler.SkipPreValidation = true
+				mockDecryptor.DecryptFunc = func(data []byte) (*sops.DecryptedData, error) {
+					return nil, fmt.Errorf("sops metadata not found")
+				}
+				sopsSecret := newSopsSecret("prevalidate-sops-error")
+				sopsSecret.Spec.SopsSecret = "token: plain\n"
+				Expect(mockReconciler.Create(ctx, sopsSecret)).To(Succeed())
+
+				_, err := mockReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(sopsSecret)})
+				Expect(err).NotTo(HaveOccurred())
+
+				updated := &secretsv1alpha1.SopsSecret{}
+				Expect(mockReconciler.Get(ctx, client.ObjectKeyFromObject(sopsSecret), updated)).To(Succeed())
+				decrypted := meta.FindStatusCondition(updated.Status.Conditions, secretsv1alpha1.ConditionTypeDecrypted)
+				Expect(decrypted).NotTo(BeNil())
+				Expect(decrypted.Reason).To(Equal("DecryptFailed"))
+				Expect(decrypted.Message).To(ContainSubstring("sops metadata not found"))
+			})
+		})
+
+		Describe("Source deletion policy", func() {
+			var path string
+
+			BeforeEach(func() {
+				dir := GinkgoT().TempDir()
+				path = filepath.Join(dir, "secret.enc.yaml")
+				Expect(os.WriteFile(path, []byte("token: ENC[test]\nsops:\n    mac: test\n"), 0600)).To(Succeed())
+				mockReconciler.EncryptedFileDirs = []string{dir}
+				mockDecryptor.DecryptFunc = func(data []byte) (*sops.DecryptedData, error) {
+					return &sops.DecryptedData{Data: map[string][]byte{"token": []byte("abc")}}, nil
+				}
+			})
+
+			// reconcileThenRemoveSource writes the Secret, deletes the source file
+			// and reconciles again.
+			reconcileThenRemoveSource := func(name string, policy secretsv1alpha1.SourceDeletionPolicy) *secretsv1alpha1.SopsSecret {
+				sopsSecret := &secretsv1alpha1.SopsSecret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:       name,
+						Namespace:  "default",
+						Finalizers: []string{finalizerName},
+					},
+					Spec: secretsv1alpha1.SopsSecretSpec{
+						EncryptedFromFile:    path,
+						SourceDeletionPolicy: policy,
+					},
+				}
+				Expect(mockReconciler.Create(ctx, sopsSecret)).To(Succeed())
+				req := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(sopsSecret)}
+
+				_, err := mockReconciler.Reconcile(ctx, req)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(mockReconciler.Get(ctx, req.NamespacedName, &corev1.Secret{})).To(Succeed())
+
+				Expect(os.Remove(path)).To(Succeed())
+				_, err = mockReconciler.Reconcile(ctx, req)
+				Expect(err).NotTo(HaveOccurred())
+
+				updated := &secretsv1alpha1.SopsSecret{}
+				Expect(mockReconciler.Get(ctx, req.NamespacedName, updated)).To(Succeed())
+				Expect(meta.IsStatusConditionTrue(updated.Status.Conditions,
+					secretsv1alpha1.ConditionTypeSourceMissing)).To(BeTrue())
+				return updated
+			}
+
+			It("should keep the Secret with the Retain policy", func() {
+				sopsSecret := reconcileThenRemoveSource("source-retain", secretsv1alpha1.SourceDeletionRetain)
+				Expect(mockReconciler.Get(ctx, client.ObjectKeyFromObject(sopsSecret), &corev1.Secret{})).To(Succeed())
+			})
+
+			It("should delete the Secret with the Delete policy", func() {
+				sopsSecret := reconcileThenRemoveSource("source-delete", secretsv1alpha1.SourceDeletionDelete)
+				err := mockReconciler.Get(ctx, client.ObjectKeyFromObject(sopsSecret), &corev1.Secret{})
+				Expect(errors.IsNotFound(err)).To(BeTrue())
+			})
+
+			It("should clear SourceMissing once the source is back", func() {
+				sopsSecret := reconcileThenRemoveSource("source-restored", secretsv1alpha1.SourceDeletionRetain)
+				Expect(os.WriteFile(path, []byte("token: ENC[test]\nsops:\n    mac: test\n"), 0600)).To(Succeed())
+
+				_, err := mockReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(sopsSecret)})
+				Expect(err).NotTo(HaveOccurred())
+
+				updated := &secretsv1alpha1.SopsSecret{}
+				Expect(mockReconciler.Get(ctx, client.ObjectKeyFromObject(sopsSecret), updated)).To(Succeed())
+				Expect(meta.FindStatusCondition(updated.Status.Conditions,
+					secretsv1alpha1.ConditionTypeSourceMissing)).To(BeNil())
+				Expect(meta.IsStatusConditionTrue(updated.Status.Conditions,
+					secretsv1alpha1.ConditionTypeReady)).To(BeTrue())
+			})
+		})
+
+		Describe("Complex value format", func() {
+			It("should write nested values as JSON when requested", func() {
+				mockDecryptor.DecryptFunc = func(data []byte) (*sops.DecryptedData, error) {
+					value := "config:\n    server:\n        port: 8080\n        host: localhost"
+					return &sops.DecryptedData{
+						Data:       map[string][]byte{"config": []byte(value)},
+						StringData: map[string]string{"config": value},
+					}, nil
+				}
+				sopsSecret := &secretsv1alpha1.SopsSecret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:       "complex-json",
+						Namespace:  "default",
+						Finalizers: []string{finalizerName},
+					},
+					Spec: secretsv1alpha1.SopsSecretSpec{
+						SopsSecret:         "config: ENC[test]\nsops:\n    mac: test\n",
+						ComplexValueFormat: secretsv1alpha1.ComplexValueJSON,
+					},
+				}
+				Expect(mockReconciler.Create(ctx, sopsSecret)).To(Succeed())
+
+				_, err := mockReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(sopsSecret)})
+				Expect(err).NotTo(HaveOccurred())
+
+				secret := &corev1.Secret{}
+				Expect(mockReconciler.Get(ctx, client.ObjectKeyFromObject(sopsSecret), secret)).To(Succeed())
+				Expect(string(secret.Data["config"])).To(Equal(`{"server":{"host":"localhost","port":8080}}`))
+			})
+		})
+
+		Describe("Generated Secret names", func() {
+			var token string
+
+			BeforeEach(func() {
+				token = "v1"
+				mockDecryptor.DecryptFunc = func(data []byte) (*sops.DecryptedData, error) {
+					return &sops.DecryptedData{Data: map[string][]byte{"token": []byte(token)}}, nil
+				}
+			})
+
+			It("should create a new Secret per payload and delete the previous one", func() {
+				sopsSecret := &secretsv1alpha1.SopsSecret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:       "generated",
+						Namespace:  "default",
+						Finalizers: []string{finalizerName},
+					},
+					Spec: secretsv1alpha1.SopsSecretSpec{
+						SopsSecret:      "token: ENC[v1]\nsops:\n    mac: test\n",
+						UseGenerateName: true,
+					},
+				}
+				Expect(mockReconciler.Create(ctx, sopsSecret)).To(Succeed())
+				req := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(sopsSecret)}
+
+				_, err := mockReconciler.Reconcile(ctx, req)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(mockReconciler.Get(ctx, req.NamespacedName, sopsSecret)).To(Succeed())
+				firstName := sopsSecret.Status.SecretName
+				Expect(firstName).To(HavePrefix("generated-"))
+				first := &corev1.Secret{}
+				Expect(mockReconciler.Get(ctx, types.NamespacedName{Name: firstName, Namespace: "default"}, first)).To(Succeed())
+				Expect(first.Data["token"]).To(Equal([]byte("v1")))
+
+				// Reconciling the same payload keeps the Secret
+				_, err = mockReconciler.Reconcile(ctx, req)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(mockReconciler.Get(ctx, req.NamespacedName, sopsSecret)).To(Succeed())
+				Expect(sopsSecret.Status.SecretName).To(Equal(firstName))
+
+				// A new payload creates a new generation
+				token = "v2"
+				sopsSecret.Spec.SopsSecret = "token: ENC[v2]\nsops:\n    mac: test\n"
+				Expect(mockReconciler.Update(ctx, sopsSecret)).To(Succeed())
+				_, err = mockReconciler.Reconcile(ctx, req)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(mockReconciler.Get(ctx, req.NamespacedName, sopsSecret)).To(Succeed())
+				secondName := sopsSecret.Status.SecretName
+				Expect(secondName).To(HavePrefix("generated-"))
+				Expect(secondName).NotTo(Equal(firstName))
+
+				second := &corev1.Secret{}
+				Expect(mockReconciler.Get(ctx, types.NamespacedName{Name: secondName, Namespace: "default"}, second)).To(Succeed())
+				Expect(second.Data["token"]).To(Equal([]byte("v2")))
+
+				err = mockReconciler.Get(ctx, types.NamespacedName{Name: firstName, Namespace: "default"}, &corev1.Secret{})
+				Expect(errors.IsNotFound(err)).To(BeTrue())
+			})
+
+			It("should release the finalizer when no Secret was generated yet", func() {
+				sopsSecret := &secretsv1alpha1.SopsSecret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:       "generated-none",
+						Namespace:  "default",
+						Finalizers: []string{finalizerName},
+					},
+					Spec: secretsv1alpha1.SopsSecretSpec{UseGenerateName: true},
+				}
+				Expect(mockReconciler.Create(ctx, sopsSecret)).To(Succeed())
+
+				_, err := mockReconciler.reconcileDelete(ctx, sopsSecret)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(sopsSecret.Finalizers).To(BeEmpty())
+			})
+		})
+
+		Describe("Null values", func() {
+			BeforeEach(func() {
+				mockDecryptor.DecryptFunc = func(data []byte) (*sops.DecryptedData, error) {
+					return &sops.DecryptedData{Data: map[string][]byte{
+						"removed": []byte("removed: null"),
+						"empty":   []byte(`empty: ""`),
+					}}, nil
+				}
+			})
+
+			reconcileWith := func(name string, omitNull bool) *corev1.Secret {
+				sopsSecret := &secretsv1alpha1.SopsSecret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:       name,
+						Namespace:  "default",
+						Finalizers: []string{finalizerName},
+					},
+					Spec: secretsv1alpha1.SopsSecretSpec{
+						SopsSecret:     "removed: ENC[test]\nempty: ENC[test]\nsops:\n    mac: test\n",
+						OmitNullValues: omitNull,
+					},
+				}
+				Expect(mockReconciler.Create(ctx, sopsSecret)).To(Succeed())
+				_, err := mockReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(sopsSecret)})
+				Expect(err).NotTo(HaveOccurred())
+
+				secret := &corev1.Secret{}
+				Expect(mockReconciler.Get(ctx, client.ObjectKeyFromObject(sopsSecret), secret)).To(Succeed())
+				return secret
+			}
+
+			It("should omit null values and keep empty strings when enabled", func() {
+				secret := reconcileWith("null-omitted", true)
+				Expect(secret.Data).NotTo(HaveKey("removed"))
+				Expect(secret.Data).To(HaveKey("empty"))
+			})
+
+			It("should keep null values by default", func() {
+				secret := reconcileWith("null-kept", false)
+				Expect(secret.Data).To(HaveKey("removed"))
+				Expect(secret.Data).To(HaveKey("empty"))
+			})
+		})
+		Describe("Value transformer", func() {
+			BeforeEach(func() {
+				mockDecryptor.DecryptFunc = func(data []byte) (*sops.DecryptedData, error) {
+					return &sops.DecryptedData{Data: map[string][]byte{"password": []byte("password: secret")}}, nil
+				}
+			})
+
+			newSopsSecret := func(name string) *secretsv1alpha1.SopsSecret {
+				sopsSecret := &secretsv1alpha1.SopsSecret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:       name,
+						Namespace:  "default",
+						Finalizers: []string{finalizerName},
+					},
+					Spec: secretsv1alpha1.SopsSecretSpec{
+						SopsSecret: "password: ENC[test]\nsops:\n    mac: test\n",
+					},
+				}
+				Expect(mockReconciler.Create(ctx, sopsSecret)).To(Succeed())
+				return sopsSecret
+			}
+
+			It("should store transformed values", func() {
+				mockReconciler.ValueTransformer = Base64Transformer{}
+				sopsSecret := newSopsSecret("transformed")
+
+				_, err := mockReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(sopsSecret)})
+				Expect(err).NotTo(HaveOccurred())
+
+				secret := &corev1.Secret{}
+				Expect(mockReconciler.Get(ctx, client.ObjectKeyFromObject(sopsSecret), secret)).To(Succeed())
+				Expect(string(secret.Data["password"])).To(Equal(base64.StdEncoding.EncodeToString([]byte("password: secret"))))
+			})
+
+			It("should not write the Secret when the transformer fails", func() {
+				mockReconciler.ValueTransformer = transformerFunc(func(_ context.Context, key string, _ []byte) ([]byte, error) {
+					return nil, fmt.Errorf("key service unavailable")
+				})
+				sopsSecret := newSopsSecret("transform-failed")
+
+				_, err := mockReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(sopsSecret)})
+				Expect(err).NotTo(HaveOccurred())
+
+				secret := &corev1.Secret{}
+				err = mockReconciler.Get(ctx, client.ObjectKeyFromObject(sopsSecret), secret)
+				Expect(errors.IsNotFound(err)).To(BeTrue())
+
+				updated := &secretsv1alpha1.SopsSecret{}
+				Expect(mockReconciler.Get(ctx, client.ObjectKeyFromObject(sopsSecret), updated)).To(Succeed())
+				ready := meta.FindStatusCondition(updated.Status.Conditions, secretsv1alpha1.ConditionTypeReady)
+				Expect(ready).NotTo(BeNil())
+				Expect(ready.Status).To(Equal(metav1.ConditionFalse))
+				Expect(ready.Reason).To(Equal(ReasonTransformFailed))
+				Expect(ready.Message).To(ContainSubstring("key service unavailable"))
+			})
+		})
+		Describe("Condition metrics", func() {
+			It("should track condition transitions and drop the series on deletion", func() {
+				decryptErr := fmt.Errorf("no matching key")
+				mockDecryptor.DecryptFunc = func(data []byte) (*sops.DecryptedData, error) {
+					if decryptErr != nil {
+						return nil, decryptErr
+					}
+					return &sops.DecryptedData{Data: map[string][]byte{"key": []byte("key: value")}}, nil
+				}
+				sopsSecret := &secretsv1alpha1.SopsSecret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:       "condition-metrics",
+						Namespace:  "default",
+						Finalizers: []string{finalizerName},
+					},
+					Spec: secretsv1alpha1.SopsSecretSpec{
+						SopsSecret: "key: ENC[test]\nsops:\n    mac: test\n",
+					},
+				}
+				Expect(mockReconciler.Create(ctx, sopsSecret)).To(Succeed())
+				key := client.ObjectKeyFromObject(sopsSecret)
+
+				_, err := mockReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(conditionValue(key, secretsv1alpha1.ConditionTypeReady, metav1.ConditionFalse)).To(Equal(1.0))
+				Expect(conditionValue(key, secretsv1alpha1.ConditionTypeReady, metav1.ConditionTrue)).To(Equal(0.0))
+
+				decryptErr = nil
+				_, err = mockReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(conditionValue(key, secretsv1alpha1.ConditionTypeReady, metav1.ConditionTrue)).To(Equal(1.0))
+				Expect(conditionValue(key, secretsv1alpha1.ConditionTypeReady, metav1.ConditionFalse)).To(Equal(0.0))
+				Expect(conditionValue(key, secretsv1alpha1.ConditionTypeDecrypted, metav1.ConditionTrue)).To(Equal(1.0))
+
+				Expect(mockReconciler.Get(ctx, key, sopsSecret)).To(Succeed())
+				Expect(mockReconciler.Delete(ctx, sopsSecret)).To(Succeed())
+				_, err = mockReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(conditionGauge.DeletePartialMatch(prometheus.Labels{"namespace": key.Namespace, "name": key.Name})).To(BeZero())
+			})
+		})
+		Describe("Key count limit", func() {
+			BeforeEach(func() {
+				mockDecryptor.DecryptFunc = func(data []byte) (*sops.DecryptedData, error) {
+					return &sops.DecryptedData{
+						Data: map[string][]byte{
+							"a": []byte("a: 1"),
+							"b": []byte("b: 2"),
+							"c": []byte("c: 3"),
+						},
+					}, nil
+				}
+			})
+
+			newSopsSecret := func(name string) *secretsv1alpha1.SopsSecret {
+				sopsSecret := &secretsv1alpha1.SopsSecret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:       name,
+						Namespace:  "default",
+						Finalizers: []string{finalizerName},
+					},
+					Spec: secretsv1alpha1.SopsSecretSpec{
+						SopsSecret: "a: ENC[test]\nb: ENC[test]\nc: ENC[test]\nsops:\n    mac: test\n",
+					},
+				}
+				Expect(mockReconciler.Create(ctx, sopsSecret)).To(Succeed())
+				return sopsSecret
+			}
+
+			It("should write the Secret when the key count is within the limit", func() {
+				mockReconciler.MaxKeysPerSecret = 3
+				sopsSecret := newSopsSecret("keys-under-limit")
+
+				_, err := mockReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(sopsSecret)})
+				Expect(err).NotTo(HaveOccurred())
+
+				secret := &corev1.Secret{}
+				Expect(mockReconciler.Get(ctx, client.ObjectKeyFromObject(sopsSecret), secret)).To(Succeed())
+				Expect(secret.Data).To(HaveLen(3))
+			})
+
+			It("should not write the Secret when the key count exceeds the limit", func() {
+				mockReconciler.MaxKeysPerSecret = 2
+				sopsSecret := newSopsSecret("keys-over-limit")
+
+				_, err := mockReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(sopsSecret)})
+				Expect(err).NotTo(HaveOccurred())
+
+				secret := &corev1.Secret{}
+				err = mockReconciler.Get(ctx, client.ObjectKeyFromObject(sopsSecret), secret)
+				Expect(errors.IsNotFound(err)).To(BeTrue())
+
+				updated := &secretsv1alpha1.SopsSecret{}
+				Expect(mockReconciler.Get(ctx, client.ObjectKeyFromObject(sopsSecret), updated)).To(Succeed())
+				ready := meta.FindStatusCondition(updated.Status.Conditions, secretsv1alpha1.ConditionTypeReady)
+				Expect(ready).NotTo(BeNil())
+				Expect(ready.Status).To(Equal(metav1.ConditionFalse))
+				Expect(ready.Reason).To(Equal(ReasonTooManyKeys))
+				Expect(ready.Message).To(ContainSubstring("3 keys"))
+			})
+		})
+		Describe("Degraded", func() {
+			It("should keep the last good Secret and report Degraded when decryption starts failing", func() {
+				decryptErr := error(nil)
+				mockDecryptor.DecryptFunc = func(data []byte) (*sops.DecryptedData, error) {
+					if decryptErr != nil {
+						return nil, decryptErr
+					}
+					return &sops.DecryptedData{Data: map[string][]byte{"token": []byte("token: v1")}}, nil
+				}
+				sopsSecret := &secretsv1alpha1.SopsSecret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:       "degraded",
+						Namespace:  "default",
+						Finalizers: []string{finalizerName},
+					},
+					Spec: secretsv1alpha1.SopsSecretSpec{
+						SopsSecret: "token: ENC[v1]\nsops:\n    mac: test\n",
+					},
+				}
+				Expect(mockReconciler.Create(ctx, sopsSecret)).To(Succeed())
+				key := client.ObjectKeyFromObject(sopsSecret)
+				_, err := mockReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+				Expect(err).NotTo(HaveOccurred())
+
+				before := &corev1.Secret{}
+				Expect(mockReconciler.Get(ctx, key, before)).To(Succeed())
+
+				decryptErr = fmt.Errorf("kms unavailable")
+				Expect(mockReconciler.Get(ctx, key, sopsSecret)).To(Succeed())
+				sopsSecret.Spec.SopsSecret = "token: ENC[v2]\nsops:\n    mac: test\n"
+				Expect(mockReconciler.Update(ctx, sopsSecret)).To(Succeed())
+				_, err = mockReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+				Expect(err).NotTo(HaveOccurred())
+
+				after := &corev1.Secret{}
+				Expect(mockReconciler.Get(ctx, key, after)).To(Succeed())
+				Expect(after.ResourceVersion).To(Equal(before.ResourceVersion))
+				Expect(after.Data).To(Equal(before.Data))
+
+				updated := &secretsv1alpha1.SopsSecret{}
+				Expect(mockReconciler.Get(ctx, key, updated)).To(Succeed())
+				degraded := meta.FindStatusCondition(updated.Status.Conditions, secretsv1alpha1.ConditionTypeDegraded)
+				Expect(degraded).NotTo(BeNil())
+				Expect(degraded.Status).To(Equal(metav1.ConditionTrue))
+				Expect(degraded.Message).To(ContainSubstring("kms unavailable"))
+				ready := meta.FindStatusCondition(updated.Status.Conditions, secretsv1alpha1.ConditionTypeReady)
+				Expect(ready.Status).To(Equal(metav1.ConditionTrue))
+				Expect(ready.Reason).To(Equal("Stale"))
+
+				decryptErr = nil
+				_, err = mockReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(mockReconciler.Get(ctx, key, updated)).To(Succeed())
+				Expect(meta.FindStatusCondition(updated.Status.Conditions, secretsv1alpha1.ConditionTypeDegraded)).To(BeNil())
+				Expect(meta.IsStatusConditionTrue(updated.Status.Conditions, secretsv1alpha1.ConditionTypeReady)).To(BeTrue())
+			})
+
+			It("should report Ready=False when there is no earlier Secret", func() {
+				mockDecryptor.DecryptFunc = func(data []byte) (*sops.DecryptedData, error) {
+					return nil, fmt.Errorf("kms unavailable")
+				}
+				sopsSecret := &secretsv1alpha1.SopsSecret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:       "degraded-first",
+						Namespace:  "default",
+						Finalizers: []string{finalizerName},
+					},
+					Spec: secretsv1alpha1.SopsSecretSpec{
+						SopsSecret: "token: ENC[v1]\nsops:\n    mac: test\n",
+					},
+				}
+				Expect(mockReconciler.Create(ctx, sopsSecret)).To(Succeed())
+				_, err := mockReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(sopsSecret)})
+				Expect(err).NotTo(HaveOccurred())
+
+				updated := &secretsv1alpha1.SopsSecret{}
+				Expect(mockReconciler.Get(ctx, client.ObjectKeyFromObject(sopsSecret), updated)).To(Succeed())
+				Expect(meta.FindStatusCondition(updated.Status.Conditions, secretsv1alpha1.ConditionTypeDegraded)).To(BeNil())
+				ready := meta.FindStatusCondition(updated.Status.Conditions, secretsv1alpha1.ConditionTypeReady)
+				Expect(ready.Status).To(Equal(metav1.ConditionFalse))
+				Expect(ready.Reason).To(Equal("DecryptFailed"))
+			})
+		})
+		Describe("AGE key Secret annotation", func() {
+			var globalCalled bool
+
+			BeforeEach(func() {
+				globalCalled = false
+				mockDecryptor.DecryptFunc = func(data []byte) (*sops.DecryptedData, error) {
+					globalCalled = true
+					return nil, fmt.Errorf("no matching key")
+				}
+			})
+
+			newSopsSecret := func(name, ref string) *secretsv1alpha1.SopsSecret {
+				sopsSecret := &secretsv1alpha1.SopsSecret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        name,
+						Namespace:   "default",
+						Finalizers:  []string{finalizerName},
+						Annotations: map[string]string{ageKeySecretAnnotation: ref},
+					},
+					Spec: secretsv1alpha1.SopsSecretSpec{
+						SopsSecret: "token: ENC[test]\nsops:\n    mac: test\n",
+					},
+				}
+				Expect(mockReconciler.Create(ctx, sopsSecret)).To(Succeed())
+				return sopsSecret
+			}
+
+			It("should decrypt with the key from the referenced Secret", func() {
+				Expect(mockReconciler.Create(ctx, &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: "one-off-key", Namespace: "default"},
+					Data:       map[string][]byte{"key.txt": []byte("# created: today\nAGE-SECRET-KEY-ONEOFF\n")},
+				})).To(Succeed())
+
+				var usedKeys []string
+				mockReconciler.NewDecryptor = func(ageKeys []string) sops.DecryptorInterface {
+					usedKeys = ageKeys
+					return &MockDecryptor{DecryptFunc: func(data []byte) (*sops.DecryptedData, error) {
+						return &sops.DecryptedData{Data: map[string][]byte{"token": []byte("token: abc")}}, nil
+					}}
+				}
+				sopsSecret := newSopsSecret("key-annotation", "one-off-key/key.txt")
+
+				_, err := mockReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(sopsSecret)})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(usedKeys).To(Equal([]string{"AGE-SECRET-KEY-ONEOFF"}))
+				Expect(globalCalled).To(BeFalse())
+				Expect(mockReconciler.Get(ctx, client.ObjectKeyFromObject(sopsSecret), &corev1.Secret{})).To(Succeed())
+			})
+
+			It("should report a missing key Secret in the conditions", func() {
+				sopsSecret := newSopsSecret("key-annotation-missing", "does-not-exist")
+
+				_, err := mockReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(sopsSecret)})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(globalCalled).To(BeFalse())
+
+				updated := &secretsv1alpha1.SopsSecret{}
+				Expect(mockReconciler.Get(ctx, client.ObjectKeyFromObject(sopsSecret), updated)).To(Succeed())
+				ready := meta.FindStatusCondition(updated.Status.Conditions, secretsv1alpha1.ConditionTypeReady)
+				Expect(ready).NotTo(BeNil())
+				Expect(ready.Status).To(Equal(metav1.ConditionFalse))
+				Expect(ready.Reason).To(Equal(ReasonKeySecretFailed))
+				decrypted := meta.FindStatusCondition(updated.Status.Conditions, secretsv1alpha1.ConditionTypeDecrypted)
+				Expect(decrypted.Message).To(ContainSubstring("does-not-exist"))
+			})
+		})
+		Describe("Value format warning", func() {
+			BeforeEach(func() {
+				mockDecryptor.DecryptFunc = func(data []byte) (*sops.DecryptedData, error) {
+					return &sops.DecryptedData{Data: map[string][]byte{
+						"token":    []byte("token: |\n    abc"),
+						"password": []byte("password: clean"),
+						"padded":   []byte(`padded: " abc"`),
+					}}, nil
+				}
+			})
+
+			reconcileWith := func(name string, warn bool) *secretsv1alpha1.SopsSecret {
+				sopsSecret := &secretsv1alpha1.SopsSecret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:       name,
+						Namespace:  "default",
+						Finalizers: []string{finalizerName},
+					},
+					Spec: secretsv1alpha1.SopsSecretSpec{
+						SopsSecret:            "token: ENC[test]\nsops:\n    mac: test\n",
+						WarnOnTrailingNewline: warn,
+					},
+				}
+				Expect(mockReconciler.Create(ctx, sopsSecret)).To(Succeed())
+				_, err := mockReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(sopsSecret)})
+				Expect(err).NotTo(HaveOccurred())
+
+				updated := &secretsv1alpha1.SopsSecret{}
+				Expect(mockReconciler.Get(ctx, client.ObjectKeyFromObject(sopsSecret), updated)).To(Succeed())
+				return updated
+			}
+
+			It("should list keys with surrounding whitespace and still write the Secret", func() {
+				updated := reconcileWith("format-warning", true)
+
+				warning := meta.FindStatusCondition(updated.Status.Conditions, secretsv1alpha1.ConditionTypeValueFormatWarning)
+				Expect(warning).NotTo(BeNil())
+				Expect(warning.Status).To(Equal(metav1.ConditionTrue))
+				Expect(warning.Message).To(ContainSubstring("padded, token"))
+				Expect(warning.Message).NotTo(ContainSubstring("password"))
+				Expect(meta.IsStatusConditionTrue(updated.Status.Conditions, secretsv1alpha1.ConditionTypeReady)).To(BeTrue())
+				Expect(mockReconciler.Get(ctx, client.ObjectKeyFromObject(updated), &corev1.Secret{})).To(Succeed())
+			})
+
+			It("should not warn when all values are clean", func() {
+				mockDecryptor.DecryptFunc = func(data []byte) (*sops.DecryptedData, error) {
+					return &sops.DecryptedData{Data: map[string][]byte{"password": []byte("password: clean")}}, nil
+				}
+				updated := reconcileWith("format-clean", true)
+				Expect(meta.FindStatusCondition(updated.Status.Conditions, secretsv1alpha1.ConditionTypeValueFormatWarning)).To(BeNil())
+			})
+
+			It("should not warn when the lint is disabled", func() {
+				updated := reconcileWith("format-disabled", false)
+				Expect(meta.FindStatusCondition(updated.Status.Conditions, secretsv1alpha1.ConditionTypeValueFormatWarning)).To(BeNil())
+			})
+		})
+		Describe("Backups", func() {
+			const recipient = "age1backup0recipient"
+
+			BeforeEach(func() {
+				mockDecryptor.DecryptFunc = func(data []byte) (*sops.DecryptedData, error) {
+					return &sops.DecryptedData{Data: map[string][]byte{
+						"password": []byte("password: secret"),
+						"username": []byte("username: admin"),
+					}}, nil
+				}
+			})
+
+			newSopsSecret := func(name string) *secretsv1alpha1.SopsSecret {
+				sopsSecret := &secretsv1alpha1.SopsSecret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:       name,
+						Namespace:  "default",
+						Finalizers: []string{finalizerName},
+					},
+					Spec: secretsv1alpha1.SopsSecretSpec{
+						SopsSecret:      "password: ENC[test]\nusername: ENC[test]\nsops:\n    mac: test\n",
+						BackupRecipient: recipient,
+					},
+				}
+				Expect(mockReconciler.Create(ctx, sopsSecret)).To(Succeed())
+				return sopsSecret
+			}
+
+			It("should store the document re-encrypted to the backup recipient", func() {
+				encryptor := &recipientEncryptor{}
+				mockReconciler.Encryptor = encryptor
+				sopsSecret := newSopsSecret("with-backup")
+
+				_, err := mockReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(sopsSecret)})
+				Expect(err).NotTo(HaveOccurred())
+
+				backup := &corev1.Secret{}
+				Expect(mockReconciler.Get(ctx, types.NamespacedName{Namespace: "default", Name: "with-backup-backup"}, backup)).To(Succeed())
+				Expect(metav1.IsControlledBy(backup, sopsSecret)).To(BeTrue())
+				ciphertext := backup.Data[backupSecretKey]
+				Expect(string(ciphertext)).NotTo(ContainSubstring("secret"))
+
+				plaintext, err := encryptor.decrypt(ciphertext, recipient)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(plaintext)).To(Equal("password: secret\nusername: admin\n"))
+				_, err = encryptor.decrypt(ciphertext, "age1other")
+				Expect(err).To(HaveOccurred())
+			})
+
+			It("should still write the Secret when the backup fails", func() {
+				mockReconciler.Encryptor = nil
+				sopsSecret := newSopsSecret("backup-failed")
+
+				_, err := mockReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(sopsSecret)})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(mockReconciler.Get(ctx, client.ObjectKeyFromObject(sopsSecret), &corev1.Secret{})).To(Succeed())
+				err = mockReconciler.Get(ctx, types.NamespacedName{Namespace: "default", Name: "backup-failed-backup"}, &corev1.Secret{})
+				Expect(errors.IsNotFound(err)).To(BeTrue())
+			})
+		})
+		Describe("Plaintext passthrough", func() {
+			var decryptCalled bool
+
+			BeforeEach(func() {
+				decryptCalled = false
+				mockDecryptor.DecryptFunc = func(data []byte) (*sops.DecryptedData, error) {
+					decryptCalled = true
+					return nil, fmt.Errorf("sops metadata not found")
+				}
+			})
+
+			reconcileWith := func(name string, allow bool) *secretsv1alpha1.SopsSecret {
+				sopsSecret := &secretsv1alpha1.SopsSecret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:       name,
+						Namespace:  "default",
+						Finalizers: []string{finalizerName},
+					},
+					Spec: secretsv1alpha1.SopsSecretSpec{
+						SopsSecret:     "token: dev-token\n",
+						AllowPlaintext: allow,
+					},
+				}
+				Expect(mockReconciler.Create(ctx, sopsSecret)).To(Succeed())
+				_, err := mockReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(sopsSecret)})
+				Expect(err).NotTo(HaveOccurred())
+
+				updated := &secretsv1alpha1.SopsSecret{}
+				Expect(mockReconciler.Get(ctx, client.ObjectKeyFromObject(sopsSecret), updated)).To(Succeed())
+				return updated
+			}
+
+			It("should use the plaintext document and flag it when allowed", func() {
+				updated := reconcileWith("plaintext-allowed", true)
+				Expect(decryptCalled).To(BeFalse())
+
+				plaintext := meta.FindStatusCondition(updated.Status.Conditions, secretsv1alpha1.ConditionTypePlaintext)
+				Expect(plaintext).NotTo(BeNil())
+				Expect(plaintext.Status).To(Equal(metav1.ConditionTrue))
+				Expect(meta.IsStatusConditionTrue(updated.Status.Conditions, secretsv1alpha1.ConditionTypeReady)).To(BeTrue())
+
+				secret := &corev1.Secret{}
+				Expect(mockReconciler.Get(ctx, client.ObjectKeyFromObject(updated), secret)).To(Succeed())
+				Expect(string(secret.Data["token"])).To(Equal("token: dev-token"))
+			})
+
+			It("should reject a plaintext document by default", func() {
+				updated := reconcileWith("plaintext-rejected", false)
+				Expect(decryptCalled).To(BeFalse())
+				Expect(meta.FindStatusCondition(updated.Status.Conditions, secretsv1alpha1.ConditionTypePlaintext)).To(BeNil())
+
+				ready := meta.FindStatusCondition(updated.Status.Conditions, secretsv1alpha1.ConditionTypeReady)
+				Expect(ready).NotTo(BeNil())
+				Expect(ready.Status).To(Equal(metav1.ConditionFalse))
+				Expect(ready.Reason).To(Equal("ValidationFailed"))
+				err := mockReconciler.Get(ctx, client.ObjectKeyFromObject(updated), &corev1.Secret{})
+				Expect(errors.IsNotFound(err)).To(BeTrue())
+			})
+		})
+		Describe("ConfigMap data", func() {
+			BeforeEach(func() {
+				mockDecryptor.DecryptFunc = func(data []byte) (*sops.DecryptedData, error) {
+					return &sops.DecryptedData{Data: map[string][]byte{"password": []byte("password: secret")}}, nil
+				}
+			})
+
+			reconcileSopsSecret := func(sopsSecret *secretsv1alpha1.SopsSecret) {
+				_, err := mockReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(sopsSecret)})
+				Expect(err).NotTo(HaveOccurred())
+			}
+
+			updateConfigMapData := func(sopsSecret *secretsv1alpha1.SopsSecret, data map[string]string) {
+				Expect(mockReconciler.Get(ctx, client.ObjectKeyFromObject(sopsSecret), sopsSecret)).To(Succeed())
+				sopsSecret.Spec.ConfigMapData = data
+				sopsSecret.Generation++
+				Expect(mockReconciler.Update(ctx, sopsSecret)).To(Succeed())
+				reconcileSopsSecret(sopsSecret)
+			}
+
+			It("should create, update and delete the ConfigMap alongside the Secret", func() {
+				sopsSecret := &secretsv1alpha1.SopsSecret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:       "with-configmap",
+						Namespace:  "default",
+						Finalizers: []string{finalizerName},
+					},
+					Spec: secretsv1alpha1.SopsSecretSpec{
+						SopsSecret:    "password: ENC[test]\nsops:\n    mac: test\n",
+						SecretName:    "app-config",
+						ConfigMapData: map[string]string{"LOG_LEVEL": "info"},
+					},
+				}
+				Expect(mockReconciler.Create(ctx, sopsSecret)).To(Succeed())
+				reconcileSopsSecret(sopsSecret)
+
+				key := types.NamespacedName{Namespace: "default", Name: "app-config"}
+				Expect(mockReconciler.Get(ctx, key, &corev1.Secret{})).To(Succeed())
+				configMap := &corev1.ConfigMap{}
+				Expect(mockReconciler.Get(ctx, key, configMap)).To(Succeed())
+				Expect(configMap.Data).To(Equal(map[string]string{"LOG_LEVEL": "info"}))
+				Expect(configMap.Labels).To(HaveKeyWithValue(sopsSecretLabel, "with-configmap"))
+				Expect(metav1.IsControlledBy(configMap, sopsSecret)).To(BeTrue())
+
+				updateConfigMapData(sopsSecret, map[string]string{"LOG_LEVEL": "debug", "REGION": "eu"})
+				Expect(mockReconciler.Get(ctx, key, configMap)).To(Succeed())
+				Expect(configMap.Data).To(Equal(map[string]string{"LOG_LEVEL": "debug", "REGION": "eu"}))
+
+				updateConfigMapData(sopsSecret, nil)
+				err := mockReconciler.Get(ctx, key, &corev1.ConfigMap{})
+				Expect(errors.IsNotFound(err)).To(BeTrue())
+				Expect(mockReconciler.Get(ctx, key, &corev1.Secret{})).To(Succeed())
+			})
+
+			It("should delete the ConfigMap when the SopsSecret is deleted", func() {
+				sopsSecret := &secretsv1alpha1.SopsSecret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:       "configmap-deleted",
+						Namespace:  "default",
+						Finalizers: []string{finalizerName},
+					},
+					Spec: secretsv1alpha1.SopsSecretSpec{
+						SopsSecret:    "password: ENC[test]\nsops:\n    mac: test\n",
+						ConfigMapData: map[string]string{"LOG_LEVEL": "info"},
+					},
+				}
+				Expect(mockReconciler.Create(ctx, sopsSecret)).To(Succeed())
+				reconcileSopsSecret(sopsSecret)
+				Expect(mockReconciler.Get(ctx, client.ObjectKeyFromObject(sopsSecret), &corev1.ConfigMap{})).To(Succeed())
+
+				Expect(mockReconciler.Delete(ctx, sopsSecret)).To(Succeed())
+				reconcileSopsSecret(sopsSecret)
+
+				err := mockReconciler.Get(ctx, client.ObjectKeyFromObject(sopsSecret), &corev1.ConfigMap{})
+				Expect(errors.IsNotFound(err)).To(BeTrue())
+				err = mockReconciler.Get(ctx, client.ObjectKeyFromObject(sopsSecret), &secretsv1alpha1.SopsSecret{})
+				Expect(errors.IsNotFound(err)).To(BeTrue())
+			})
+		})
+		Describe("JSON-typed keys", func() {
+			reconcileWith := func(name string, value []byte) *secretsv1alpha1.SopsSecret {
+				mockDecryptor.DecryptFunc = func(data []byte) (*sops.DecryptedData, error) {
+					return &sops.DecryptedData{Data: map[string][]byte{
+						"config.json": value,
+						"username":    []byte("username: admin"),
+					}}, nil
+				}
+				sopsSecret := &secretsv1alpha1.SopsSecret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:       name,
+						Namespace:  "default",
+						Finalizers: []string{finalizerName},
+					},
+					Spec: secretsv1alpha1.SopsSecretSpec{
+						SopsSecret: "config.json: ENC[test]\nusername: ENC[test]\nsops:\n    mac: test\n",
+						KeyTypes:   map[string]secretsv1alpha1.KeyType{"config.json": secretsv1alpha1.KeyTypeJSON},
+					},
+				}
+				Expect(mockReconciler.Create(ctx, sopsSecret)).To(Succeed())
+				_, err := mockReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(sopsSecret)})
+				Expect(err).NotTo(HaveOccurred())
+
+				updated := &secretsv1alpha1.SopsSecret{}
+				Expect(mockReconciler.Get(ctx, client.ObjectKeyFromObject(sopsSecret), updated)).To(Succeed())
+				return updated
+			}
+
+			It("should write the Secret when the value is valid JSON", func() {
+				updated := reconcileWith("json-valid", []byte(`config.json: '{"debug": true}'`))
+				Expect(meta.FindStatusCondition(updated.Status.Conditions, secretsv1alpha1.ConditionTypeInvalidJSON)).To(BeNil())
+				Expect(meta.IsStatusConditionTrue(updated.Status.Conditions, secretsv1alpha1.ConditionTypeReady)).To(BeTrue())
+				Expect(mockReconciler.Get(ctx, client.ObjectKeyFromObject(updated), &corev1.Secret{})).To(Succeed())
+			})
+
+			It("should accept nested values encoded as JSON", func() {
+				updated := reconcileWith("json-nested", []byte(`{"debug":true}`))
+				Expect(meta.FindStatusCondition(updated.Status.Conditions, secretsv1alpha1.ConditionTypeInvalidJSON)).To(BeNil())
+				Expect(meta.IsStatusConditionTrue(updated.Status.Conditions, secretsv1alpha1.ConditionTypeReady)).To(BeTrue())
+			})
+
+			It("should not write the Secret and name the key when the value is not valid JSON", func() {
+				updated := reconcileWith("json-invalid", []byte(`config.json: '{"debug": tru'`))
+
+				invalid := meta.FindStatusCondition(updated.Status.Conditions, secretsv1alpha1.ConditionTypeInvalidJSON)
+				Expect(invalid).NotTo(BeNil())
+				Expect(invalid.Status).To(Equal(metav1.ConditionTrue))
+				Expect(invalid.Message).To(ContainSubstring("config.json"))
+				Expect(invalid.Message).NotTo(ContainSubstring("debug"))
+				ready := meta.FindStatusCondition(updated.Status.Conditions, secretsv1alpha1.ConditionTypeReady)
+				Expect(ready.Status).To(Equal(metav1.ConditionFalse))
+				Expect(ready.Reason).To(Equal(ReasonInvalidJSON))
+
+				err := mockReconciler.Get(ctx, client.ObjectKeyFromObject(updated), &corev1.Secret{})
+				Expect(errors.IsNotFound(err)).To(BeTrue())
+			})
+		})
+		Describe("Condition stabilization", func() {
+			BeforeEach(func() {
+				mockDecryptor.DecryptFunc = func(data []byte) (*sops.DecryptedData, error) {
+					return &sops.DecryptedData{Data: map[string][]byte{
+						"password": []byte("password: secret"),
+						"username": []byte("username: admin"),
+					}}, nil
+				}
+				mockReconciler.ConditionStabilizationWindow = time.Hour
+			})
+
+			reconcileSopsSecret := func(sopsSecret *secretsv1alpha1.SopsSecret) (ctrl.Result, *secretsv1alpha1.SopsSecret) {
+				result, err := mockReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(sopsSecret)})
+				Expect(err).NotTo(HaveOccurred())
+				updated := &secretsv1alpha1.SopsSecret{}
+				Expect(mockReconciler.Get(ctx, client.ObjectKeyFromObject(sopsSecret), updated)).To(Succeed())
+				return result, updated
+			}
+
+			// changeSpec makes the next reconcile decrypt again
+			changeSpec := func(sopsSecret *secretsv1alpha1.SopsSecret) {
+				Expect(mockReconciler.Get(ctx, client.ObjectKeyFromObject(sopsSecret), sopsSecret)).To(Succeed())
+				sopsSecret.Generation++
+				sopsSecret.Spec.SecretLabels = map[string]string{"revision": fmt.Sprint(sopsSecret.Generation)}
+				Expect(mockReconciler.Update(ctx, sopsSecret)).To(Succeed())
+			}
+
+			It("should hold back Ready=False until failures outlast the window", func() {
+				sopsSecret := &secretsv1alpha1.SopsSecret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:       "stabilized",
+						Namespace:  "default",
+						Finalizers: []string{finalizerName},
+					},
+					Spec: secretsv1alpha1.SopsSecretSpec{
+						SopsSecret: "password: ENC[test]\nusername: ENC[test]\nsops:\n    mac: test\n",
+					},
+				}
+				Expect(mockReconciler.Create(ctx, sopsSecret)).To(Succeed())
+				_, updated := reconcileSopsSecret(sopsSecret)
+				Expect(meta.IsStatusConditionTrue(updated.Status.Conditions, secretsv1alpha1.ConditionTypeReady)).To(BeTrue())
+
+				By("failing once")
+				mockReconciler.MaxKeysPerSecret = 1
+				changeSpec(sopsSecret)
+				result, updated := reconcileSopsSecret(sopsSecret)
+				Expect(meta.IsStatusConditionTrue(updated.Status.Conditions, secretsv1alpha1.ConditionTypeReady)).To(BeTrue())
+				Expect(updated.Status.FirstFailureTime).NotTo(BeNil())
+				Expect(result.RequeueAfter).To(BeNumerically("<=", time.Hour))
+
+				By("failing after the window elapsed")
+				updated.Status.FirstFailureTime = &metav1.Time{Time: time.Now().Add(-2 * time.Hour)}
+				Expect(mockReconciler.Status().Update(ctx, updated)).To(Succeed())
+				_, updated = reconcileSopsSecret(sopsSecret)
+				ready := meta.FindStatusCondition(updated.Status.Conditions, secretsv1alpha1.ConditionTypeReady)
+				Expect(ready.Status).To(Equal(metav1.ConditionFalse))
+				Expect(ready.Reason).To(Equal(ReasonTooManyKeys))
+
+				By("recovering")
+				mockReconciler.MaxKeysPerSecret = 0
+				changeSpec(sopsSecret)
+				_, updated = reconcileSopsSecret(sopsSecret)
+				Expect(meta.IsStatusConditionTrue(updated.Status.Conditions, secretsv1alpha1.ConditionTypeReady)).To(BeTrue())
+				Expect(updated.Status.FirstFailureTime).To(BeNil())
+			})
+
+			It("should report failures immediately when the SopsSecret was not Ready", func() {
+				mockReconciler.MaxKeysPerSecret = 1
+				sopsSecret := &secretsv1alpha1.SopsSecret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:       "stabilized-new",
+						Namespace:  "default",
+						Finalizers: []string{finalizerName},
+					},
+					Spec: secretsv1alpha1.SopsSecretSpec{
+						SopsSecret: "password: ENC[test]\nusername: ENC[test]\nsops:\n    mac: test\n",
+					},
+				}
+				Expect(mockReconciler.Create(ctx, sopsSecret)).To(Succeed())
+				_, updated := reconcileSopsSecret(sopsSecret)
+				Expect(meta.IsStatusConditionFalse(updated.Status.Conditions, secretsv1alpha1.ConditionTypeReady)).To(BeTrue())
+			})
+		})
+		Describe("Kubeconfig validation", func() {
+			const kubeconfig = `apiVersion: v1
+kind: Config
+clusters:
+- name: remote
+  cluster:
+    server: https://remote.example.com:6443
+users:
+- name: admin
+  user:
+    token: remote-token
+contexts:
+- name: remote
+  context:
+    cluster: remote
+    user: admin
+current-context: remote
+`
+
+			reconcileWith := func(name string, value string) *secretsv1alpha1.SopsSecret {
+				// Flat values are stored wrapped under their key, as a block scalar here
+				wrapped := "value: |\n  " + strings.ReplaceAll(strings.TrimSuffix(value, "\n"), "\n", "\n  ")
+				mockDecryptor.DecryptFunc = func(data []byte) (*sops.DecryptedData, error) {
+					return &sops.DecryptedData{Data: map[string][]byte{"value": []byte(wrapped)}}, nil
+				}
+				sopsSecret := &secretsv1alpha1.SopsSecret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:       name,
+						Namespace:  "default",
+						Finalizers: []string{finalizerName},
+					},
+					Spec: secretsv1alpha1.SopsSecretSpec{
+						SopsSecret:         "value: ENC[test]\nsops:\n    mac: test\n",
+						ValidateKubeconfig: true,
+					},
+				}
+				Expect(mockReconciler.Create(ctx, sopsSecret)).To(Succeed())
+				_, err := mockReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(sopsSecret)})
+				Expect(err).NotTo(HaveOccurred())
+
+				updated := &secretsv1alpha1.SopsSecret{}
+				Expect(mockReconciler.Get(ctx, client.ObjectKeyFromObject(sopsSecret), updated)).To(Succeed())
+				return updated
+			}
+
+			It("should write a Secret with a valid kubeconfig", func() {
+				updated := reconcileWith("kubeconfig-valid", kubeconfig)
+				Expect(meta.FindStatusCondition(updated.Status.Conditions, secretsv1alpha1.ConditionTypeInvalidKubeconfig)).To(BeNil())
+				Expect(meta.IsStatusConditionTrue(updated.Status.Conditions, secretsv1alpha1.ConditionTypeReady)).To(BeTrue())
+				Expect(mockReconciler.Get(ctx, client.ObjectKeyFromObject(updated), &corev1.Secret{})).To(Succeed())
+			})
+
+			It("should not write a Secret with an unusable kubeconfig", func() {
+				updated := reconcileWith("kubeconfig-invalid", strings.Replace(kubeconfig, "current-context: remote", "current-context: missing", 1))
+
+				invalid := meta.FindStatusCondition(updated.Status.Conditions, secretsv1alpha1.ConditionTypeInvalidKubeconfig)
+				Expect(invalid).NotTo(BeNil())
+				Expect(invalid.Status).To(Equal(metav1.ConditionTrue))
+				Expect(invalid.Message).To(ContainSubstring("value"))
+				Expect(invalid.Message).NotTo(ContainSubstring("remote-token"))
+				Expect(meta.IsStatusConditionFalse(updated.Status.Conditions, secretsv1alpha1.ConditionTypeReady)).To(BeTrue())
+
+				err := mockReconciler.Get(ctx, client.ObjectKeyFromObject(updated), &corev1.Secret{})
+				Expect(errors.IsNotFound(err)).To(BeTrue())
+			})
+
+			It("should not write a Secret whose kubeconfig does not parse", func() {
+				updated := reconcileWith("kubeconfig-garbage", "not: [a kubeconfig")
+				Expect(meta.IsStatusConditionTrue(updated.Status.Conditions, secretsv1alpha1.ConditionTypeInvalidKubeconfig)).To(BeTrue())
+				Expect(meta.IsStatusConditionFalse(updated.Status.Conditions, secretsv1alpha1.ConditionTypeReady)).To(BeTrue())
+			})
+		})
+		Describe("Suspend and unsuspend", func() {
+			var decryptCalls int
+
+			BeforeEach(func() {
+				decryptCalls = 0
+				mockDecryptor.DecryptFunc = func(data []byte) (*sops.DecryptedData, error) {
+					decryptCalls++
+					return &sops.DecryptedData{Data: map[string][]byte{"password": []byte("password: secret")}}, nil
+				}
+			})
+
+			setSuspend := func(sopsSecret *secretsv1alpha1.SopsSecret, suspend bool) {
+				Expect(mockReconciler.Get(ctx, client.ObjectKeyFromObject(sopsSecret), sopsSecret)).To(Succeed())
+				sopsSecret.Spec.Suspend = suspend
+				sopsSecret.Generation++
+				Expect(mockReconciler.Update(ctx, sopsSecret)).To(Succeed())
+				_, err := mockReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(sopsSecret)})
+				Expect(err).NotTo(HaveOccurred())
+			}
+
+			newSopsSecret := func(name string) *secretsv1alpha1.SopsSecret {
+				sopsSecret := &secretsv1alpha1.SopsSecret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:       name,
+						Namespace:  "default",
+						Generation: 1,
+						Finalizers: []string{finalizerName},
+					},
+					Spec: secretsv1alpha1.SopsSecretSpec{
+						SopsSecret: "password: ENC[test]\nsops:\n    mac: test\n",
+					},
+				}
+				Expect(mockReconciler.Create(ctx, sopsSecret)).To(Succeed())
+				_, err := mockReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(sopsSecret)})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(decryptCalls).To(Equal(1))
+				return sopsSecret
+			}
+
+			It("should not decrypt again when unsuspended with an unchanged payload", func() {
+				sopsSecret := newSopsSecret("suspend-toggle")
+
+				setSuspend(sopsSecret, true)
+				setSuspend(sopsSecret, false)
+				Expect(decryptCalls).To(Equal(1))
+
+				updated := &secretsv1alpha1.SopsSecret{}
+				Expect(mockReconciler.Get(ctx, client.ObjectKeyFromObject(sopsSecret), updated)).To(Succeed())
+				Expect(updated.Status.ObservedGeneration).To(Equal(updated.Generation))
+				Expect(meta.IsStatusConditionTrue(updated.Status.Conditions, secretsv1alpha1.ConditionTypeReady)).To(BeTrue())
+			})
+
+			It("should decrypt again when the Secret was deleted while suspended", func() {
+				sopsSecret := newSopsSecret("suspend-secret-deleted")
+
+				setSuspend(sopsSecret, true)
+				Expect(mockReconciler.Delete(ctx, &corev1.Secret{ObjectMeta: metav1.ObjectMeta{
+					Name: "suspend-secret-deleted", Namespace: "default",
+				}})).To(Succeed())
+				setSuspend(sopsSecret, false)
+
+				Expect(decryptCalls).To(Equal(2))
+				Expect(mockReconciler.Get(ctx, client.ObjectKeyFromObject(sopsSecret), &corev1.Secret{})).To(Succeed())
+			})
+		})
+		Describe("Owner reference migration", func() {
+			BeforeEach(func() {
+				mockDecryptor.DecryptFunc = func(data []byte) (*sops.DecryptedData, error) {
+					return &sops.DecryptedData{Data: map[string][]byte{"password": []byte("password: secret")}}, nil
+				}
+			})
+
+			// ageOwnerReference rewrites the Secret's owner reference as an
+			// earlier API version of the operator would have written it
+			ageOwnerReference := func(sopsSecret *secretsv1alpha1.SopsSecret) {
+				secret := &corev1.Secret{}
+				Expect(mockReconciler.Get(ctx, client.ObjectKeyFromObject(sopsSecret), secret)).To(Succeed())
+				Expect(secret.OwnerReferences).To(HaveLen(1))
+				secret.OwnerReferences[0].APIVersion = "secrets.scalaric.io/v1alpha0"
+				Expect(mockReconciler.Update(ctx, secret)).To(Succeed())
+			}
+
+			expectCurrentOwnerReference := func(sopsSecret *secretsv1alpha1.SopsSecret) {
+				secret := &corev1.Secret{}
+				Expect(mockReconciler.Get(ctx, client.ObjectKeyFromObject(sopsSecret), secret)).To(Succeed())
+				Expect(secret.OwnerReferences).To(HaveLen(1))
+				Expect(secret.OwnerReferences[0].APIVersion).To(Equal(secretsv1alpha1.GroupVersion.String()))
+				Expect(metav1.IsControlledBy(secret, sopsSecret)).To(BeTrue())
+			}
+
+			newSopsSecret := func(name string) *secretsv1alpha1.SopsSecret {
+				sopsSecret := &secretsv1alpha1.SopsSecret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:       name,
+						Namespace:  "default",
+						Finalizers: []string{finalizerName},
+					},
+					Spec: secretsv1alpha1.SopsSecretSpec{
+						SopsSecret: "password: ENC[test]\nsops:\n    mac: test\n",
+					},
+				}
+				Expect(mockReconciler.Create(ctx, sopsSecret)).To(Succeed())
+				_, err := mockReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(sopsSecret)})
+				Expect(err).NotTo(HaveOccurred())
+				return sopsSecret
+			}
+
+			It("should update a stale owner reference apiVersion on an unchanged Secret", func() {
+				sopsSecret := newSopsSecret("stale-owner")
+				ageOwnerReference(sopsSecret)
+
+				_, err := mockReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(sopsSecret)})
+				Expect(err).NotTo(HaveOccurred())
+				expectCurrentOwnerReference(sopsSecret)
+			})
+
+			It("should update a stale owner reference apiVersion when the Secret is rewritten", func() {
+				sopsSecret := newSopsSecret("stale-owner-updated")
+				ageOwnerReference(sopsSecret)
+
+				Expect(mockReconciler.Get(ctx, client.ObjectKeyFromObject(sopsSecret), sopsSecret)).To(Succeed())
+				sopsSecret.Spec.SopsSecret = "password: ENC[changed]\nsops:\n    mac: test\n"
+				Expect(mockReconciler.Update(ctx, sopsSecret)).To(Succeed())
+				_, err := mockReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(sopsSecret)})
+				Expect(err).NotTo(HaveOccurred())
+				expectCurrentOwnerReference(sopsSecret)
+			})
+		})
+		Describe("Secret adoption", func() {
+			var recorder *events.FakeRecorder
+
+			BeforeEach(func() {
+				recorder = events.NewFakeRecorder(20)
+				mockReconciler.Recorder = recorder
+				mockDecryptor.DecryptFunc = func(data []byte) (*sops.DecryptedData, error) {
+					return &sops.DecryptedData{Data: map[string][]byte{"password": []byte("password: secret")}}, nil
+				}
+			})
+
+			recordedEvents := func() []string {
+				var recorded []string
+				for len(recorder.Events) > 0 {
+					recorded = append(recorded, <-recorder.Events)
+				}
+				return recorded
+			}
+
+			reconcileNew := func(name string) *secretsv1alpha1.SopsSecret {
+				sopsSecret := &secretsv1alpha1.SopsSecret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:       name,
+						Namespace:  "default",
+						Finalizers: []string{finalizerName},
+					},
+					Spec: secretsv1alpha1.SopsSecretSpec{
+						SopsSecret: "password: ENC[test]\nsops:\n    mac: test\n",
+					},
+				}
+				Expect(mockReconciler.Create(ctx, sopsSecret)).To(Succeed())
+				_, err := mockReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(sopsSecret)})
+				Expect(err).NotTo(HaveOccurred())
+
+				updated := &secretsv1alpha1.SopsSecret{}
+				Expect(mockReconciler.Get(ctx, client.ObjectKeyFromObject(sopsSecret), updated)).To(Succeed())
+				return updated
+			}
+
+			It("should adopt an unowned Secret carrying the SopsSecret label", func() {
+				Expect(mockReconciler.Create(ctx, &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "restored",
+						Namespace: "default",
+						Labels:    map[string]string{sopsSecretLabel: "restored"},
+					},
+					Data: map[string][]byte{"password": []byte("stale")},
+				})).To(Succeed())
+
+				updated := reconcileNew("restored")
+
+				adopted := meta.FindStatusCondition(updated.Status.Conditions, secretsv1alpha1.ConditionTypeAdopted)
+				Expect(adopted).NotTo(BeNil())
+				Expect(adopted.Status).To(Equal(metav1.ConditionTrue))
+				Expect(adopted.Reason).To(Equal(ReasonSecretAdopted))
+				Expect(recordedEvents()).To(ContainElement(ContainSubstring(ReasonSecretAdopted)))
+
+				secret := &corev1.Secret{}
+				Expect(mockReconciler.Get(ctx, client.ObjectKeyFromObject(updated), secret)).To(Succeed())
+				Expect(metav1.IsControlledBy(secret, updated)).To(BeTrue())
+			})
+
+			It("should not report adoption on create or update", func() {
+				updated := reconcileNew("not-adopted")
+				Expect(meta.FindStatusCondition(updated.Status.Conditions, secretsv1alpha1.ConditionTypeAdopted)).To(BeNil())
+
+				updated.Spec.SopsSecret = "password: ENC[changed]\nsops:\n    mac: test\n"
+				Expect(mockReconciler.Update(ctx, updated)).To(Succeed())
+				_, err := mockReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(updated)})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(mockReconciler.Get(ctx, client.ObjectKeyFromObject(updated), updated)).To(Succeed())
+				Expect(meta.FindStatusCondition(updated.Status.Conditions, secretsv1alpha1.ConditionTypeAdopted)).To(BeNil())
+				recorded := recordedEvents()
+				Expect(recorded).To(ContainElement(ContainSubstring(ReasonSecretUpdated)))
+				Expect(recorded).NotTo(ContainElement(ContainSubstring(ReasonSecretAdopted)))
+			})
+
+			It("should not adopt an unowned Secret without the label", func() {
+				Expect(mockReconciler.Create(ctx, &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: "unlabeled", Namespace: "default"},
+				})).To(Succeed())
+
+				updated := reconcileNew("unlabeled")
+				Expect(meta.FindStatusCondition(updated.Status.Conditions, secretsv1alpha1.ConditionTypeAdopted)).To(BeNil())
+				Expect(recordedEvents()).NotTo(ContainElement(ContainSubstring(ReasonSecretAdopted)))
+			})
+		})
+		Describe("Source generation annotations", func() {
+			BeforeEach(func() {
+				mockDecryptor.DecryptFunc = func(data []byte) (*sops.DecryptedData, error) {
+					return &sops.DecryptedData{Data: map[string][]byte{"password": []byte("password: secret")}}, nil
+				}
+			})
+
+			reconcileAndGetSecret := func(sopsSecret *secretsv1alpha1.SopsSecret) *corev1.Secret {
+				_, err := mockReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(sopsSecret)})
+				Expect(err).NotTo(HaveOccurred())
+				secret := &corev1.Secret{}
+				Expect(mockReconciler.Get(ctx, client.ObjectKeyFromObject(sopsSecret), secret)).To(Succeed())
+				return secret
+			}
+
+			It("should annotate the Secret with the current generation", func() {
+				sopsSecret := &secretsv1alpha1.SopsSecret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:       "source-generation",
+						Namespace:  "default",
+						Generation: 1,
+						Finalizers: []string{finalizerName},
+					},
+					Spec: secretsv1alpha1.SopsSecretSpec{
+						SopsSecret: "password: ENC[test]\nsops:\n    mac: test\n",
+					},
+				}
+				Expect(mockReconciler.Create(ctx, sopsSecret)).To(Succeed())
+				secret := reconcileAndGetSecret(sopsSecret)
+				Expect(secret.Annotations).To(HaveKeyWithValue(sourceGenerationAnnotation, "1"))
+				Expect(secret.Annotations).To(HaveKeyWithValue(sourceResourceVersionAnnotation, sopsSecret.ResourceVersion))
+
+				By("changing the payload")
+				Expect(mockReconciler.Get(ctx, client.ObjectKeyFromObject(sopsSecret), sopsSecret)).To(Succeed())
+				sopsSecret.Spec.SopsSecret = "password: ENC[changed]\nsops:\n    mac: test\n"
+				sopsSecret.Generation = 2
+				Expect(mockReconciler.Update(ctx, sopsSecret)).To(Succeed())
+				secret = reconcileAndGetSecret(sopsSecret)
+				Expect(secret.Annotations).To(HaveKeyWithValue(sourceGenerationAnnotation, "2"))
+				Expect(secret.Annotations).To(HaveKeyWithValue(sourceResourceVersionAnnotation, sopsSecret.ResourceVersion))
+
+				By("toggling suspend")
+				Expect(mockReconciler.Get(ctx, client.ObjectKeyFromObject(sopsSecret), sopsSecret)).To(Succeed())
+				sopsSecret.Spec.Suspend = true
+				sopsSecret.Generation = 3
+				Expect(mockReconciler.Update(ctx, sopsSecret)).To(Succeed())
+				reconcileAndGetSecret(sopsSecret)
+				Expect(mockReconciler.Get(ctx, client.ObjectKeyFromObject(sopsSecret), sopsSecret)).To(Succeed())
+				sopsSecret.Spec.Suspend = false
+				sopsSecret.Generation = 4
+				Expect(mockReconciler.Update(ctx, sopsSecret)).To(Succeed())
+				secret = reconcileAndGetSecret(sopsSecret)
+				Expect(secret.Annotations).To(HaveKeyWithValue(sourceGenerationAnnotation, "4"))
+			})
+		})
+		Describe("Encrypted document from chunks", func() {
+			const document = "password: ENC[test]\nsops:\n    mac: test\n"
+			var decryptedPayload string
+
+			BeforeEach(func() {
+				decryptedPayload = ""
+				mockDecryptor.DecryptFunc = func(data []byte) (*sops.DecryptedData, error) {
+					decryptedPayload = string(data)
+					return &sops.DecryptedData{Data: map[string][]byte{"password": []byte("password: secret")}}, nil
+				}
+				// The document split across two ConfigMaps, the second chunk in binaryData
+				Expect(mockReconciler.Create(ctx, &corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{Name: "chunks-a", Namespace: "default"},
+					Data:       map[string]string{"part0": document[:12]},
+				})).To(Succeed())
+				Expect(mockReconciler.Create(ctx, &corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{Name: "chunks-b", Namespace: "default"},
+					Data:       map[string]string{"part1": document[12:25]},
+					BinaryData: map[string][]byte{"part2": []byte(document[25:])},
+				})).To(Succeed())
+			})
+
+			reconcileWith := func(name string, chunks []secretsv1alpha1.ConfigMapChunkRef) *secretsv1alpha1.SopsSecret {
+				sopsSecret := &secretsv1alpha1.SopsSecret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:       name,
+						Namespace:  "default",
+						Finalizers: []string{finalizerName},
+					},
+					Spec: secretsv1alpha1.SopsSecretSpec{EncryptedFromChunks: chunks},
+				}
+				Expect(mockReconciler.Create(ctx, sopsSecret)).To(Succeed())
+				_, err := mockReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(sopsSecret)})
+				Expect(err).NotTo(HaveOccurred())
+
+				updated := &secretsv1alpha1.SopsSecret{}
+				Expect(mockReconciler.Get(ctx, client.ObjectKeyFromObject(sopsSecret), updated)).To(Succeed())
+				return updated
+			}
+
+			expectAssembled := func(updated *secretsv1alpha1.SopsSecret) {
+				Expect(decryptedPayload).To(Equal(document))
+				Expect(meta.IsStatusConditionTrue(updated.Status.Conditions, secretsv1alpha1.ConditionTypeChunksComplete)).To(BeTrue())
+				Expect(meta.IsStatusConditionTrue(updated.Status.Conditions, secretsv1alpha1.ConditionTypeReady)).To(BeTrue())
+				Expect(mockReconciler.Get(ctx, client.ObjectKeyFromObject(updated), &corev1.Secret{})).To(Succeed())
+			}
+
+			expectIncomplete := func(updated *secretsv1alpha1.SopsSecret, reason string) {
+				Expect(decryptedPayload).To(BeEmpty())
+				chunks := meta.FindStatusCondition(updated.Status.Conditions, secretsv1alpha1.ConditionTypeChunksComplete)
+				Expect(chunks).NotTo(BeNil())
+				Expect(chunks.Status).To(Equal(metav1.ConditionFalse))
+				Expect(chunks.Reason).To(Equal(reason))
+				Expect(meta.IsStatusConditionFalse(updated.Status.Conditions, secretsv1alpha1.ConditionTypeReady)).To(BeTrue())
+			}
+
+			It("should assemble chunks listed in order", func() {
+				expectAssembled(reconcileWith("chunks-ordered", []secretsv1alpha1.ConfigMapChunkRef{
+					{Name: "chunks-a", Key: "part0", Index: 0},
+					{Name: "chunks-b", Key: "part1", Index: 1},
+					{Name: "chunks-b", Key: "part2", Index: 2},
+				}))
+			})
+
+			It("should assemble chunks listed out of order", func() {
+				expectAssembled(reconcileWith("chunks-unordered", []secretsv1alpha1.ConfigMapChunkRef{
+					{Name: "chunks-b", Key: "part2", Index: 2},
+					{Name: "chunks-a", Key: "part0", Index: 0},
+					{Name: "chunks-b", Key: "part1", Index: 1},
+				}))
+			})
+
+			It("should report a gap in the chunk indexes", func() {
+				expectIncomplete(reconcileWith("chunks-gap", []secretsv1alpha1.ConfigMapChunkRef{
+					{Name: "chunks-a", Key: "part0", Index: 0},
+					{Name: "chunks-b", Key: "part2", Index: 2},
+				}), "MissingChunk")
+			})
+
+			It("should report a duplicate chunk index", func() {
+				expectIncomplete(reconcileWith("chunks-duplicate", []secretsv1alpha1.ConfigMapChunkRef{
+					{Name: "chunks-a", Key: "part0", Index: 0},
+					{Name: "chunks-b", Key: "part1", Index: 1},
+					{Name: "chunks-b", Key: "part2", Index: 1},
+				}), "DuplicateChunk")
+			})
+
+			It("should report a chunk whose ConfigMap or key does not exist", func() {
+				expectIncomplete(reconcileWith("chunks-missing-configmap", []secretsv1alpha1.ConfigMapChunkRef{
+					{Name: "chunks-a", Key: "part0", Index: 0},
+					{Name: "chunks-c", Key: "part1", Index: 1},
+				}), "ChunkNotFound")
+				expectIncomplete(reconcileWith("chunks-missing-key", []secretsv1alpha1.ConfigMapChunkRef{
+					{Name: "chunks-a", Key: "part0", Index: 0},
+					{Name: "chunks-a", Key: "part1", Index: 1},
+				}), "ChunkNotFound")
+			})
+		})
+
+		Describe("Server-side apply", func() {
+			It("should apply the Secret as the sops-operator field manager and keep fields of other managers", func() {
+				var fieldManagers []string
+				base := mockReconciler.Client.(client.WithWatch)
+				mockReconciler.ServerSideApply = true
+				mockReconciler.Client = interceptor.NewClient(base, interceptor.Funcs{
+					Apply: func(ctx context.Context, c client.WithWatch, obj runtime.ApplyConfiguration, opts ...client.ApplyOption) error {
+						applyOpts := &client.ApplyOptions{}
+						applyOpts.ApplyOptions(opts)
+						fieldManagers = append(fieldManagers, applyOpts.FieldManager)
+						return c.Apply(ctx, obj, opts...)
+					},
+					Update: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+						if _, ok := obj.(*corev1.Secret); ok {
+							Fail("Secret updated without server-side apply")
+						}
+						return c.Update(ctx, obj, opts...)
+					},
+				})
+				password := "first"
+				mockDecryptor.DecryptFunc = func(_ []byte) (*sops.DecryptedData, error) {
+					return &sops.DecryptedData{Data: map[string][]byte{"password": []byte(password)}}, nil
+				}
+				sopsSecret := &secretsv1alpha1.SopsSecret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:       "server-side-apply",
+						Namespace:  "default",
+						Finalizers: []string{finalizerName},
+					},
+					Spec: secretsv1alpha1.SopsSecretSpec{SopsSecret: "password: ENC[test]\nsops:\n    mac: test\n"},
+				}
+				Expect(mockReconciler.Create(ctx, sopsSecret)).To(Succeed())
+				key := client.ObjectKeyFromObject(sopsSecret)
+
+				_, err := mockReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(fieldManagers).To(Equal([]string{fieldManager}))
+
+				secret := &corev1.Secret{}
+				Expect(mockReconciler.Get(ctx, key, secret)).To(Succeed())
+				Expect(secret.Data).To(HaveKeyWithValue("password", []byte("first")))
+				Expect(metav1.IsControlledBy(secret, sopsSecret)).To(BeTrue())
+
+				By("adding fields as another field manager")
+				secret.Labels["team"] = "payments"
+				secret.Data["extra"] = []byte("added")
+				Expect(base.Update(ctx, secret, client.FieldOwner("other-controller"))).To(Succeed())
+
+				By("updating the document")
+				password = "second"
+				updated := &secretsv1alpha1.SopsSecret{}
+				Expect(mockReconciler.Get(ctx, key, updated)).To(Succeed())
+				updated.Generation++
+				updated.Spec.SopsSecret = "password: ENC[rotated]\nsops:\n    mac: test\n"
+				Expect(mockReconciler.Update(ctx, updated)).To(Succeed())
+				_, err = mockReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(fieldManagers).To(Equal([]string{fieldManager, fieldManager}))
+
+				Expect(mockReconciler.Get(ctx, key, secret)).To(Succeed())
+				Expect(secret.Data).To(HaveKeyWithValue("password", []byte("second")))
+				Expect(secret.Data).To(HaveKeyWithValue("extra", []byte("added")))
+				Expect(secret.Labels).To(HaveKeyWithValue("team", "payments"))
+				Expect(secret.Labels).To(HaveKeyWithValue(sopsSecretLabel, sopsSecret.Name))
+			})
+
+			It("should take over the fields written with Update when switching to server-side apply", func() {
+				base := fake.NewClientBuilder().
+					WithScheme(scheme.Scheme).
+					WithStatusSubresource(&secretsv1alpha1.SopsSecret{}).
+					WithReturnManagedFields().
+					Build()
+				mockReconciler.Client = base
+				mockDecryptor.DecryptFunc = func(_ []byte) (*sops.DecryptedData, error) {
+					return &sops.DecryptedData{Data: map[string][]byte{"password": []byte("secret")}}, nil
+				}
+				sopsSecret := &secretsv1alpha1.SopsSecret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:       "switch-to-apply",
+						Namespace:  "default",
+						Finalizers: []string{finalizerName},
+					},
+					Spec: secretsv1alpha1.SopsSecretSpec{
+						SopsSecret:   "password: ENC[test]\nsops:\n    mac: test\n",
+						SecretLabels: map[string]string{"team": "payments", "tier": "backend"},
+					},
+				}
+				Expect(mockReconciler.Create(ctx, sopsSecret)).To(Succeed())
+				key := client.ObjectKeyFromObject(sopsSecret)
+				_, err := mockReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+				Expect(err).NotTo(HaveOccurred())
+
+				By("pointing the owner reference at an older API version")
+				secret := &corev1.Secret{}
+				Expect(base.Get(ctx, key, secret)).To(Succeed())
+				Expect(secret.Labels).To(HaveKeyWithValue("tier", "backend"))
+				secret.OwnerReferences[0].APIVersion = "secrets.scalaric.io/v1alpha0"
+				Expect(base.Update(ctx, secret, client.FieldOwner(fieldManager))).To(Succeed())
+				secret.Labels["owner"] = "platform"
+				Expect(base.Update(ctx, secret, client.FieldOwner("other-controller"))).To(Succeed())
+
+				By("switching to server-side apply and dropping a label")
+				mockReconciler.ServerSideApply = true
+				updated := &secretsv1alpha1.SopsSecret{}
+				Expect(mockReconciler.Get(ctx, key, updated)).To(Succeed())
+				updated.Generation++
+				updated.Spec.SecretLabels = map[string]string{"team": "payments"}
+				Expect(mockReconciler.Update(ctx, updated)).To(Succeed())
+				_, err = mockReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(base.Get(ctx, key, secret)).To(Succeed())
+				Expect(secret.Labels).NotTo(HaveKey("tier"))
+				Expect(secret.Labels).To(HaveKeyWithValue("team", "payments"))
+				Expect(secret.Labels).To(HaveKeyWithValue("owner", "platform"))
+				Expect(secret.OwnerReferences).To(HaveLen(1))
+				Expect(secret.OwnerReferences[0].APIVersion).To(Equal(secretsv1alpha1.GroupVersion.String()))
+				for _, entry := range secret.ManagedFields {
+					if entry.Manager == fieldManager {
+						Expect(entry.Operation).To(Equal(metav1.ManagedFieldsOperationApply))
+					}
+				}
+			})
+		})
+
+		Describe("String data", func() {
+			It("should write valid UTF-8 values to stringData on create and update", func() {
+				password := "first"
+				mockDecryptor.DecryptFunc = func(_ []byte) (*sops.DecryptedData, error) {
+					return &sops.DecryptedData{Data: map[string][]byte{
+						"password": []byte(password),
+						"binary":   {0xff, 0xfe},
+					}}, nil
+				}
+				sopsSecret := &secretsv1alpha1.SopsSecret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:       "string-data",
+						Namespace:  "default",
+						Finalizers: []string{finalizerName},
+					},
+					Spec: secretsv1alpha1.SopsSecretSpec{
+						SopsSecret: "password: ENC[test]\nsops:\n    mac: test\n",
+						StringData: true,
+					},
+				}
+				Expect(mockReconciler.Create(ctx, sopsSecret)).To(Succeed())
+				key := client.ObjectKeyFromObject(sopsSecret)
+
+				_, err := mockReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+				Expect(err).NotTo(HaveOccurred())
+
+				secret := &corev1.Secret{}
+				Expect(mockReconciler.Get(ctx, key, secret)).To(Succeed())
+				Expect(secret.StringData).To(Equal(map[string]string{"password": "first"}))
+				Expect(secret.Data).To(Equal(map[string][]byte{"binary": {0xff, 0xfe}}))
+
+				updated := &secretsv1alpha1.SopsSecret{}
+				Expect(mockReconciler.Get(ctx, key, updated)).To(Succeed())
+				Expect(updated.Status.ManagedKeys).To(Equal([]string{"binary", "password"}))
+
+				By("skipping an unchanged document")
+				password = "ignored"
+				_, err = mockReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(mockReconciler.Get(ctx, key, secret)).To(Succeed())
+				Expect(secret.StringData).To(HaveKeyWithValue("password", "first"))
+
+				By("updating the document")
+				password = "second"
+				updated.Generation++
+				updated.Spec.SopsSecret = "password: ENC[rotated]\nsops:\n    mac: test\n"
+				Expect(mockReconciler.Update(ctx, updated)).To(Succeed())
+				_, err = mockReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(mockReconciler.Get(ctx, key, secret)).To(Succeed())
+				Expect(secret.StringData).To(Equal(map[string]string{"password": "second"}))
+				Expect(secret.Data).To(Equal(map[string][]byte{"binary": {0xff, 0xfe}}))
+			})
+		})
+
+		Describe("Recursive decrypt", func() {
+			const innerDocument = "password: ENC[inner]\nsops:\n    mac: inner"
+
+			newSopsSecret := func(name string) client.ObjectKey {
+				sopsSecret := &secretsv1alpha1.SopsSecret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:       name,
+						Namespace:  "default",
+						Finalizers: []string{finalizerName},
+					},
+					Spec: secretsv1alpha1.SopsSecretSpec{
+						SopsSecret:       "database: ENC[outer]\nsops:\n    mac: test\n",
+						RecursiveDecrypt: true,
+					},
+				}
+				Expect(mockReconciler.Create(ctx, sopsSecret)).To(Succeed())
+				return client.ObjectKeyFromObject(sopsSecret)
+			}
+
+			It("should inline the decrypted document of a value that is a sops document", func() {
+				var decrypted []string
+				mockDecryptor.DecryptFunc = func(data []byte) (*sops.DecryptedData, error) {
+					decrypted = append(decrypted, string(data))
+					if string(data) == innerDocument {
+						return &sops.DecryptedData{Data: map[string][]byte{"password": []byte("password: secret")}}, nil
+					}
+					return &sops.DecryptedData{Data: map[string][]byte{
+						"database": []byte("database: |\n    password: ENC[inner]\n    sops:\n        mac: inner"),
+						"user":     []byte("user: admin"),
+					}}, nil
+				}
+				key := newSopsSecret("recursive-decrypt")
+
+				_, err := mockReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(decrypted).To(HaveLen(2))
+				Expect(decrypted[1]).To(Equal(innerDocument))
+
+				secret := &corev1.Secret{}
+				Expect(mockReconciler.Get(ctx, key, secret)).To(Succeed())
+				Expect(string(secret.Data["database"])).To(ContainSubstring("password: secret"))
+				Expect(string(secret.Data["database"])).NotTo(ContainSubstring("sops"))
+				Expect(string(secret.Data["user"])).To(ContainSubstring("admin"))
+			})
+
+			It("should fail a document that keeps nesting sops documents", func() {
+				calls := 0
+				mockDecryptor.DecryptFunc = func(_ []byte) (*sops.DecryptedData, error) {
+					calls++
+					return &sops.DecryptedData{Data: map[string][]byte{
+						"database": []byte("database: |\n    database: ENC[self]\n    sops:\n        mac: self"),
+					}}, nil
+				}
+				key := newSopsSecret("recursive-decrypt-loop")
+
+				_, err := mockReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(calls).To(Equal(1 + maxNestedDecryptLevels))
+
+				updated := &secretsv1alpha1.SopsSecret{}
+				Expect(mockReconciler.Get(ctx, key, updated)).To(Succeed())
+				ready := meta.FindStatusCondition(updated.Status.Conditions, secretsv1alpha1.ConditionTypeReady)
+				Expect(ready).NotTo(BeNil())
+				Expect(ready.Status).To(Equal(metav1.ConditionFalse))
+				Expect(ready.Reason).To(Equal(ReasonDecryptFailed))
+				decryptedCond := meta.FindStatusCondition(updated.Status.Conditions, secretsv1alpha1.ConditionTypeDecrypted)
+				Expect(decryptedCond.Message).To(ContainSubstring("nested more than 3 levels"))
+				Expect(errors.IsNotFound(mockReconciler.Get(ctx, key, &corev1.Secret{}))).To(BeTrue())
+			})
+		})
+
+		Describe("Nesting depth", func() {
+			It("should report a document nested beyond the limit and clear it once fixed", func() {
+				decryptErr := error(&sops.DepthError{Key: "app", MaxDepth: 3})
+				mockDecryptor.DecryptFunc = func(_ []byte) (*sops.DecryptedData, error) {
+					if decryptErr != nil {
+						return nil, decryptErr
+					}
+					return &sops.DecryptedData{Data: map[string][]byte{"app": []byte("app:\n  db: localhost")}}, nil
+				}
+				sopsSecret := &secretsv1alpha1.SopsSecret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:       "too-deep",
+						Namespace:  "default",
+						Finalizers: []string{finalizerName},
+					},
+					Spec: secretsv1alpha1.SopsSecretSpec{SopsSecret: "app: ENC[test]\nsops:\n    mac: test\n"},
+				}
+				Expect(mockReconciler.Create(ctx, sopsSecret)).To(Succeed())
+				key := client.ObjectKeyFromObject(sopsSecret)
+
+				_, err := mockReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+				Expect(err).NotTo(HaveOccurred())
+
+				updated := &secretsv1alpha1.SopsSecret{}
+				Expect(mockReconciler.Get(ctx, key, updated)).To(Succeed())
+				nested := meta.FindStatusCondition(updated.Status.Conditions, secretsv1alpha1.ConditionTypeTooDeeplyNested)
+				Expect(nested).NotTo(BeNil())
+				Expect(nested.Status).To(Equal(metav1.ConditionTrue))
+				Expect(nested.Message).To(ContainSubstring("app"))
+				ready := meta.FindStatusCondition(updated.Status.Conditions, secretsv1alpha1.ConditionTypeReady)
+				Expect(ready.Status).To(Equal(metav1.ConditionFalse))
+				Expect(ready.Reason).To(Equal(ReasonTooDeeplyNested))
+				Expect(errors.IsNotFound(mockReconciler.Get(ctx, key, &corev1.Secret{}))).To(BeTrue())
+
+				By("decrypting a document within the limit")
+				decryptErr = nil
+				updated.Generation++
+				updated.Spec.SopsSecret = "app: ENC[flat]\nsops:\n    mac: test\n"
+				Expect(mockReconciler.Update(ctx, updated)).To(Succeed())
+				_, err = mockReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(mockReconciler.Get(ctx, key, updated)).To(Succeed())
+				Expect(meta.FindStatusCondition(updated.Status.Conditions, secretsv1alpha1.ConditionTypeTooDeeplyNested)).To(BeNil())
+				Expect(meta.IsStatusConditionTrue(updated.Status.Conditions, secretsv1alpha1.ConditionTypeReady)).To(BeTrue())
+			})
+		})
+
+		Describe("Failure backoff", func() {
+			var decryptErr error
+			var sopsSecret *secretsv1alpha1.SopsSecret
+
+			BeforeEach(func() {
+				mockReconciler.FailureBackoffBase = 10 * time.Second
+				mockReconciler.FailureBackoffMax = 30 * time.Second
+				decryptErr = fmt.Errorf("sops decrypt failed: failed to get the data key")
+				mockDecryptor.DecryptFunc = func(_ []byte) (*sops.DecryptedData, error) {
+					if decryptErr != nil {
+						return nil, decryptErr
+					}
+					return &sops.DecryptedData{Data: map[string][]byte{"password": []byte("secret")}}, nil
+				}
+				sopsSecret = &secretsv1alpha1.SopsSecret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:       "backoff",
+						Namespace:  "default",
+						Finalizers: []string{finalizerName},
+					},
+					Spec: secretsv1alpha1.SopsSecretSpec{SopsSecret: "password: ENC[test]\nsops:\n    mac: test\n"},
+				}
+				Expect(mockReconciler.Create(ctx, sopsSecret)).To(Succeed())
+			})
+
+			reconcileOnce := func() (time.Duration, int32) {
+				key := client.ObjectKeyFromObject(sopsSecret)
+				result, err := mockReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+				Expect(err).NotTo(HaveOccurred())
+				updated := &secretsv1alpha1.SopsSecret{}
+				Expect(mockReconciler.Get(ctx, key, updated)).To(Succeed())
+				return result.RequeueAfter, updated.Status.FailureCount
+			}
+
+			It("should back off repeated decrypt failures and reset on success", func() {
+				for _, want := range []time.Duration{10 * time.Second, 20 * time.Second, 30 * time.Second, 30 * time.Second} {
+					after, _ := reconcileOnce()
+					Expect(after).To(Equal(want))
+				}
+				_, failures := reconcileOnce()
+				Expect(failures).To(Equal(int32(5)))
+
+				decryptErr = nil
+				after, failures := reconcileOnce()
+				Expect(after).To(Equal(5 * time.Minute))
+				Expect(failures).To(BeZero())
+			})
+
+			It("should back off while a stale Secret is kept", func() {
+				decryptErr = nil
+				_, failures := reconcileOnce()
+				Expect(failures).To(BeZero())
+
+				decryptErr = fmt.Errorf("sops decrypt failed: failed to get the data key")
+				updated := &secretsv1alpha1.SopsSecret{}
+				Expect(mockReconciler.Get(ctx, client.ObjectKeyFromObject(sopsSecret), updated)).To(Succeed())
+				updated.Generation++
+				updated.Spec.SopsSecret = "password: ENC[rotated]\nsops:\n    mac: test\n"
+				Expect(mockReconciler.Update(ctx, updated)).To(Succeed())
+
+				after, failures := reconcileOnce()
+				Expect(after).To(Equal(10 * time.Second))
+				Expect(failures).To(Equal(int32(1)))
+			})
+		})
+
+		Describe("Rotation schedule", func() {
+			It("should decrypt again when the schedule fires", func() {
+				now := time.Date(2026, 1, 1, 2, 30, 0, 0, time.UTC)
+				mockReconciler.clock = func() time.Time { return now }
+				mockReconciler.ReconcileInterval = time.Hour
+				decrypts := 0
+				mockDecryptor.DecryptFunc = func(_ []byte) (*sops.DecryptedData, error) {
+					decrypts++
+					return &sops.DecryptedData{Data: map[string][]byte{"password": fmt.Appendf(nil, "secret-%d", decrypts)}}, nil
+				}
+
+				sopsSecret := &secretsv1alpha1.SopsSecret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:       "rotation",
+						Namespace:  "default",
+						Finalizers: []string{finalizerName},
+					},
+					Spec: secretsv1alpha1.SopsSecretSpec{
+						SopsSecret:       "password: ENC[test]\nsops:\n    mac: test\n",
+						RotationSchedule: "0 3 * * *",
+					},
+				}
+				Expect(mockReconciler.Create(ctx, sopsSecret)).To(Succeed())
+				key := client.ObjectKeyFromObject(sopsSecret)
+
+				result, err := mockReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(decrypts).To(Equal(1))
+				Expect(result.RequeueAfter).To(Equal(30 * time.Minute))
+
+				By("reconciling before the schedule fires")
+				now = now.Add(20 * time.Minute)
+				result, err = mockReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(decrypts).To(Equal(1))
+				Expect(result.RequeueAfter).To(Equal(10 * time.Minute))
+
+				By("reconciling when the schedule fires")
+				now = now.Add(10 * time.Minute)
+				result, err = mockReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(decrypts).To(Equal(2))
+				Expect(result.RequeueAfter).To(Equal(time.Hour))
+
+				secret := &corev1.Secret{}
+				Expect(mockReconciler.Get(ctx, key, secret)).To(Succeed())
+				Expect(string(secret.Data["password"])).To(Equal("secret-2"))
+				updated := &secretsv1alpha1.SopsSecret{}
+				Expect(mockReconciler.Get(ctx, key, updated)).To(Succeed())
+				Expect(updated.Status.LastDecryptedTime.Time).To(BeTemporally("==", now))
+
+				By("not decrypting again until the next fire time")
+				now = now.Add(time.Minute)
+				_, err = mockReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(decrypts).To(Equal(2))
+			})
+
+			It("should report an invalid schedule without decrypting", func() {
+				mockDecryptor.DecryptFunc = func(_ []byte) (*sops.DecryptedData, error) {
+					Fail("decrypted a SopsSecret with an invalid rotationSchedule")
+					return nil, nil
+				}
+				sopsSecret := &secretsv1alpha1.SopsSecret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:       "rotation-invalid",
+						Namespace:  "default",
+						Finalizers: []string{finalizerName},
+					},
+					Spec: secretsv1alpha1.SopsSecretSpec{
+						SopsSecret:       "password: ENC[test]\nsops:\n    mac: test\n",
+						RotationSchedule: "0 25 * * *",
+					},
+				}
+				Expect(mockReconciler.Create(ctx, sopsSecret)).To(Succeed())
+				key := client.ObjectKeyFromObject(sopsSecret)
+
+				_, err := mockReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+				Expect(err).NotTo(HaveOccurred())
+
+				updated := &secretsv1alpha1.SopsSecret{}
+				Expect(mockReconciler.Get(ctx, key, updated)).To(Succeed())
+				ready := meta.FindStatusCondition(updated.Status.Conditions, secretsv1alpha1.ConditionTypeReady)
+				Expect(ready).NotTo(BeNil())
+				Expect(ready.Status).To(Equal(metav1.ConditionFalse))
+				Expect(ready.Reason).To(Equal(ReasonInvalidSchedule))
+				Expect(ready.Message).To(ContainSubstring("hour"))
+			})
+		})
+
+		Describe("Secret TTL", func() {
+			It("should delete the Secret once the TTL elapses and recreate it after an update", func() {
+				now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+				mockReconciler.clock = func() time.Time { return now }
+				mockDecryptor.DecryptFunc = func(data []byte) (*sops.DecryptedData, error) {
+					return &sops.DecryptedData{Data: map[string][]byte{"password": []byte("password: secret")}}, nil
+				}
+
+				sopsSecret := &secretsv1alpha1.SopsSecret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:       "ttl",
+						Namespace:  "default",
+						Finalizers: []string{finalizerName},
+					},
+					Spec: secretsv1alpha1.SopsSecretSpec{
+						SopsSecret: "password: ENC[test]\nsops:\n    mac: test\n",
+						SecretTTL:  &metav1.Duration{Duration: time.Minute},
+					},
+				}
+				Expect(mockReconciler.Create(ctx, sopsSecret)).To(Succeed())
+				key := client.ObjectKeyFromObject(sopsSecret)
+
+				result, err := mockReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.RequeueAfter).To(Equal(time.Minute))
+				Expect(mockReconciler.Get(ctx, key, &corev1.Secret{})).To(Succeed())
+
+				By("reconciling before the TTL elapsed")
+				now = now.Add(40 * time.Second)
+				result, err = mockReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.RequeueAfter).To(Equal(20 * time.Second))
+				Expect(mockReconciler.Get(ctx, key, &corev1.Secret{})).To(Succeed())
+
+				By("reconciling after the TTL elapsed")
+				now = now.Add(30 * time.Second)
+				_, err = mockReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+				Expect(err).NotTo(HaveOccurred())
+				err = mockReconciler.Get(ctx, key, &corev1.Secret{})
+				Expect(errors.IsNotFound(err)).To(BeTrue())
+
+				updated := &secretsv1alpha1.SopsSecret{}
+				Expect(mockReconciler.Get(ctx, key, updated)).To(Succeed())
+				Expect(meta.IsStatusConditionTrue(updated.Status.Conditions, secretsv1alpha1.ConditionTypeExpired)).To(BeTrue())
+				ready := meta.FindStatusCondition(updated.Status.Conditions, secretsv1alpha1.ConditionTypeReady)
+				Expect(ready.Status).To(Equal(metav1.ConditionFalse))
+				Expect(ready.Reason).To(Equal(ReasonSecretExpired))
+
+				By("staying deleted on later reconciles")
+				_, err = mockReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+				Expect(err).NotTo(HaveOccurred())
+				err = mockReconciler.Get(ctx, key, &corev1.Secret{})
+				Expect(errors.IsNotFound(err)).To(BeTrue())
+
+				By("updating the SopsSecret")
+				updated.Generation++
+				updated.Spec.SopsSecret = "password: ENC[rotated]\nsops:\n    mac: test\n"
+				Expect(mockReconciler.Update(ctx, updated)).To(Succeed())
+				_, err = mockReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(mockReconciler.Get(ctx, key, &corev1.Secret{})).To(Succeed())
+				Expect(mockReconciler.Get(ctx, key, updated)).To(Succeed())
+				Expect(meta.FindStatusCondition(updated.Status.Conditions, secretsv1alpha1.ConditionTypeExpired)).To(BeNil())
+				Expect(meta.IsStatusConditionTrue(updated.Status.Conditions, secretsv1alpha1.ConditionTypeReady)).To(BeTrue())
+			})
+		})
+
+		Describe("Owner reference modes", func() {
+			BeforeEach(func() {
+				mockDecryptor.DecryptFunc = func(data []byte) (*sops.DecryptedData, error) {
+					return &sops.DecryptedData{Data: map[string][]byte{"password": []byte("password: secret")}}, nil
+				}
+			})
+
+			// reconcileWithMode reconciles a new SopsSecret with mode and returns it
+			// and its Secret
+			reconcileWithMode := func(name string, mode secretsv1alpha1.OwnerReferenceMode) (*secretsv1alpha1.SopsSecret, *corev1.Secret) {
+				sopsSecret := &secretsv1alpha1.SopsSecret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:       name,
+						Namespace:  "default",
+						Finalizers: []string{finalizerName},
+					},
+					Spec: secretsv1alpha1.SopsSecretSpec{
+						SopsSecret:         "password: ENC[test]\nsops:\n    mac: test\n",
+						OwnerReferenceMode: mode,
+					},
+				}
+				Expect(mockReconciler.Create(ctx, sopsSecret)).To(Succeed())
+				_, err := mockReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(sopsSecret)})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(mockReconciler.Get(ctx, client.ObjectKeyFromObject(sopsSecret), sopsSecret)).To(Succeed())
+				secret := &corev1.Secret{}
+				Expect(mockReconciler.Get(ctx, client.ObjectKeyFromObject(sopsSecret), secret)).To(Succeed())
+				return sopsSecret, secret
+			}
+
+			// deleteSopsSecret deletes sopsSecret and reconciles the deletion
+			deleteSopsSecret := func(sopsSecret *secretsv1alpha1.SopsSecret) {
+				Expect(mockReconciler.Delete(ctx, sopsSecret)).To(Succeed())
+				_, err := mockReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(sopsSecret)})
+				Expect(err).NotTo(HaveOccurred())
+				err = mockReconciler.Get(ctx, client.ObjectKeyFromObject(sopsSecret), &secretsv1alpha1.SopsSecret{})
+				Expect(errors.IsNotFound(err)).To(BeTrue())
+			}
+
+			It("should set a controller reference and delete the Secret in Controller mode", func() {
+				sopsSecret, secret := reconcileWithMode("mode-controller", secretsv1alpha1.OwnerReferenceController)
+				Expect(metav1.IsControlledBy(secret, sopsSecret)).To(BeTrue())
+
+				deleteSopsSecret(sopsSecret)
+				err := mockReconciler.Get(ctx, client.ObjectKeyFromObject(secret), &corev1.Secret{})
+				Expect(errors.IsNotFound(err)).To(BeTrue())
+			})
+
+			It("should set a non-controller reference and retain the Secret in NonController mode", func() {
+				sopsSecret, secret := reconcileWithMode("mode-non-controller", secretsv1alpha1.OwnerReferenceNonController)
+				Expect(secret.OwnerReferences).To(HaveLen(1))
+				Expect(secret.OwnerReferences[0].UID).To(Equal(sopsSecret.UID))
+				Expect(metav1.GetControllerOf(secret)).To(BeNil())
+
+				By("updating the Secret on a payload change")
+				sopsSecret.Spec.SopsSecret = "password: ENC[rotated]\nsops:\n    mac: test\n"
+				Expect(mockReconciler.Update(ctx, sopsSecret)).To(Succeed())
+				_, err := mockReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(sopsSecret)})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(mockReconciler.Get(ctx, client.ObjectKeyFromObject(secret), secret)).To(Succeed())
+				Expect(secret.OwnerReferences).To(HaveLen(1))
+				Expect(metav1.GetControllerOf(secret)).To(BeNil())
+
+				// Garbage collection would delete the Secret while any owner
+				// reference to the SopsSecret is left
+				deleteSopsSecret(sopsSecret)
+				Expect(mockReconciler.Get(ctx, client.ObjectKeyFromObject(secret), secret)).To(Succeed())
+				Expect(secret.OwnerReferences).To(BeEmpty())
+			})
+
+			It("should set no owner reference and retain the Secret in None mode", func() {
+				sopsSecret, secret := reconcileWithMode("mode-none", secretsv1alpha1.OwnerReferenceNone)
+				Expect(secret.OwnerReferences).To(BeEmpty())
+				Expect(secret.Labels).To(HaveKeyWithValue(sopsSecretLabel, sopsSecret.Name))
+
+				deleteSopsSecret(sopsSecret)
+				Expect(mockReconciler.Get(ctx, client.ObjectKeyFromObject(secret), secret)).To(Succeed())
+				Expect(secret.OwnerReferences).To(BeEmpty())
+			})
+
+			It("should replace the owner reference when the mode changes", func() {
+				sopsSecret, secret := reconcileWithMode("mode-switch", secretsv1alpha1.OwnerReferenceController)
+				Expect(metav1.IsControlledBy(secret, sopsSecret)).To(BeTrue())
+
+				sopsSecret.Generation++
+				sopsSecret.Spec.OwnerReferenceMode = secretsv1alpha1.OwnerReferenceNonController
+				Expect(mockReconciler.Update(ctx, sopsSecret)).To(Succeed())
+				_, err := mockReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(sopsSecret)})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(mockReconciler.Get(ctx, client.ObjectKeyFromObject(secret), secret)).To(Succeed())
+				Expect(secret.OwnerReferences).To(HaveLen(1))
+				Expect(metav1.GetControllerOf(secret)).To(BeNil())
+			})
+		})
+
+		Describe("Published key list", func() {
+			BeforeEach(func() {
+				mockDecryptor.DecryptFunc = func(data []byte) (*sops.DecryptedData, error) {
+					return &sops.DecryptedData{Data: map[string][]byte{
+						"username": []byte("username: admin"),
+						"api-key":  []byte("api-key: s3cr3t-value"),
+						"password": []byte("password: hunter2"),
+					}}, nil
+				}
+			})
+
+			It("should publish the sorted key names without values and clean up", func() {
+				sopsSecret := &secretsv1alpha1.SopsSecret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:       "key-list",
+						Namespace:  "default",
+						Finalizers: []string{finalizerName},
+					},
+					Spec: secretsv1alpha1.SopsSecretSpec{
+						SopsSecret:     "username: ENC[test]\napi-key: ENC[test]\npassword: ENC[test]\nsops:\n    mac: test\n",
+						SecretName:     "app",
+						PublishKeyList: true,
+					},
+				}
+				Expect(mockReconciler.Create(ctx, sopsSecret)).To(Succeed())
+				request := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(sopsSecret)}
+				_, err := mockReconciler.Reconcile(ctx, request)
+				Expect(err).NotTo(HaveOccurred())
+
+				secret := &corev1.Secret{}
+				Expect(mockReconciler.Get(ctx, types.NamespacedName{Namespace: "default", Name: "app"}, secret)).To(Succeed())
+				keyList := &corev1.ConfigMap{}
+				keyListName := types.NamespacedName{Namespace: "default", Name: "app-keys"}
+				Expect(mockReconciler.Get(ctx, keyListName, keyList)).To(Succeed())
+				Expect(keyList.Data).To(Equal(map[string]string{keyListKey: "api-key\npassword\nusername"}))
+				Expect(strings.Split(keyList.Data[keyListKey], "\n")).To(ConsistOf(sortedKeys(secret.Data)))
+				Expect(keyList.BinaryData).To(BeEmpty())
+				for _, value := range []string{"admin", "s3cr3t-value", "hunter2"} {
+					Expect(keyList.Data[keyListKey]).NotTo(ContainSubstring(value))
+				}
+				Expect(metav1.IsControlledBy(keyList, sopsSecret)).To(BeTrue())
+
+				By("turning publishKeyList off")
+				Expect(mockReconciler.Get(ctx, request.NamespacedName, sopsSecret)).To(Succeed())
+				sopsSecret.Generation++
+				sopsSecret.Spec.PublishKeyList = false
+				Expect(mockReconciler.Update(ctx, sopsSecret)).To(Succeed())
+				_, err = mockReconciler.Reconcile(ctx, request)
+				Expect(err).NotTo(HaveOccurred())
+				err = mockReconciler.Get(ctx, keyListName, &corev1.ConfigMap{})
+				Expect(errors.IsNotFound(err)).To(BeTrue())
+			})
+
+			It("should delete the key list when the SopsSecret is deleted", func() {
+				sopsSecret := &secretsv1alpha1.SopsSecret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:       "key-list-deleted",
+						Namespace:  "default",
+						Finalizers: []string{finalizerName},
+					},
+					Spec: secretsv1alpha1.SopsSecretSpec{
+						SopsSecret:     "username: ENC[test]\nsops:\n    mac: test\n",
+						PublishKeyList: true,
+					},
+				}
+				Expect(mockReconciler.Create(ctx, sopsSecret)).To(Succeed())
+				request := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(sopsSecret)}
+				_, err := mockReconciler.Reconcile(ctx, request)
+				Expect(err).NotTo(HaveOccurred())
+				keyListName := types.NamespacedName{Namespace: "default", Name: "key-list-deleted-keys"}
+				Expect(mockReconciler.Get(ctx, keyListName, &corev1.ConfigMap{})).To(Succeed())
+
+				Expect(mockReconciler.Delete(ctx, sopsSecret)).To(Succeed())
+				_, err = mockReconciler.Reconcile(ctx, request)
+				Expect(err).NotTo(HaveOccurred())
+				err = mockReconciler.Get(ctx, keyListName, &corev1.ConfigMap{})
+				Expect(errors.IsNotFound(err)).To(BeTrue())
+			})
+		})
+
+		Describe("Recreate annotation", func() {
+			var recorder *events.FakeRecorder
+
+			BeforeEach(func() {
+				recorder = events.NewFakeRecorder(20)
+				mockReconciler.Recorder = recorder
+				mockDecryptor.DecryptFunc = func(data []byte) (*sops.DecryptedData, error) {
+					return &sops.DecryptedData{Data: map[string][]byte{"password": []byte("password: secret")}}, nil
+				}
+			})
+
+			recreatedEvents := func() int {
+				count := 0
+				for len(recorder.Events) > 0 {
+					if strings.Contains(<-recorder.Events, ReasonSecretRecreated) {
+						count++
+					}
+				}
+				return count
+			}
+
+			It("should recreate the Secret once per annotation", func() {
+				sopsSecret := &secretsv1alpha1.SopsSecret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:       "recreate",
+						Namespace:  "default",
+						Finalizers: []string{finalizerName},
+					},
+					Spec: secretsv1alpha1.SopsSecretSpec{
+						SopsSecret: "password: ENC[test]\nsops:\n    mac: test\n",
+					},
+				}
+				Expect(mockReconciler.Create(ctx, sopsSecret)).To(Succeed())
+				key := client.ObjectKeyFromObject(sopsSecret)
+				_, err := mockReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(recreatedEvents()).To(BeZero())
+
+				// corrupt marks the Secret with a label and data a regular update keeps
+				corrupt := func() {
+					secret := &corev1.Secret{}
+					Expect(mockReconciler.Get(ctx, key, secret)).To(Succeed())
+					secret.Labels["corrupted"] = "true"
+					secret.Data["stray"] = []byte("garbage")
+					Expect(mockReconciler.Update(ctx, secret)).To(Succeed())
+				}
+				setAnnotation := func(value string) {
+					Expect(mockReconciler.Get(ctx, key, sopsSecret)).To(Succeed())
+					if value == "" {
+						delete(sopsSecret.Annotations, recreateAnnotation)
+					} else {
+						metav1.SetMetaDataAnnotation(&sopsSecret.ObjectMeta, recreateAnnotation, value)
+					}
+					Expect(mockReconciler.Update(ctx, sopsSecret)).To(Succeed())
+					_, err := mockReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+					Expect(err).NotTo(HaveOccurred())
+					Expect(mockReconciler.Get(ctx, key, sopsSecret)).To(Succeed())
+				}
+
+				By("recreating the corrupted Secret")
+				corrupt()
+				setAnnotation("true")
+				secret := &corev1.Secret{}
+				Expect(mockReconciler.Get(ctx, key, secret)).To(Succeed())
+				Expect(secret.Labels).NotTo(HaveKey("corrupted"))
+				Expect(secret.Data).To(Equal(map[string][]byte{"password": []byte("password: secret")}))
+				Expect(metav1.IsControlledBy(secret, sopsSecret)).To(BeTrue())
+				Expect(sopsSecret.Status.RecreateAcknowledged).To(BeTrue())
+				Expect(recreatedEvents()).To(Equal(1))
+
+				By("not recreating again while the annotation is acknowledged")
+				corrupt()
+				_, err = mockReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(mockReconciler.Get(ctx, key, secret)).To(Succeed())
+				Expect(secret.Labels).To(HaveKey("corrupted"))
+				Expect(recreatedEvents()).To(BeZero())
+
+				By("clearing the acknowledgment once the annotation is removed")
+				setAnnotation("")
+				Expect(sopsSecret.Status.RecreateAcknowledged).To(BeFalse())
+				Expect(recreatedEvents()).To(BeZero())
+
+				By("recreating again when the annotation is set again")
+				setAnnotation("true")
+				Expect(mockReconciler.Get(ctx, key, secret)).To(Succeed())
+				Expect(secret.Labels).NotTo(HaveKey("corrupted"))
+				Expect(recreatedEvents()).To(Equal(1))
+			})
+		})
+
+		Describe("Reconcile triggers", func() {
+			triggerCount := func(reason string) float64 {
+				return testutil.ToFloat64(reconcileTriggers.WithLabelValues("default", reason))
+			}
+
+			It("should count reconciles by their inferred trigger", func() {
+				now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+				mockReconciler.clock = func() time.Time { return now }
+				mockDecryptor.DecryptFunc = func(data []byte) (*sops.DecryptedData, error) {
+					return &sops.DecryptedData{Data: map[string][]byte{"password": []byte("password: secret")}}, nil
+				}
+
+				sopsSecret := &secretsv1alpha1.SopsSecret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:       "triggers",
+						Namespace:  "default",
+						Finalizers: []string{finalizerName},
+					},
+					Spec: secretsv1alpha1.SopsSecretSpec{
+						SopsSecret: "password: ENC[test]\nsops:\n    mac: test\n",
+					},
+				}
+				Expect(mockReconciler.Create(ctx, sopsSecret)).To(Succeed())
+				request := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(sopsSecret)}
+
+				expectTrigger := func(reason string) ctrl.Result {
+					before := triggerCount(reason)
+					result, _ := mockReconciler.Reconcile(ctx, request)
+					Expect(triggerCount(reason) - before).To(Equal(1.0))
+					return result
+				}
+
+				By("reconciling for the first time")
+				result := expectTrigger(triggerInitial)
+				Expect(result.RequeueAfter).To(BeNumerically(">", 0))
+
+				By("reconciling once the requeue is due")
+				now = now.Add(result.RequeueAfter)
+				expectTrigger(triggerPeriodic)
+
+				By("reconciling on a watch event while no requeue is due")
+				now = now.Add(time.Second)
+				expectTrigger(triggerEvent)
+
+				By("retrying after a failed reconcile")
+				working := mockReconciler.Client
+				mockReconciler.Client = &ErrorClient{Client: working, GetError: fmt.Errorf("api server unavailable")}
+				expectTrigger(triggerEvent)
+				mockReconciler.Client = working
+				expectTrigger(triggerBackoff)
+			})
+		})
+
+		Describe("Reflection into other namespaces", func() {
+			BeforeEach(func() {
+				mockDecryptor.DecryptFunc = func(data []byte) (*sops.DecryptedData, error) {
+					return &sops.DecryptedData{Data: map[string][]byte{".dockerconfigjson": []byte(`{"auths":{}}`)}}, nil
+				}
+				mockReconciler.AllowCrossNamespace = true
+				for _, ns := range []string{"team-a", "team-b", "team-c"} {
+					Expect(mockReconciler.Create(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+						Name:        ns,
+						Annotations: map[string]string{acceptSecretsFromAnnotation: "other, default"},
+					}})).To(Succeed())
+				}
+			})
+
+			It("should refuse namespaces that do not accept the Secret", func() {
+				Expect(mockReconciler.Create(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "kube-system"}})).To(Succeed())
+				sopsSecret := &secretsv1alpha1.SopsSecret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:       "escalate",
+						Namespace:  "default",
+						Finalizers: []string{finalizerName},
+					},
+					Spec: secretsv1alpha1.SopsSecretSpec{
+						SopsSecret:          ".dockerconfigjson: ENC[test]\nsops:\n    mac: test\n",
+						ReflectToNamespaces: []string{"kube-system", "missing", "team-a"},
+					},
+				}
+				Expect(mockReconciler.Create(ctx, sopsSecret)).To(Succeed())
+				request := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(sopsSecret)}
+				_, err := mockReconciler.Reconcile(ctx, request)
+				Expect(err).NotTo(HaveOccurred())
+
+				for _, ns := range []string{"kube-system", "missing"} {
+					err = mockReconciler.Get(ctx, types.NamespacedName{Namespace: ns, Name: "escalate"}, &corev1.Secret{})
+					Expect(errors.IsNotFound(err)).To(BeTrue(), ns)
+				}
+				Expect(mockReconciler.Get(ctx, types.NamespacedName{Namespace: "team-a", Name: "escalate"}, &corev1.Secret{})).To(Succeed())
+				Expect(mockReconciler.Get(ctx, request.NamespacedName, sopsSecret)).To(Succeed())
+				failed := meta.FindStatusCondition(sopsSecret.Status.Conditions, secretsv1alpha1.ConditionTypeReflectionFailed)
+				Expect(failed).NotTo(BeNil())
+				Expect(failed.Message).To(ContainSubstring("namespace kube-system does not accept Secrets from namespace default"))
+				Expect(failed.Message).To(ContainSubstring("namespace missing does not exist"))
+			})
+
+			It("should refuse every namespace unless the operator allows it", func() {
+				mockReconciler.AllowCrossNamespace = false
+				sopsSecret := &secretsv1alpha1.SopsSecret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:       "disabled",
+						Namespace:  "default",
+						Finalizers: []string{finalizerName},
+					},
+					Spec: secretsv1alpha1.SopsSecretSpec{
+						SopsSecret:          ".dockerconfigjson: ENC[test]\nsops:\n    mac: test\n",
+						ReflectToNamespaces: []string{"team-a"},
+					},
+				}
+				Expect(mockReconciler.Create(ctx, sopsSecret)).To(Succeed())
+				request := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(sopsSecret)}
+				_, err := mockReconciler.Reconcile(ctx, request)
+				Expect(err).NotTo(HaveOccurred())
+
+				err = mockReconciler.Get(ctx, types.NamespacedName{Namespace: "team-a", Name: "disabled"}, &corev1.Secret{})
+				Expect(errors.IsNotFound(err)).To(BeTrue())
+				Expect(mockReconciler.Get(ctx, request.NamespacedName, &corev1.Secret{})).To(Succeed())
+				Expect(mockReconciler.Get(ctx, request.NamespacedName, sopsSecret)).To(Succeed())
+				failed := meta.FindStatusCondition(sopsSecret.Status.Conditions, secretsv1alpha1.ConditionTypeReflectionFailed)
+				Expect(failed).NotTo(BeNil())
+				Expect(failed.Message).To(ContainSubstring("disabled in the operator"))
+			})
+
+			It("should reflect the Secret into the listed namespaces and remove dropped copies", func() {
+				sopsSecret := &secretsv1alpha1.SopsSecret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:       "pull-secret",
+						Namespace:  "default",
+						Finalizers: []string{finalizerName},
+					},
+					Spec: secretsv1alpha1.SopsSecretSpec{
+						SopsSecret:          ".dockerconfigjson: ENC[test]\nsops:\n    mac: test\n",
+						SecretType:          corev1.SecretTypeDockerConfigJson,
+						ReflectToNamespaces: []string{"team-a", "team-b", "default"},
+					},
+				}
+				Expect(mockReconciler.Create(ctx, sopsSecret)).To(Succeed())
+				request := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(sopsSecret)}
+				_, err := mockReconciler.Reconcile(ctx, request)
+				Expect(err).NotTo(HaveOccurred())
+
+				source := &corev1.Secret{}
+				Expect(mockReconciler.Get(ctx, request.NamespacedName, source)).To(Succeed())
+				for _, ns := range []string{"team-a", "team-b"} {
+					reflected := &corev1.Secret{}
+					Expect(mockReconciler.Get(ctx, types.NamespacedName{Namespace: ns, Name: "pull-secret"}, reflected)).To(Succeed())
+					Expect(reflected.Type).To(Equal(corev1.SecretTypeDockerConfigJson))
+					Expect(reflected.Data).To(Equal(source.Data))
+					Expect(reflected.OwnerReferences).To(BeEmpty())
+					Expect(reflected.Labels).To(HaveKeyWithValue(reflectedFromNameLabel, "pull-secret"))
+					Expect(reflected.Labels).To(HaveKeyWithValue(reflectedFromNamespaceLabel, "default"))
+					Expect(reflected.Labels).NotTo(HaveKey(sopsSecretLabel))
+				}
+
+				By("repairing a copy that was changed")
+				tampered := &corev1.Secret{}
+				Expect(mockReconciler.Get(ctx, types.NamespacedName{Namespace: "team-a", Name: "pull-secret"}, tampered)).To(Succeed())
+				tampered.Data = map[string][]byte{".dockerconfigjson": []byte("{}")}
+				Expect(mockReconciler.Update(ctx, tampered)).To(Succeed())
+				_, err = mockReconciler.Reconcile(ctx, request)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(mockReconciler.Get(ctx, client.ObjectKeyFromObject(tampered), tampered)).To(Succeed())
+				Expect(tampered.Data).To(Equal(source.Data))
+
+				By("removing a namespace from the list")
+				Expect(mockReconciler.Get(ctx, request.NamespacedName, sopsSecret)).To(Succeed())
+				sopsSecret.Generation++
+				sopsSecret.Spec.ReflectToNamespaces = []string{"team-a"}
+				Expect(mockReconciler.Update(ctx, sopsSecret)).To(Succeed())
+				_, err = mockReconciler.Reconcile(ctx, request)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(mockReconciler.Get(ctx, types.NamespacedName{Namespace: "team-a", Name: "pull-secret"}, &corev1.Secret{})).To(Succeed())
+				err = mockReconciler.Get(ctx, types.NamespacedName{Namespace: "team-b", Name: "pull-secret"}, &corev1.Secret{})
+				Expect(errors.IsNotFound(err)).To(BeTrue())
+
+				By("deleting the SopsSecret")
+				Expect(mockReconciler.Delete(ctx, sopsSecret)).To(Succeed())
+				_, err = mockReconciler.Reconcile(ctx, request)
+				Expect(err).NotTo(HaveOccurred())
+				err = mockReconciler.Get(ctx, types.NamespacedName{Namespace: "team-a", Name: "pull-secret"}, &corev1.Secret{})
+				Expect(errors.IsNotFound(err)).To(BeTrue())
+			})
+
+			It("should not overwrite a Secret that is not a copy", func() {
+				Expect(mockReconciler.Create(ctx, &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: "foreign", Namespace: "team-c"},
+					Data:       map[string][]byte{"own": []byte("data")},
+				})).To(Succeed())
+				sopsSecret := &secretsv1alpha1.SopsSecret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:       "foreign",
+						Namespace:  "default",
+						Finalizers: []string{finalizerName},
+					},
+					Spec: secretsv1alpha1.SopsSecretSpec{
+						SopsSecret:          ".dockerconfigjson: ENC[test]\nsops:\n    mac: test\n",
+						ReflectToNamespaces: []string{"team-c"},
+					},
+				}
+				Expect(mockReconciler.Create(ctx, sopsSecret)).To(Succeed())
+				request := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(sopsSecret)}
+				_, err := mockReconciler.Reconcile(ctx, request)
+				Expect(err).NotTo(HaveOccurred())
+
+				foreign := &corev1.Secret{}
+				Expect(mockReconciler.Get(ctx, types.NamespacedName{Namespace: "team-c", Name: "foreign"}, foreign)).To(Succeed())
+				Expect(foreign.Data).To(Equal(map[string][]byte{"own": []byte("data")}))
+				Expect(mockReconciler.Get(ctx, request.NamespacedName, sopsSecret)).To(Succeed())
+				failed := meta.FindStatusCondition(sopsSecret.Status.Conditions, secretsv1alpha1.ConditionTypeReflectionFailed)
+				Expect(failed).NotTo(BeNil())
+				Expect(failed.Message).To(ContainSubstring("not reflected from this SopsSecret"))
+			})
+
+			It("should reflect into the other namespaces when one fails and retry it", func() {
+				mockReconciler.FailureBackoffBase = 10 * time.Second
+				failTeamB := true
+				mockReconciler.Client = interceptor.NewClient(mockReconciler.Client.(client.WithWatch), interceptor.Funcs{
+					Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+						if failTeamB && obj.GetNamespace() == "team-b" {
+							return errors.NewForbidden(corev1.Resource("secrets"), obj.GetName(), fmt.Errorf("quota exceeded"))
+						}
+						return c.Create(ctx, obj, opts...)
+					},
+				})
+				sopsSecret := &secretsv1alpha1.SopsSecret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:       "partial",
+						Namespace:  "default",
+						Finalizers: []string{finalizerName},
+					},
+					Spec: secretsv1alpha1.SopsSecretSpec{
+						SopsSecret:          ".dockerconfigjson: ENC[test]\nsops:\n    mac: test\n",
+						ReflectToNamespaces: []string{"team-a", "team-b", "team-c"},
+					},
+				}
+				Expect(mockReconciler.Create(ctx, sopsSecret)).To(Succeed())
+				request := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(sopsSecret)}
+				result, err := mockReconciler.Reconcile(ctx, request)
+				Expect(err).NotTo(HaveOccurred())
+
+				for _, ns := range []string{"team-a", "team-c"} {
+					Expect(mockReconciler.Get(ctx, types.NamespacedName{Namespace: ns, Name: "partial"}, &corev1.Secret{})).To(Succeed())
+				}
+				err = mockReconciler.Get(ctx, types.NamespacedName{Namespace: "team-b", Name: "partial"}, &corev1.Secret{})
+				Expect(errors.IsNotFound(err)).To(BeTrue())
+				Expect(mockReconciler.Get(ctx, request.NamespacedName, sopsSecret)).To(Succeed())
+				failed := meta.FindStatusCondition(sopsSecret.Status.Conditions, secretsv1alpha1.ConditionTypeReflectionFailed)
+				Expect(failed).NotTo(BeNil())
+				Expect(failed.Status).To(Equal(metav1.ConditionTrue))
+				Expect(failed.Message).To(ContainSubstring("namespace team-b"))
+				Expect(failed.Message).NotTo(ContainSubstring("team-a"))
+				ready := meta.FindStatusCondition(sopsSecret.Status.Conditions, secretsv1alpha1.ConditionTypeReady)
+				Expect(ready.Status).To(Equal(metav1.ConditionFalse))
+				Expect(ready.Reason).To(Equal(ReasonReflectionFailed))
+				Expect(sopsSecret.Status.SecretName).To(Equal("partial"))
+				Expect(sopsSecret.Status.FailureCount).To(Equal(int32(1)))
+				Expect(result.RequeueAfter).To(Equal(10 * time.Second))
+
+				By("retrying once the namespace accepts the copy")
+				failTeamB = false
+				_, err = mockReconciler.Reconcile(ctx, request)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(mockReconciler.Get(ctx, types.NamespacedName{Namespace: "team-b", Name: "partial"}, &corev1.Secret{})).To(Succeed())
+				Expect(mockReconciler.Get(ctx, request.NamespacedName, sopsSecret)).To(Succeed())
+				Expect(meta.FindStatusCondition(sopsSecret.Status.Conditions, secretsv1alpha1.ConditionTypeReflectionFailed)).To(BeNil())
+				Expect(meta.IsStatusConditionTrue(sopsSecret.Status.Conditions, secretsv1alpha1.ConditionTypeReady)).To(BeTrue())
+			})
+
+			It("should reflect into a namespace added to the list", func() {
+				sopsSecret := &secretsv1alpha1.SopsSecret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:       "growing",
+						Namespace:  "default",
+						Finalizers: []string{finalizerName},
+					},
+					Spec: secretsv1alpha1.SopsSecretSpec{
+						SopsSecret:          ".dockerconfigjson: ENC[test]\nsops:\n    mac: test\n",
+						ReflectToNamespaces: []string{"team-a"},
+					},
+				}
+				Expect(mockReconciler.Create(ctx, sopsSecret)).To(Succeed())
+				request := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(sopsSecret)}
+				_, err := mockReconciler.Reconcile(ctx, request)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(mockReconciler.Get(ctx, request.NamespacedName, sopsSecret)).To(Succeed())
+				sopsSecret.Generation++
+				sopsSecret.Spec.ReflectToNamespaces = []string{"team-a", "team-b"}
+				Expect(mockReconciler.Update(ctx, sopsSecret)).To(Succeed())
+				_, err = mockReconciler.Reconcile(ctx, request)
+				Expect(err).NotTo(HaveOccurred())
+				for _, ns := range []string{"team-a", "team-b"} {
+					reflected := &corev1.Secret{}
+					Expect(mockReconciler.Get(ctx, types.NamespacedName{Namespace: ns, Name: "growing"}, reflected)).To(Succeed())
+					Expect(reflected.Labels).To(HaveKeyWithValue(reflectedFromNameLabel, "growing"))
+				}
+			})
+		})
+
+		Describe("Target namespace", func() {
+			password := "first"
+
+			BeforeEach(func() {
+				password = "first"
+				mockDecryptor.DecryptFunc = func(_ []byte) (*sops.DecryptedData, error) {
+					return &sops.DecryptedData{Data: map[string][]byte{"password": []byte(password)}}, nil
+				}
+				mockReconciler.AllowCrossNamespace = true
+				Expect(mockReconciler.Create(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+					Name:        "payments",
+					Annotations: map[string]string{acceptSecretsFromAnnotation: "default"},
+				}})).To(Succeed())
+			})
+
+			newSopsSecret := func(name string) *secretsv1alpha1.SopsSecret {
+				return &secretsv1alpha1.SopsSecret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:       name,
+						Namespace:  "default",
+						Finalizers: []string{finalizerName},
+					},
+					Spec: secretsv1alpha1.SopsSecretSpec{
+						SopsSecret:      "password: ENC[v1]\nsops:\n    mac: test\n",
+						TargetNamespace: "payments",
+					},
+				}
+			}
+
+			It("should create, update and delete the Secret in the target namespace", func() {
+				sopsSecret := newSopsSecret("cross")
+				Expect(mockReconciler.Create(ctx, sopsSecret)).To(Succeed())
+				request := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(sopsSecret)}
+				_, err := mockReconciler.Reconcile(ctx, request)
+				Expect(err).NotTo(HaveOccurred())
+
+				err = mockReconciler.Get(ctx, request.NamespacedName, &corev1.Secret{})
+				Expect(errors.IsNotFound(err)).To(BeTrue())
+				key := types.NamespacedName{Namespace: "payments", Name: "cross"}
+				secret := &corev1.Secret{}
+				Expect(mockReconciler.Get(ctx, key, secret)).To(Succeed())
+				Expect(secret.Data).To(HaveKeyWithValue("password", []byte("first")))
+				Expect(secret.OwnerReferences).To(BeEmpty())
+				Expect(secret.Labels).To(HaveKeyWithValue(sopsSecretLabel, "cross"))
+				Expect(secret.Labels).To(HaveKeyWithValue(sopsSecretNamespaceLabel, "default"))
+				Expect(sopsSecretOfTargetSecret(ctx, secret)).To(ConsistOf(request))
+
+				By("updating the Secret and keeping labels set by others")
+				secret.Labels["team"] = "payments"
+				Expect(mockReconciler.Update(ctx, secret)).To(Succeed())
+				password = "second"
+				Expect(mockReconciler.Get(ctx, request.NamespacedName, sopsSecret)).To(Succeed())
+				sopsSecret.Generation++
+				sopsSecret.Spec.SopsSecret = "password: ENC[v2]\nsops:\n    mac: test\n"
+				Expect(mockReconciler.Update(ctx, sopsSecret)).To(Succeed())
+				_, err = mockReconciler.Reconcile(ctx, request)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(mockReconciler.Get(ctx, key, secret)).To(Succeed())
+				Expect(secret.Data).To(HaveKeyWithValue("password", []byte("second")))
+				Expect(secret.Labels).To(HaveKeyWithValue("team", "payments"))
+				Expect(secret.OwnerReferences).To(BeEmpty())
+
+				By("deleting the SopsSecret")
+				Expect(mockReconciler.Delete(ctx, sopsSecret)).To(Succeed())
+				_, err = mockReconciler.Reconcile(ctx, request)
+				Expect(err).NotTo(HaveOccurred())
+				err = mockReconciler.Get(ctx, key, &corev1.Secret{})
+				Expect(errors.IsNotFound(err)).To(BeTrue())
+			})
+
+			It("should retain the Secret on deletion without a controller reference", func() {
+				sopsSecret := newSopsSecret("retained")
+				sopsSecret.Spec.OwnerReferenceMode = secretsv1alpha1.OwnerReferenceNone
+				Expect(mockReconciler.Create(ctx, sopsSecret)).To(Succeed())
+				request := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(sopsSecret)}
+				_, err := mockReconciler.Reconcile(ctx, request)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(mockReconciler.Delete(ctx, sopsSecret)).To(Succeed())
+				_, err = mockReconciler.Reconcile(ctx, request)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(mockReconciler.Get(ctx, types.NamespacedName{Namespace: "payments", Name: "retained"}, &corev1.Secret{})).To(Succeed())
+			})
+
+			It("should not overwrite a Secret it does not manage", func() {
+				Expect(mockReconciler.Create(ctx, &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: "taken", Namespace: "payments"},
+					Data:       map[string][]byte{"own": []byte("data")},
+				})).To(Succeed())
+				sopsSecret := newSopsSecret("taken")
+				Expect(mockReconciler.Create(ctx, sopsSecret)).To(Succeed())
+				request := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(sopsSecret)}
+				_, err := mockReconciler.Reconcile(ctx, request)
+				Expect(err).NotTo(HaveOccurred())
+
+				foreign := &corev1.Secret{}
+				Expect(mockReconciler.Get(ctx, types.NamespacedName{Namespace: "payments", Name: "taken"}, foreign)).To(Succeed())
+				Expect(foreign.Data).To(Equal(map[string][]byte{"own": []byte("data")}))
+				Expect(mockReconciler.Get(ctx, request.NamespacedName, sopsSecret)).To(Succeed())
+				ready := meta.FindStatusCondition(sopsSecret.Status.Conditions, secretsv1alpha1.ConditionTypeReady)
+				Expect(ready).NotTo(BeNil())
+				Expect(ready.Status).To(Equal(metav1.ConditionFalse))
+				Expect(ready.Reason).To(Equal(ReasonSecretNotManaged))
+
+				By("leaving the Secret alone on deletion")
+				Expect(mockReconciler.Delete(ctx, sopsSecret)).To(Succeed())
+				_, err = mockReconciler.Reconcile(ctx, request)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(mockReconciler.Get(ctx, client.ObjectKeyFromObject(foreign), &corev1.Secret{})).To(Succeed())
+			})
+
+			It("should refuse a target namespace that does not accept the Secret", func() {
+				Expect(mockReconciler.Create(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "kube-system"}})).To(Succeed())
+				sopsSecret := newSopsSecret("escalate")
+				sopsSecret.Spec.TargetNamespace = "kube-system"
+				Expect(mockReconciler.Create(ctx, sopsSecret)).To(Succeed())
+				request := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(sopsSecret)}
+				_, err := mockReconciler.Reconcile(ctx, request)
+				Expect(err).NotTo(HaveOccurred())
+
+				key := types.NamespacedName{Namespace: "kube-system", Name: "escalate"}
+				err = mockReconciler.Get(ctx, key, &corev1.Secret{})
+				Expect(errors.IsNotFound(err)).To(BeTrue())
+				Expect(mockReconciler.Get(ctx, request.NamespacedName, sopsSecret)).To(Succeed())
+				refused := meta.FindStatusCondition(sopsSecret.Status.Conditions, secretsv1alpha1.ConditionTypeTargetNamespaceRefused)
+				Expect(refused).NotTo(BeNil())
+				Expect(refused.Message).To(ContainSubstring("does not accept Secrets from namespace default"))
+				ready := meta.FindStatusCondition(sopsSecret.Status.Conditions, secretsv1alpha1.ConditionTypeReady)
+				Expect(ready.Status).To(Equal(metav1.ConditionFalse))
+				Expect(ready.Reason).To(Equal(ReasonTargetRefused))
+
+				By("writing the Secret once the namespace accepts it")
+				namespace := &corev1.Namespace{}
+				Expect(mockReconciler.Get(ctx, client.ObjectKey{Name: "kube-system"}, namespace)).To(Succeed())
+				namespace.Annotations = map[string]string{acceptSecretsFromAnnotation: "*"}
+				Expect(mockReconciler.Update(ctx, namespace)).To(Succeed())
+				_, err = mockReconciler.Reconcile(ctx, request)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(mockReconciler.Get(ctx, key, &corev1.Secret{})).To(Succeed())
+				Expect(mockReconciler.Get(ctx, request.NamespacedName, sopsSecret)).To(Succeed())
+				Expect(meta.FindStatusCondition(sopsSecret.Status.Conditions, secretsv1alpha1.ConditionTypeTargetNamespaceRefused)).To(BeNil())
+				Expect(meta.IsStatusConditionTrue(sopsSecret.Status.Conditions, secretsv1alpha1.ConditionTypeReady)).To(BeTrue())
+			})
+
+			It("should refuse every target namespace unless the operator allows it", func() {
+				mockReconciler.AllowCrossNamespace = false
+				sopsSecret := newSopsSecret("disabled")
+				Expect(mockReconciler.Create(ctx, sopsSecret)).To(Succeed())
+				request := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(sopsSecret)}
+				_, err := mockReconciler.Reconcile(ctx, request)
+				Expect(err).NotTo(HaveOccurred())
+
+				err = mockReconciler.Get(ctx, types.NamespacedName{Namespace: "payments", Name: "disabled"}, &corev1.Secret{})
+				Expect(errors.IsNotFound(err)).To(BeTrue())
+				Expect(mockReconciler.Get(ctx, request.NamespacedName, sopsSecret)).To(Succeed())
+				Expect(meta.IsStatusConditionTrue(sopsSecret.Status.Conditions,
+					secretsv1alpha1.ConditionTypeTargetNamespaceRefused)).To(BeTrue())
+			})
+
+			It("should ignore Secrets in the namespace of the SopsSecret", func() {
+				secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{
+					Name:      "local",
+					Namespace: "default",
+					Labels:    map[string]string{sopsSecretLabel: "local", sopsSecretNamespaceLabel: "default"},
+				}}
+				Expect(sopsSecretOfTargetSecret(ctx, secret)).To(BeEmpty())
+				secret.Labels = map[string]string{sopsSecretLabel: "local"}
+				secret.Namespace = "payments"
+				Expect(sopsSecretOfTargetSecret(ctx, secret)).To(BeEmpty())
+			})
+		})
+
+		Describe("Secret aliases", func() {
+			password := "first"
+
+			BeforeEach(func() {
+				password = "first"
+				mockDecryptor.DecryptFunc = func(_ []byte) (*sops.DecryptedData, error) {
+					return &sops.DecryptedData{Data: map[string][]byte{"password": []byte(password)}}, nil
+				}
+			})
+
+			getAlias := func(name string) (*corev1.Secret, error) {
+				alias := &corev1.Secret{}
+				err := mockReconciler.Get(ctx, types.NamespacedName{Namespace: "default", Name: name}, alias)
+				return alias, err
+			}
+
+			It("should create, update and remove aliases", func() {
+				sopsSecret := &secretsv1alpha1.SopsSecret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:       "aliased",
+						Namespace:  "default",
+						Finalizers: []string{finalizerName},
+					},
+					Spec: secretsv1alpha1.SopsSecretSpec{
+						SopsSecret: "password: ENC[v1]\nsops:\n    mac: test\n",
+						Aliases:    []string{"legacy-name", "other-name", "aliased"},
+					},
+				}
+				Expect(mockReconciler.Create(ctx, sopsSecret)).To(Succeed())
+				request := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(sopsSecret)}
+				_, err := mockReconciler.Reconcile(ctx, request)
+				Expect(err).NotTo(HaveOccurred())
+
+				source := &corev1.Secret{}
+				Expect(mockReconciler.Get(ctx, request.NamespacedName, source)).To(Succeed())
+				Expect(mockReconciler.Get(ctx, request.NamespacedName, sopsSecret)).To(Succeed())
+				for _, name := range []string{"legacy-name", "other-name"} {
+					alias, err := getAlias(name)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(alias.Data).To(Equal(source.Data))
+					Expect(alias.Labels).To(HaveKeyWithValue(aliasOfLabel, "aliased"))
+					Expect(alias.Labels).NotTo(HaveKey(sopsSecretLabel))
+					Expect(metav1.GetControllerOf(alias)).NotTo(BeNil())
+					Expect(metav1.GetControllerOf(alias).Name).To(Equal("aliased"))
+				}
+
+				By("repairing an alias that was deleted")
+				legacy, err := getAlias("legacy-name")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(mockReconciler.Delete(ctx, legacy)).To(Succeed())
+				_, err = mockReconciler.Reconcile(ctx, request)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = getAlias("legacy-name")
+				Expect(err).NotTo(HaveOccurred())
+
+				By("updating the aliases with the document and the list")
+				password = "second"
+				Expect(mockReconciler.Get(ctx, request.NamespacedName, sopsSecret)).To(Succeed())
+				sopsSecret.Generation++
+				sopsSecret.Spec.SopsSecret = "password: ENC[v2]\nsops:\n    mac: test\n"
+				sopsSecret.Spec.Aliases = []string{"other-name", "new-name"}
+				Expect(mockReconciler.Update(ctx, sopsSecret)).To(Succeed())
+				_, err = mockReconciler.Reconcile(ctx, request)
+				Expect(err).NotTo(HaveOccurred())
+				for _, name := range []string{"other-name", "new-name"} {
+					alias, err := getAlias(name)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(alias.Data).To(HaveKeyWithValue("password", []byte("second")))
+				}
+				_, err = getAlias("legacy-name")
+				Expect(errors.IsNotFound(err)).To(BeTrue())
+
+				By("deleting the SopsSecret")
+				Expect(mockReconciler.Delete(ctx, sopsSecret)).To(Succeed())
+				_, err = mockReconciler.Reconcile(ctx, request)
+				Expect(err).NotTo(HaveOccurred())
+				for _, name := range []string{"other-name", "new-name"} {
+					_, err := getAlias(name)
+					Expect(errors.IsNotFound(err)).To(BeTrue())
+				}
+			})
+
+			It("should not overwrite a Secret that is not an alias", func() {
+				Expect(mockReconciler.Create(ctx, &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: "taken-name", Namespace: "default"},
+					Data:       map[string][]byte{"own": []byte("data")},
+				})).To(Succeed())
+				sopsSecret := &secretsv1alpha1.SopsSecret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:       "alias-conflict",
+						Namespace:  "default",
+						Finalizers: []string{finalizerName},
+					},
+					Spec: secretsv1alpha1.SopsSecretSpec{
+						SopsSecret: "password: ENC[v1]\nsops:\n    mac: test\n",
+						Aliases:    []string{"taken-name"},
+					},
+				}
+				Expect(mockReconciler.Create(ctx, sopsSecret)).To(Succeed())
+				_, err := mockReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(sopsSecret)})
+				Expect(err).To(MatchError(ContainSubstring("not an alias of this SopsSecret")))
+
+				taken, err := getAlias("taken-name")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(taken.Data).To(Equal(map[string][]byte{"own": []byte("data")}))
+			})
+		})
+
+		Describe("Vault sink", func() {
+			var (
+				mu       sync.Mutex
+				stored   map[string]map[string]string
+				writes   int
+				logins   []string
+				server   *httptest.Server
+				recorder *events.FakeRecorder
+			)
+
+			BeforeEach(func() {
+				stored = map[string]map[string]string{}
+				writes = 0
+				logins = nil
+				// A KV version 2 engine mounted at kv that accepts the token
+				// "root", which the Kubernetes auth method at k8s hands out
+				server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					mu.Lock()
+					defer mu.Unlock()
+					if r.URL.Path == "/v1/auth/k8s/login" {
+						var body map[string]string
+						_ = json.NewDecoder(r.Body).Decode(&body)
+						logins = append(logins, body["role"]+":"+body["jwt"])
+						_, _ = w.Write([]byte(`{"auth":{"client_token":"root"}}`))
+						return
+					}
+					if r.Header.Get("X-Vault-Token") != "root" {
+						w.WriteHeader(http.StatusForbidden)
+						return
+					}
+					switch {
+					case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/v1/kv/data/"):
+						data, ok := stored[strings.TrimPrefix(r.URL.Path, "/v1/kv/data/")]
+						if !ok {
+							w.WriteHeader(http.StatusNotFound)
+							return
+						}
+						_ = json.NewEncoder(w).Encode(map[string]any{"data": map[string]any{"data": data}})
+					case r.Method == http.MethodPost && strings.HasPrefix(r.URL.Path, "/v1/kv/data/"):
+						var body struct {
+							Data map[string]string `json:"data"`
+						}
+						_ = json.NewDecoder(r.Body).Decode(&body)
+						stored[strings.TrimPrefix(r.URL.Path, "/v1/kv/data/")] = body.Data
+						writes++
+					case r.Method == http.MethodDelete && strings.HasPrefix(r.URL.Path, "/v1/kv/metadata/"):
+						delete(stored, strings.TrimPrefix(r.URL.Path, "/v1/kv/metadata/"))
+						w.WriteHeader(http.StatusNoContent)
+					default:
+						w.WriteHeader(http.StatusNotFound)
+					}
+				}))
+				DeferCleanup(server.Close)
+
+				recorder = events.NewFakeRecorder(20)
+				mockReconciler.Recorder = recorder
+				mockReconciler.VaultSink = NewVaultSink(mockReconciler.Client, []string{server.URL})
+				Expect(mockReconciler.Create(ctx, &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: "vault-token", Namespace: "default"},
+					Data:       map[string][]byte{"token": []byte("root\n")},
+				})).To(Succeed())
+				mockDecryptor.DecryptFunc = func(_ []byte) (*sops.DecryptedData, error) {
+					return &sops.DecryptedData{Data: map[string][]byte{"password": []byte("secret")}}, nil
+				}
+			})
+
+			newSopsSecret := func(name string, sink *secretsv1alpha1.VaultSink) *secretsv1alpha1.SopsSecret {
+				sopsSecret := &secretsv1alpha1.SopsSecret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:       name,
+						Namespace:  "default",
+						Finalizers: []string{finalizerName},
+					},
+					Spec: secretsv1alpha1.SopsSecretSpec{
+						SopsSecret: "password: ENC[test]\nsops:\n    mac: test\n",
+						VaultSink:  sink,
+					},
+				}
+				Expect(mockReconciler.Create(ctx, sopsSecret)).To(Succeed())
+				return sopsSecret
+			}
+
+			tokenSink := func(address string) *secretsv1alpha1.VaultSink {
+				return &secretsv1alpha1.VaultSink{
+					Address: address,
+					Mount:   "kv",
+					Path:    "apps/db",
+					Auth: secretsv1alpha1.VaultAuth{
+						TokenSecretRef: &secretsv1alpha1.VaultTokenSecretRef{Name: "vault-token"},
+					},
+					DeleteOnCleanup: true,
+				}
+			}
+
+			It("should write the values to Vault once and delete them with the SopsSecret", func() {
+				sopsSecret := newSopsSecret("vault-sink", tokenSink(server.URL))
+				key := client.ObjectKeyFromObject(sopsSecret)
+				_, err := mockReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+				Expect(err).NotTo(HaveOccurred())
+
+				secret := &corev1.Secret{}
+				Expect(mockReconciler.Get(ctx, key, secret)).To(Succeed())
+				Expect(stored).To(HaveKeyWithValue("apps/db", map[string]string{"password": "secret"}))
+				Expect(writes).To(Equal(1))
+
+				By("writing unchanged values again")
+				Expect(mockReconciler.Get(ctx, key, sopsSecret)).To(Succeed())
+				sopsSecret.Generation = 2
+				sopsSecret.Spec.SecretLabels = map[string]string{"team": "payments"}
+				Expect(mockReconciler.Update(ctx, sopsSecret)).To(Succeed())
+				_, err = mockReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(writes).To(Equal(1))
+
+				By("deleting the SopsSecret")
+				Expect(mockReconciler.Get(ctx, key, sopsSecret)).To(Succeed())
+				_, err = mockReconciler.reconcileDelete(ctx, sopsSecret)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(stored).NotTo(HaveKey("apps/db"))
+			})
+
+			It("should keep the Vault secret on delete without deleteOnCleanup", func() {
+				sink := tokenSink(server.URL)
+				sink.DeleteOnCleanup = false
+				sopsSecret := newSopsSecret("vault-sink-retain", sink)
+				key := client.ObjectKeyFromObject(sopsSecret)
+				_, err := mockReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(mockReconciler.Get(ctx, key, sopsSecret)).To(Succeed())
+				_, err = mockReconciler.reconcileDelete(ctx, sopsSecret)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(stored).To(HaveKey("apps/db"))
+			})
+
+			It("should fail the reconcile for an address that is not allowed", func() {
+				sopsSecret := newSopsSecret("vault-sink-denied", tokenSink("https://vault.example.com"))
+				key := client.ObjectKeyFromObject(sopsSecret)
+				_, err := mockReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+				Expect(err).To(MatchError(ContainSubstring("not allowed by --vault-sink-addresses")))
+				Expect(writes).To(BeZero())
+
+				found := false
+				for len(recorder.Events) > 0 {
+					if strings.Contains(<-recorder.Events, ReasonVaultSinkFailed) {
+						found = true
+					}
+				}
+				Expect(found).To(BeTrue())
+			})
+
+			It("should log in with the service account token through the Kubernetes auth method", func() {
+				tokenFile := filepath.Join(GinkgoT().TempDir(), "token")
+				Expect(os.WriteFile(tokenFile, []byte("service-account-token\n"), 0o600)).To(Succeed())
+				mockReconciler.VaultSink.(*vaultSink).tokenFile = tokenFile
+
+				sopsSecret := newSopsSecret("vault-sink-kubernetes", &secretsv1alpha1.VaultSink{
+					Address: server.URL,
+					Mount:   "kv",
+					Path:    "default/kubernetes",
+					Auth: secretsv1alpha1.VaultAuth{
+						Kubernetes: &secretsv1alpha1.VaultKubernetesAuth{Role: "sops", MountPath: "k8s"},
+					},
+				})
+				_, err := mockReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(sopsSecret)})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(logins).To(Equal([]string{"sops:service-account-token"}))
+				Expect(stored).To(HaveKeyWithValue("default/kubernetes", map[string]string{"password": "secret"}))
+			})
+
+			It("should reuse the Kubernetes auth login until the token expires", func() {
+				tokenFile := filepath.Join(GinkgoT().TempDir(), "token")
+				Expect(os.WriteFile(tokenFile, []byte("service-account-token\n"), 0o600)).To(Succeed())
+				sink := mockReconciler.VaultSink.(*vaultSink)
+				sink.tokenFile = tokenFile
+
+				sopsSecret := newSopsSecret("vault-sink-cached", &secretsv1alpha1.VaultSink{
+					Address: server.URL,
+					Mount:   "kv",
+					Path:    "default/cached",
+					Auth: secretsv1alpha1.VaultAuth{
+						Kubernetes: &secretsv1alpha1.VaultKubernetesAuth{Role: "sops", MountPath: "k8s"},
+					},
+				})
+				Expect(sink.Write(ctx, sopsSecret, map[string][]byte{"password": []byte("secret")})).To(Succeed())
+				Expect(sink.Write(ctx, sopsSecret, map[string][]byte{"password": []byte("rotated")})).To(Succeed())
+				Expect(sink.Delete(ctx, sopsSecret)).To(Succeed())
+				Expect(logins).To(HaveLen(1))
+				Expect(writes).To(Equal(2))
+			})
+
+			It("should refuse Kubernetes auth for paths outside the namespace", func() {
+				tokenFile := filepath.Join(GinkgoT().TempDir(), "token")
+				Expect(os.WriteFile(tokenFile, []byte("service-account-token\n"), 0o600)).To(Succeed())
+				mockReconciler.VaultSink.(*vaultSink).tokenFile = tokenFile
+
+				for i, path := range []string{"team-b/db", "default/../team-b/db", "default"} {
+					sopsSecret := newSopsSecret(fmt.Sprintf("vault-sink-escape-%d", i), &secretsv1alpha1.VaultSink{
+						Address: server.URL,
+						Mount:   "kv",
+						Path:    path,
+						Auth: secretsv1alpha1.VaultAuth{
+							Kubernetes: &secretsv1alpha1.VaultKubernetesAuth{Role: "sops", MountPath: "k8s"},
+						},
+					})
+					_, err := mockReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(sopsSecret)})
+					Expect(err).To(MatchError(ContainSubstring("must start with default/")), path)
+				}
+				Expect(logins).To(BeEmpty())
+				Expect(stored).To(BeEmpty())
+			})
+		})
+
+		Describe("Locked data", func() {
+			It("should keep the Secret and report a document changed while locked", func() {
+				recorder := events.NewFakeRecorder(20)
+				mockReconciler.Recorder = recorder
+				password := "first"
+				mockDecryptor.DecryptFunc = func(_ []byte) (*sops.DecryptedData, error) {
+					return &sops.DecryptedData{Data: map[string][]byte{"password": []byte(password)}}, nil
+				}
+				sopsSecret := &secretsv1alpha1.SopsSecret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:       "locked-data",
+						Namespace:  "default",
+						Finalizers: []string{finalizerName},
+					},
+					Spec: secretsv1alpha1.SopsSecretSpec{
+						SopsSecret: "password: ENC[test]\nsops:\n    mac: test\n",
+						LockData:   true,
+					},
+				}
+				Expect(mockReconciler.Create(ctx, sopsSecret)).To(Succeed())
+				key := client.ObjectKeyFromObject(sopsSecret)
+				_, err := mockReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+				Expect(err).NotTo(HaveOccurred())
+
+				By("changing the locked document")
+				password = "second"
+				updated := &secretsv1alpha1.SopsSecret{}
+				Expect(mockReconciler.Get(ctx, key, updated)).To(Succeed())
+				updated.Generation++
+				updated.Spec.SopsSecret = "password: ENC[rotated]\nsops:\n    mac: test\n"
+				Expect(mockReconciler.Update(ctx, updated)).To(Succeed())
+				_, err = mockReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+				Expect(err).NotTo(HaveOccurred())
+
+				secret := &corev1.Secret{}
+				Expect(mockReconciler.Get(ctx, key, secret)).To(Succeed())
+				Expect(secret.Data).To(HaveKeyWithValue("password", []byte("first")))
+				Expect(mockReconciler.Get(ctx, key, updated)).To(Succeed())
+				Expect(meta.IsStatusConditionTrue(updated.Status.Conditions,
+					secretsv1alpha1.ConditionTypeLockedDataChanged)).To(BeTrue())
+				ready := meta.FindStatusCondition(updated.Status.Conditions, secretsv1alpha1.ConditionTypeReady)
+				Expect(ready).NotTo(BeNil())
+				Expect(ready.Status).To(Equal(metav1.ConditionFalse))
+				Expect(ready.Reason).To(Equal(ReasonDataLocked))
+				var recorded []string
+				for len(recorder.Events) > 0 {
+					recorded = append(recorded, <-recorder.Events)
+				}
+				Expect(recorded).To(ContainElement(ContainSubstring(ReasonDataLocked)))
+
+				By("removing the lock")
+				updated.Generation++
+				updated.Spec.LockData = false
+				Expect(mockReconciler.Update(ctx, updated)).To(Succeed())
+				_, err = mockReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(mockReconciler.Get(ctx, key, secret)).To(Succeed())
+				Expect(secret.Data).To(HaveKeyWithValue("password", []byte("second")))
+				Expect(mockReconciler.Get(ctx, key, updated)).To(Succeed())
+				Expect(meta.FindStatusCondition(updated.Status.Conditions,
+					secretsv1alpha1.ConditionTypeLockedDataChanged)).To(BeNil())
+				Expect(meta.IsStatusConditionTrue(updated.Status.Conditions,
+					secretsv1alpha1.ConditionTypeReady)).To(BeTrue())
+			})
+		})
+
+		Describe("Immutable secrets", func() {
+			var recorder *events.FakeRecorder
+			var deleted []string
+
+			BeforeEach(func() {
+				recorder = events.NewFakeRecorder(20)
+				mockReconciler.Recorder = recorder
+				deleted = nil
+				mockReconciler.Client = interceptor.NewClient(mockReconciler.Client.(client.WithWatch), interceptor.Funcs{
+					Delete: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.DeleteOption) error {
+						if _, ok := obj.(*corev1.Secret); ok {
+							deleted = append(deleted, obj.GetName())
+						}
+						return c.Delete(ctx, obj, opts...)
+					},
+				})
+			})
+
+			// reconcileTwice reconciles an immutable SopsSecret with password
+			// "secret", then again for a new generation decrypting to password
+			// and returns the Secret before and after
+			reconcileTwice := func(name, password string) (*corev1.Secret, *corev1.Secret) {
+				mockDecryptor.DecryptFunc = func(_ []byte) (*sops.DecryptedData, error) {
+					return &sops.DecryptedData{Data: map[string][]byte{"password": []byte("secret")}}, nil
+				}
+				sopsSecret := &secretsv1alpha1.SopsSecret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:       name,
+						Namespace:  "default",
+						Finalizers: []string{finalizerName},
+					},
+					Spec: secretsv1alpha1.SopsSecretSpec{
+						SopsSecret: "password: ENC[test]\nsops:\n    mac: test\n",
+						Immutable:  true,
+					},
+				}
+				Expect(mockReconciler.Create(ctx, sopsSecret)).To(Succeed())
+				key := client.ObjectKeyFromObject(sopsSecret)
+				_, err := mockReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+				Expect(err).NotTo(HaveOccurred())
+				before := &corev1.Secret{}
+				Expect(mockReconciler.Get(ctx, key, before)).To(Succeed())
+
+				mockDecryptor.DecryptFunc = func(_ []byte) (*sops.DecryptedData, error) {
+					return &sops.DecryptedData{Data: map[string][]byte{"password": []byte(password)}}, nil
+				}
+				Expect(mockReconciler.Get(ctx, key, sopsSecret)).To(Succeed())
+				sopsSecret.Generation = 2
+				sopsSecret.Spec.SecretLabels = map[string]string{"team": "payments"}
+				Expect(mockReconciler.Update(ctx, sopsSecret)).To(Succeed())
+				_, err = mockReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+				Expect(err).NotTo(HaveOccurred())
+				after := &corev1.Secret{}
+				Expect(mockReconciler.Get(ctx, key, after)).To(Succeed())
+				return before, after
+			}
+
+			recreated := func() bool {
+				found := false
+				for len(recorder.Events) > 0 {
+					if strings.Contains(<-recorder.Events, ReasonSecretRecreated) {
+						found = true
+					}
+				}
+				return found
+			}
+
+			It("should create the Secret as immutable", func() {
+				before, _ := reconcileTwice("immutable-create", "secret")
+				Expect(before.Immutable).NotTo(BeNil())
+				Expect(*before.Immutable).To(BeTrue())
+			})
+
+			It("should delete and recreate the Secret when its data changes", func() {
+				_, after := reconcileTwice("immutable-changed", "rotated")
+				Expect(deleted).To(Equal([]string{"immutable-changed"}))
+				Expect(after.Data).To(HaveKeyWithValue("password", []byte("rotated")))
+				Expect(after.Labels).To(HaveKeyWithValue("team", "payments"))
+				Expect(after.Immutable).NotTo(BeNil())
+				Expect(*after.Immutable).To(BeTrue())
+				Expect(recreated()).To(BeTrue())
+			})
+
+			It("should update the Secret in place when its data is unchanged", func() {
+				_, after := reconcileTwice("immutable-unchanged", "secret")
+				Expect(deleted).To(BeEmpty())
+				Expect(after.Data).To(HaveKeyWithValue("password", []byte("secret")))
+				Expect(after.Labels).To(HaveKeyWithValue("team", "payments"))
+				Expect(recreated()).To(BeFalse())
+			})
+		})
+
+		Describe("Secret type transitions", func() {
+			var recorder *events.FakeRecorder
+
+			BeforeEach(func() {
+				recorder = events.NewFakeRecorder(20)
+				mockReconciler.Recorder = recorder
+			})
+
+			// changeType reconciles the SopsSecret as Opaque, then again with
+			// secretType and the decrypted data
+			changeType := func(name string, secretType corev1.SecretType, data map[string][]byte) (*secretsv1alpha1.SopsSecret, *corev1.Secret) {
+				mockDecryptor.DecryptFunc = func(_ []byte) (*sops.DecryptedData, error) {
+					return &sops.DecryptedData{Data: map[string][]byte{"password": []byte("password: secret")}}, nil
+				}
+				sopsSecret := &secretsv1alpha1.SopsSecret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:       name,
+						Namespace:  "default",
+						Finalizers: []string{finalizerName},
+					},
+					Spec: secretsv1alpha1.SopsSecretSpec{
+						SopsSecret: "password: ENC[test]\nsops:\n    mac: test\n",
+					},
+				}
+				Expect(mockReconciler.Create(ctx, sopsSecret)).To(Succeed())
+				key := client.ObjectKeyFromObject(sopsSecret)
+				_, err := mockReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+				Expect(err).NotTo(HaveOccurred())
+
+				mockDecryptor.DecryptFunc = func(_ []byte) (*sops.DecryptedData, error) {
+					return &sops.DecryptedData{Data: data}, nil
+				}
+				Expect(mockReconciler.Get(ctx, key, sopsSecret)).To(Succeed())
+				sopsSecret.Spec.SecretType = secretType
+				sopsSecret.Generation = 2
+				Expect(mockReconciler.Update(ctx, sopsSecret)).To(Succeed())
+				_, err = mockReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(mockReconciler.Get(ctx, key, sopsSecret)).To(Succeed())
+				secret := &corev1.Secret{}
+				Expect(mockReconciler.Get(ctx, key, secret)).To(Succeed())
+				return sopsSecret, secret
+			}
+
+			It("should recreate the Secret when the data fits the new type", func() {
+				sopsSecret, secret := changeType("type-valid", corev1.SecretTypeTLS, map[string][]byte{
+					"tls.crt": []byte("tls.crt: CERT"),
+					"tls.key": []byte("tls.key: KEY"),
+				})
+				Expect(secret.Type).To(Equal(corev1.SecretTypeTLS))
+				Expect(secret.Data).To(Equal(map[string][]byte{"tls.crt": []byte("CERT"), "tls.key": []byte("KEY")}))
+				ready := meta.FindStatusCondition(sopsSecret.Status.Conditions, secretsv1alpha1.ConditionTypeReady)
+				Expect(ready).NotTo(BeNil())
+				Expect(ready.Status).To(Equal(metav1.ConditionTrue))
+			})
+
+			It("should keep the old Secret when the data does not fit the new type", func() {
+				sopsSecret, secret := changeType("type-invalid", corev1.SecretTypeTLS, map[string][]byte{
+					"password": []byte("password: rotated"),
+				})
+				Expect(secret.Type).To(Equal(corev1.SecretTypeOpaque))
+				Expect(secret.Data).To(Equal(map[string][]byte{"password": []byte("password: secret")}))
+				ready := meta.FindStatusCondition(sopsSecret.Status.Conditions, secretsv1alpha1.ConditionTypeReady)
+				Expect(ready).NotTo(BeNil())
+				Expect(ready.Status).To(Equal(metav1.ConditionFalse))
+				Expect(ready.Reason).To(Equal(ReasonInvalidSecretType))
+				Expect(ready.Message).To(ContainSubstring("tls.crt, tls.key"))
+
+				found := false
+				for len(recorder.Events) > 0 {
+					if strings.Contains(<-recorder.Events, ReasonInvalidSecretType) {
+						found = true
+					}
+				}
+				Expect(found).To(BeTrue())
+			})
+		})
+
+		Describe("MAC mismatch retry", func() {
+			const (
+				stale = "password: ENC[stale]\nsops:\n    mac: test\n"
+				fresh = "password: ENC[fresh]\nsops:\n    mac: test\n"
+			)
+			var decrypted []string
+
+			macMismatch := fmt.Errorf("sops decrypt failed: exit status 1: MAC mismatch. File has ABC, computed DEF")
+
+			// reconcileChunk reconciles a SopsSecret read from one chunk that
+			// holds the stale document. The decryptor calls update on every
+			// decrypt and fails with a MAC mismatch on payloads other than fresh.
+			reconcileChunk := func(name string, update func()) *secretsv1alpha1.SopsSecret {
+				decrypted = nil
+				mockDecryptor.DecryptFunc = func(data []byte) (*sops.DecryptedData, error) {
+					decrypted = append(decrypted, string(data))
+					update()
+					if string(data) != fresh {
+						return nil, macMismatch
+					}
+					return &sops.DecryptedData{Data: map[string][]byte{"password": []byte("password: secret")}}, nil
+				}
+				Expect(mockReconciler.Create(ctx, &corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+					Data:       map[string]string{"document": stale},
+				})).To(Succeed())
+				sopsSecret := &secretsv1alpha1.SopsSecret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:       name,
+						Namespace:  "default",
+						Finalizers: []string{finalizerName},
+					},
+					Spec: secretsv1alpha1.SopsSecretSpec{
+						EncryptedFromChunks: []secretsv1alpha1.ConfigMapChunkRef{{Name: name, Key: "document", Index: 0}},
+					},
+				}
+				Expect(mockReconciler.Create(ctx, sopsSecret)).To(Succeed())
+				key := client.ObjectKeyFromObject(sopsSecret)
+				_, err := mockReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(mockReconciler.Get(ctx, key, sopsSecret)).To(Succeed())
+				return sopsSecret
+			}
+
+			// writeChunk replaces the document in the chunk ConfigMap
+			writeChunk := func(name, document string) {
+				configMap := &corev1.ConfigMap{}
+				Expect(mockReconciler.Get(ctx, types.NamespacedName{Namespace: "default", Name: name}, configMap)).To(Succeed())
+				configMap.Data["document"] = document
+				Expect(mockReconciler.Update(ctx, configMap)).To(Succeed())
+			}
+
+			It("should read the source again after a MAC mismatch on a stale read", func() {
+				sopsSecret := reconcileChunk("mac-stale", func() { writeChunk("mac-stale", fresh) })
+				Expect(decrypted).To(Equal([]string{stale, fresh}))
+				Expect(meta.IsStatusConditionTrue(sopsSecret.Status.Conditions, secretsv1alpha1.ConditionTypeReady)).To(BeTrue())
+				Expect(sopsSecret.Status.LastDecryptedHash).To(Equal(calculateHash(fresh)))
+				Expect(mockReconciler.Get(ctx, client.ObjectKeyFromObject(sopsSecret), &corev1.Secret{})).To(Succeed())
+			})
+
+			It("should retry at most once", func() {
+				n := 0
+				sopsSecret := reconcileChunk("mac-changing", func() {
+					n++
+					writeChunk("mac-changing", fmt.Sprintf("password: ENC[%d]\nsops:\n    mac: test\n", n))
+				})
+				Expect(decrypted).To(HaveLen(2))
+				Expect(meta.IsStatusConditionFalse(sopsSecret.Status.Conditions, secretsv1alpha1.ConditionTypeReady)).To(BeTrue())
+			})
+
+			It("should not decrypt an unchanged source again", func() {
+				sopsSecret := reconcileChunk("mac-unchanged", func() {})
+				Expect(decrypted).To(Equal([]string{stale}))
+				Expect(meta.IsStatusConditionFalse(sopsSecret.Status.Conditions, secretsv1alpha1.ConditionTypeReady)).To(BeTrue())
+			})
+		})
+
+		Describe("Secret UID", func() {
+			BeforeEach(func() {
+				// The fake client does not assign UIDs, the API server does
+				created := 0
+				mockReconciler.Client = interceptor.NewClient(mockReconciler.Client.(client.WithWatch), interceptor.Funcs{
+					Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+						created++
+						obj.SetUID(types.UID(fmt.Sprintf("uid-%d", created)))
+						return c.Create(ctx, obj, opts...)
+					},
+				})
+				mockDecryptor.DecryptFunc = func(_ []byte) (*sops.DecryptedData, error) {
+					return &sops.DecryptedData{Data: map[string][]byte{"password": []byte("password: secret")}}, nil
+				}
+			})
+
+			It("should record the UID and update it after a recreate", func() {
+				sopsSecret := &secretsv1alpha1.SopsSecret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:       "secret-uid",
+						Namespace:  "default",
+						Finalizers: []string{finalizerName},
+					},
+					Spec: secretsv1alpha1.SopsSecretSpec{
+						SopsSecret: "password: ENC[test]\nsops:\n    mac: test\n",
+					},
+				}
+				Expect(mockReconciler.Create(ctx, sopsSecret)).To(Succeed())
+				key := client.ObjectKeyFromObject(sopsSecret)
+				_, err := mockReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+				Expect(err).NotTo(HaveOccurred())
+
+				secret := &corev1.Secret{}
+				Expect(mockReconciler.Get(ctx, key, secret)).To(Succeed())
+				Expect(secret.UID).NotTo(BeEmpty())
+				Expect(mockReconciler.Get(ctx, key, sopsSecret)).To(Succeed())
+				Expect(sopsSecret.Status.SecretUID).To(Equal(secret.UID))
+				firstUID := secret.UID
+
+				By("recording the new UID after a recreate")
+				metav1.SetMetaDataAnnotation(&sopsSecret.ObjectMeta, recreateAnnotation, "true")
+				Expect(mockReconciler.Update(ctx, sopsSecret)).To(Succeed())
+				_, err = mockReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(mockReconciler.Get(ctx, key, secret)).To(Succeed())
+				Expect(secret.UID).NotTo(Equal(firstUID))
+				Expect(mockReconciler.Get(ctx, key, sopsSecret)).To(Succeed())
+				Expect(sopsSecret.Status.SecretUID).To(Equal(secret.UID))
+
+				By("backfilling a missing UID without decrypting")
+				sopsSecret.Status.SecretUID = ""
+				Expect(mockReconciler.Status().Update(ctx, sopsSecret)).To(Succeed())
+				mockDecryptor.DecryptFunc = func(_ []byte) (*sops.DecryptedData, error) {
+					return nil, fmt.Errorf("unexpected decrypt")
+				}
+				_, err = mockReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(mockReconciler.Get(ctx, key, sopsSecret)).To(Succeed())
+				Expect(sopsSecret.Status.SecretUID).To(Equal(secret.UID))
+			})
+		})
+
+		Describe("Named value transforms", func() {
+			BeforeEach(func() {
+				mockDecryptor.DecryptFunc = func(_ []byte) (*sops.DecryptedData, error) {
+					return &sops.DecryptedData{Data: map[string][]byte{
+						"token":    []byte("token: '  MiXed  '"),
+						"encoded":  []byte("encoded: c2VjcmV0"),
+						"password": []byte("password: secret"),
+					}}, nil
+				}
+			})
+
+			reconcileWith := func(name string, transforms map[string][]string) (*secretsv1alpha1.SopsSecret, *corev1.Secret, error) {
+				sopsSecret := &secretsv1alpha1.SopsSecret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:       name,
+						Namespace:  "default",
+						Finalizers: []string{finalizerName},
+					},
+					Spec: secretsv1alpha1.SopsSecretSpec{
+						SopsSecret: "token: ENC[test]\nsops:\n    mac: test\n",
+						Transforms: transforms,
+					},
+				}
+				Expect(mockReconciler.Create(ctx, sopsSecret)).To(Succeed())
+				key := client.ObjectKeyFromObject(sopsSecret)
+				_, err := mockReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(mockReconciler.Get(ctx, key, sopsSecret)).To(Succeed())
+				secret := &corev1.Secret{}
+				return sopsSecret, secret, mockReconciler.Get(ctx, key, secret)
+			}
+
+			It("should chain transforms on a single key in order", func() {
+				_, secret, err := reconcileWith("transforms-chain", map[string][]string{
+					"token":   {"trim", "lower", "base64encode"},
+					"encoded": {"base64decode", "upper"},
+					"missing": {"trim"},
+				})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(secret.Data).To(Equal(map[string][]byte{
+					"token":    []byte("bWl4ZWQ="),
+					"encoded":  []byte("SECRET"),
+					"password": []byte("password: secret"),
+				}))
+			})
+
+			It("should not write the Secret for an unknown transform", func() {
+				sopsSecret, _, err := reconcileWith("transforms-unknown", map[string][]string{
+					"token": {"trim", "rot13"},
+				})
+				Expect(errors.IsNotFound(err)).To(BeTrue())
+				ready := meta.FindStatusCondition(sopsSecret.Status.Conditions, secretsv1alpha1.ConditionTypeReady)
+				Expect(ready).NotTo(BeNil())
+				Expect(ready.Status).To(Equal(metav1.ConditionFalse))
+				Expect(ready.Reason).To(Equal(ReasonTransformFailed))
+				Expect(ready.Message).To(ContainSubstring(`unknown transform "rot13" for key token`))
+			})
+		})
+
+		Describe("Schema validation", func() {
+			const schema = `{
+				"type": "object",
+				"required": ["username", "port"],
+				"properties": {
+					"username": {"type": "string", "minLength": 3},
+					"port": {"type": "integer", "minimum": 1}
+				}
+			}`
+
+			var data map[string][]byte
+
+			BeforeEach(func() {
+				data = nil
+				mockDecryptor.DecryptFunc = func(_ []byte) (*sops.DecryptedData, error) {
+					return &sops.DecryptedData{Data: data}, nil
+				}
+				Expect(mockReconciler.Create(ctx, &corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{Name: "db-schema", Namespace: "default"},
+					Data:       map[string]string{"schema.json": schema},
+				})).To(Succeed())
+			})
+
+			reconcileWith := func(name string) (*secretsv1alpha1.SopsSecret, error) {
+				sopsSecret := &secretsv1alpha1.SopsSecret{}
+				key := types.NamespacedName{Namespace: "default", Name: name}
+				if err := mockReconciler.Get(ctx, key, sopsSecret); errors.IsNotFound(err) {
+					sopsSecret = &secretsv1alpha1.SopsSecret{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:       name,
+							Namespace:  "default",
+							Finalizers: []string{finalizerName},
+						},
+						Spec: secretsv1alpha1.SopsSecretSpec{
+							SopsSecret: "username: ENC[test]\nsops:\n    mac: test\n",
+							SchemaRef:  &secretsv1alpha1.SchemaRef{Name: "db-schema"},
+						},
+					}
+					Expect(mockReconciler.Create(ctx, sopsSecret)).To(Succeed())
+				}
+				_, err := mockReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(mockReconciler.Get(ctx, key, sopsSecret)).To(Succeed())
+				return sopsSecret, mockReconciler.Get(ctx, key, &corev1.Secret{})
+			}
+
+			It("should write data that matches the schema", func() {
+				data = map[string][]byte{
+					"username": []byte("username: admin"),
+					"port":     []byte("port: 5432"),
+				}
+				sopsSecret, err := reconcileWith("schema-valid")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(meta.IsStatusConditionTrue(sopsSecret.Status.Conditions, secretsv1alpha1.ConditionTypeReady)).To(BeTrue())
+				Expect(meta.FindStatusCondition(sopsSecret.Status.Conditions, secretsv1alpha1.ConditionTypeSchemaInvalid)).To(BeNil())
+				Expect(sopsSecret.Status.SchemaResourceVersion).NotTo(BeEmpty())
+			})
+
+			It("should not write data that does not match the schema", func() {
+				data = map[string][]byte{
+					"username": []byte("username: ab"),
+					"port":     []byte("port: closed"),
+				}
+				sopsSecret, err := reconcileWith("schema-invalid")
+				Expect(errors.IsNotFound(err)).To(BeTrue())
+				cond := meta.FindStatusCondition(sopsSecret.Status.Conditions, secretsv1alpha1.ConditionTypeSchemaInvalid)
+				Expect(cond).NotTo(BeNil())
+				Expect(cond.Status).To(Equal(metav1.ConditionTrue))
+				Expect(cond.Message).To(ContainSubstring("port has the wrong type"))
+				Expect(cond.Message).To(ContainSubstring("username is too short"))
+				// Values are never quoted
+				Expect(cond.Message).NotTo(ContainSubstring("closed"))
+				ready := meta.FindStatusCondition(sopsSecret.Status.Conditions, secretsv1alpha1.ConditionTypeReady)
+				Expect(ready.Status).To(Equal(metav1.ConditionFalse))
+				Expect(ready.Reason).To(Equal(ReasonSchemaInvalid))
+			})
+
+			It("should validate again when the schema changes", func() {
+				data = map[string][]byte{
+					"username": []byte("username: admin"),
+					"port":     []byte("port: 5432"),
+				}
+				_, err := reconcileWith("schema-changed")
+				Expect(err).NotTo(HaveOccurred())
+
+				configMap := &corev1.ConfigMap{}
+				Expect(mockReconciler.Get(ctx, types.NamespacedName{Namespace: "default", Name: "db-schema"}, configMap)).To(Succeed())
+				configMap.Data["schema.json"] = `{"type": "object", "required": ["password"]}`
+				Expect(mockReconciler.Update(ctx, configMap)).To(Succeed())
+				requests := mockReconciler.sopsSecretsUsingSchema(ctx, configMap)
+				Expect(requests).To(ConsistOf(reconcile.Request{
+					NamespacedName: types.NamespacedName{Namespace: "default", Name: "schema-changed"},
+				}))
+
+				sopsSecret, _ := reconcileWith("schema-changed")
+				cond := meta.FindStatusCondition(sopsSecret.Status.Conditions, secretsv1alpha1.ConditionTypeSchemaInvalid)
+				Expect(cond).NotTo(BeNil())
+				Expect(cond.Message).To(ContainSubstring("password is required"))
+			})
+
+			It("should report a missing schema", func() {
+				data = map[string][]byte{"username": []byte("username: admin")}
+				sopsSecret := &secretsv1alpha1.SopsSecret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:       "schema-missing",
+						Namespace:  "default",
+						Finalizers: []string{finalizerName},
+					},
+					Spec: secretsv1alpha1.SopsSecretSpec{
+						SopsSecret: "username: ENC[test]\nsops:\n    mac: test\n",
+						SchemaRef:  &secretsv1alpha1.SchemaRef{Name: "no-such-schema"},
+					},
+				}
+				Expect(mockReconciler.Create(ctx, sopsSecret)).To(Succeed())
+				sopsSecret, err := reconcileWith("schema-missing")
+				Expect(errors.IsNotFound(err)).To(BeTrue())
+				ready := meta.FindStatusCondition(sopsSecret.Status.Conditions, secretsv1alpha1.ConditionTypeReady)
+				Expect(ready.Reason).To(Equal(ReasonSchemaUnavailable))
+			})
+		})
+
+		Describe("Key change events", func() {
+			var recorder *events.FakeRecorder
+
+			BeforeEach(func() {
+				recorder = events.NewFakeRecorder(50)
+				mockReconciler.Recorder = recorder
+			})
+
+			// keysChangedEvents drains the recorder and returns the KeysChanged events
+			keysChangedEvents := func() []string {
+				var found []string
+				for len(recorder.Events) > 0 {
+					if event := <-recorder.Events; strings.Contains(event, ReasonKeysChanged) {
+						found = append(found, event)
+					}
+				}
+				return found
+			}
+
+			It("should summarize added and removed keys", func() {
+				keys := []string{"a", "b"}
+				mockDecryptor.DecryptFunc = func(_ []byte) (*sops.DecryptedData, error) {
+					data := map[string][]byte{}
+					for _, key := range keys {
+						data[key] = []byte(key + ": value")
+					}
+					return &sops.DecryptedData{Data: data}, nil
+				}
+				sopsSecret := &secretsv1alpha1.SopsSecret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:       "key-changes",
+						Namespace:  "default",
+						Finalizers: []string{finalizerName},
+					},
+					Spec: secretsv1alpha1.SopsSecretSpec{
+						SopsSecret: "a: ENC[0]\nsops:\n    mac: test\n",
+					},
+				}
+				Expect(mockReconciler.Create(ctx, sopsSecret)).To(Succeed())
+				key := client.ObjectKeyFromObject(sopsSecret)
+
+				// rotate changes the document so that it is decrypted again
+				rotate := func(generation int64) {
+					Expect(mockReconciler.Get(ctx, key, sopsSecret)).To(Succeed())
+					sopsSecret.Spec.SopsSecret = fmt.Sprintf("a: ENC[%d]\nsops:\n    mac: test\n", generation)
+					sopsSecret.Generation = generation
+					Expect(mockReconciler.Update(ctx, sopsSecret)).To(Succeed())
+					_, err := mockReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+					Expect(err).NotTo(HaveOccurred())
+				}
+
+				By("not reporting the keys of a new Secret")
+				_, err := mockReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(keysChangedEvents()).To(BeEmpty())
+				Expect(mockReconciler.Get(ctx, key, sopsSecret)).To(Succeed())
+				Expect(sopsSecret.Status.ManagedKeys).To(Equal([]string{"a", "b"}))
+
+				By("counting added and removed keys")
+				keys = []string{"a", "c", "d"}
+				rotate(2)
+				Expect(keysChangedEvents()).To(ConsistOf(ContainSubstring("2 keys added (c, d), 1 key removed (b)")))
+				Expect(mockReconciler.Get(ctx, key, sopsSecret)).To(Succeed())
+				Expect(sopsSecret.Status.ManagedKeys).To(Equal([]string{"a", "c", "d"}))
+
+				By("staying quiet while the keys are unchanged")
+				rotate(3)
+				Expect(keysChangedEvents()).To(BeEmpty())
+
+				By("reporting removed keys alone")
+				keys = []string{"a"}
+				rotate(4)
+				Expect(keysChangedEvents()).To(ConsistOf(ContainSubstring("2 keys removed (c, d)")))
+			})
+		})
+
+		Describe("Backend policy from namespace labels", func() {
+			const (
+				kmsDocument = "token: ENC[test]\nsops:\n    kms:\n        - arn: arn:aws:kms:eu-west-1:111122223333:key/test\n    mac: test\n"
+				ageDocument = "token: ENC[test]\nsops:\n    age:\n        - recipient: age1test\n    mac: test\n"
+			)
+			var decrypts int
+
+			BeforeEach(func() {
+				decrypts = 0
+				mockDecryptor.DecryptFunc = func(_ []byte) (*sops.DecryptedData, error) {
+					decrypts++
+					return &sops.DecryptedData{Data: map[string][]byte{"token": []byte("secret")}}, nil
+				}
+			})
+
+			// reconcileIn creates a SopsSecret in a namespace labelled with the
+			// policy and reconciles it
+			reconcileIn := func(name, policy, document string) *secretsv1alpha1.SopsSecret {
+				namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+					Name:   name,
+					Labels: map[string]string{requiredBackendLabel: policy},
+				}}
+				Expect(mockReconciler.Create(ctx, namespace)).To(Succeed())
+				sopsSecret := &secretsv1alpha1.SopsSecret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:       "policy",
+						Namespace:  name,
+						Finalizers: []string{finalizerName},
+					},
+					Spec: secretsv1alpha1.SopsSecretSpec{SopsSecret: document},
+				}
+				Expect(mockReconciler.Create(ctx, sopsSecret)).To(Succeed())
+				key := client.ObjectKeyFromObject(sopsSecret)
+				_, err := mockReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(mockReconciler.Get(ctx, key, sopsSecret)).To(Succeed())
+				return sopsSecret
+			}
+
+			expectViolation := func(sopsSecret *secretsv1alpha1.SopsSecret, message string) {
+				Expect(decrypts).To(BeZero())
+				cond := meta.FindStatusCondition(sopsSecret.Status.Conditions,
+					secretsv1alpha1.ConditionTypeBackendPolicyViolation)
+				Expect(cond).NotTo(BeNil())
+				Expect(cond.Status).To(Equal(metav1.ConditionTrue))
+				Expect(cond.Message).To(ContainSubstring(message))
+				Expect(meta.IsStatusConditionFalse(sopsSecret.Status.Conditions,
+					secretsv1alpha1.ConditionTypeReady)).To(BeTrue())
+				err := mockReconciler.Get(ctx, types.NamespacedName{Name: "policy", Namespace: sopsSecret.Namespace},
+					&corev1.Secret{})
+				Expect(errors.IsNotFound(err)).To(BeTrue())
+			}
+
+			It("should decrypt a document using the required backend", func() {
+				sopsSecret := reconcileIn("policy-kms", "kms", kmsDocument)
+				Expect(decrypts).To(Equal(1))
+				Expect(meta.FindStatusCondition(sopsSecret.Status.Conditions,
+					secretsv1alpha1.ConditionTypeBackendPolicyViolation)).To(BeNil())
+				Expect(meta.IsStatusConditionTrue(sopsSecret.Status.Conditions,
+					secretsv1alpha1.ConditionTypeReady)).To(BeTrue())
+			})
+
+			It("should not decrypt a document using another backend", func() {
+				sopsSecret := reconcileIn("policy-kms-age", "kms", ageDocument)
+				expectViolation(sopsSecret, "requires backend kms, document is encrypted with age")
+			})
+
+			It("should accept any backend of a list", func() {
+				sopsSecret := reconcileIn("policy-list", "gcp_kms, kms", kmsDocument)
+				Expect(decrypts).To(Equal(1))
+				Expect(meta.FindStatusCondition(sopsSecret.Status.Conditions,
+					secretsv1alpha1.ConditionTypeBackendPolicyViolation)).To(BeNil())
+			})
+
+			It("should not decrypt a document that also uses another backend", func() {
+				mixed := "token: ENC[test]\nsops:\n    age:\n        - recipient: age1test\n" +
+					"    kms:\n        - arn: arn:aws:kms:eu-west-1:111122223333:key/test\n    mac: test\n"
+				sopsSecret := reconcileIn("policy-age-mixed", "age", mixed)
+				expectViolation(sopsSecret, "document is encrypted with age, kms")
+			})
+
+			It("should decrypt once the policy is lifted", func() {
+				sopsSecret := reconcileIn("policy-lifted", "kms", ageDocument)
+				expectViolation(sopsSecret, "requires backend kms")
+
+				namespace := &corev1.Namespace{}
+				Expect(mockReconciler.Get(ctx, client.ObjectKey{Name: "policy-lifted"}, namespace)).To(Succeed())
+				namespace.Labels[requiredBackendLabel] = "age"
+				Expect(mockReconciler.Update(ctx, namespace)).To(Succeed())
+
+				key := client.ObjectKeyFromObject(sopsSecret)
+				_, err := mockReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(decrypts).To(Equal(1))
+				Expect(mockReconciler.Get(ctx, key, sopsSecret)).To(Succeed())
+				Expect(meta.FindStatusCondition(sopsSecret.Status.Conditions,
+					secretsv1alpha1.ConditionTypeBackendPolicyViolation)).To(BeNil())
+			})
+		})
+
+		Describe("Shutdown drain", func() {
+			var (
+				shutdownCtx context.Context
+				shutdown    context.CancelFunc
+				sopsSecret  *secretsv1alpha1.SopsSecret
+			)
+
+			BeforeEach(func() {
+				shutdownCtx, shutdown = context.WithCancel(ctx)
+				DeferCleanup(func() { shutdown() })
+				sopsSecret = &secretsv1alpha1.SopsSecret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:       "draining",
+						Namespace:  "default",
+						Finalizers: []string{finalizerName},
+					},
+					Spec: secretsv1alpha1.SopsSecretSpec{
+						SopsSecret: "token: ENC[test]\nsops:\n    mac: test\n",
+					},
+				}
+				Expect(mockReconciler.Create(ctx, sopsSecret)).To(Succeed())
+			})
+
+			It("should finish a reconcile in flight within the grace period", func() {
+				mockReconciler.ShutdownGracePeriod = time.Minute
+				mockDecryptor.DecryptWithContextFunc = func(ctx context.Context, _ []byte) (*sops.DecryptedData, error) {
+					shutdown()
+					Expect(ctx.Err()).NotTo(HaveOccurred())
+					return &sops.DecryptedData{Data: map[string][]byte{"token": []byte("secret")}}, nil
+				}
+
+				_, err := mockReconciler.Reconcile(shutdownCtx,
+					reconcile.Request{NamespacedName: client.ObjectKeyFromObject(sopsSecret)})
+				Expect(err).NotTo(HaveOccurred())
+
+				secret := &corev1.Secret{}
+				Expect(mockReconciler.Get(ctx, client.ObjectKeyFromObject(sopsSecret), secret)).To(Succeed())
+				Expect(secret.Data).To(HaveKeyWithValue("token", []byte("secret")))
+			})
+
+			It("should abandon a decrypt that outlasts the grace period without writing", func() {
+				mockReconciler.ShutdownGracePeriod = 10 * time.Millisecond
+				mockDecryptor.DecryptWithContextFunc = func(ctx context.Context, _ []byte) (*sops.DecryptedData, error) {
+					shutdown()
+					<-ctx.Done()
+					return nil, fmt.Errorf("sops decrypt: %w", ctx.Err())
+				}
+
+				_, err := mockReconciler.Reconcile(shutdownCtx,
+					reconcile.Request{NamespacedName: client.ObjectKeyFromObject(sopsSecret)})
+				Expect(err).To(MatchError(context.Canceled))
+
+				err = mockReconciler.Get(ctx, client.ObjectKeyFromObject(sopsSecret), &corev1.Secret{})
+				Expect(errors.IsNotFound(err)).To(BeTrue())
+				Expect(mockReconciler.Get(ctx, client.ObjectKeyFromObject(sopsSecret), sopsSecret)).To(Succeed())
+				Expect(sopsSecret.Status.Conditions).To(BeEmpty())
+			})
+		})
+
+		Describe("Encrypted map keys", func() {
+			It("should not write a Secret with ciphertext key names", func() {
+				mockDecryptor.DecryptFunc = func(_ []byte) (*sops.DecryptedData, error) {
+					return &sops.DecryptedData{Data: map[string][]byte{
+						"ENC[AES256_GCM,data:dG9rZW4=,iv:aXY=,tag:dGFn,type:str]": []byte("secret"),
+						"plain": []byte("value"),
+					}}, nil
+				}
+				sopsSecret := &secretsv1alpha1.SopsSecret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:       "encrypted-keys",
+						Namespace:  "default",
+						Finalizers: []string{finalizerName},
+					},
+					Spec: secretsv1alpha1.SopsSecretSpec{
+						SopsSecret: "ENC[AES256_GCM,data:dG9rZW4=,iv:aXY=,tag:dGFn,type:str]: ENC[test]\nsops:\n    mac: test\n",
+					},
+				}
+				Expect(mockReconciler.Create(ctx, sopsSecret)).To(Succeed())
+				key := client.ObjectKeyFromObject(sopsSecret)
+
+				_, err := mockReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+				Expect(err).NotTo(HaveOccurred())
+
+				err = mockReconciler.Get(ctx, key, &corev1.Secret{})
+				Expect(errors.IsNotFound(err)).To(BeTrue())
+				Expect(mockReconciler.Get(ctx, key, sopsSecret)).To(Succeed())
+				cond := meta.FindStatusCondition(sopsSecret.Status.Conditions,
+					secretsv1alpha1.ConditionTypeEncryptedKeyUnsupported)
+				Expect(cond).NotTo(BeNil())
+				Expect(cond.Status).To(Equal(metav1.ConditionTrue))
+				Expect(cond.Message).To(Equal("Document has 1 encrypted key, encrypting map keys is not supported"))
+				Expect(meta.IsStatusConditionFalse(sopsSecret.Status.Conditions,
+					secretsv1alpha1.ConditionTypeReady)).To(BeTrue())
+
+				By("writing the Secret once the keys are plaintext")
+				mockDecryptor.DecryptFunc = nil
+				sopsSecret.Spec.SopsSecret = "token: ENC[test]\nsops:\n    mac: test\n"
+				sopsSecret.Generation = 2
+				Expect(mockReconciler.Update(ctx, sopsSecret)).To(Succeed())
+				_, err = mockReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(mockReconciler.Get(ctx, key, &corev1.Secret{})).To(Succeed())
+				Expect(mockReconciler.Get(ctx, key, sopsSecret)).To(Succeed())
+				Expect(meta.FindStatusCondition(sopsSecret.Status.Conditions,
+					secretsv1alpha1.ConditionTypeEncryptedKeyUnsupported)).To(BeNil())
+			})
+		})
+
+		Describe("Key source in status", func() {
+			// reconcileKeySource reconciles a new SopsSecret and returns the
+			// recorded status.keySource
+			reconcileKeySource := func(sopsSecret *secretsv1alpha1.SopsSecret) string {
+				Expect(mockReconciler.Create(ctx, sopsSecret)).To(Succeed())
+				key := client.ObjectKeyFromObject(sopsSecret)
+				_, err := mockReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(mockReconciler.Get(ctx, key, sopsSecret)).To(Succeed())
+				Expect(meta.IsStatusConditionTrue(sopsSecret.Status.Conditions,
+					secretsv1alpha1.ConditionTypeReady)).To(BeTrue())
+				return sopsSecret.Status.KeySource
+			}
+
+			newSopsSecret := func(name, document string) *secretsv1alpha1.SopsSecret {
+				return &secretsv1alpha1.SopsSecret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:       name,
+						Namespace:  "default",
+						Finalizers: []string{finalizerName},
+					},
+					Spec: secretsv1alpha1.SopsSecretSpec{SopsSecret: document},
+				}
+			}
+
+			It("should record the operator's keys from the environment", func() {
+				mockReconciler.GlobalKeySource = secretsv1alpha1.KeySourceGlobalEnv
+				Expect(reconcileKeySource(newSopsSecret("source-env", "token: ENC[test]\nsops:\n    mac: test\n"))).
+					To(Equal("global-env"))
+			})
+
+			It("should record the operator's keys from a file", func() {
+				mockReconciler.GlobalKeySource = secretsv1alpha1.KeySourceGlobalFile
+				Expect(reconcileKeySource(newSopsSecret("source-file", "token: ENC[test]\nsops:\n    mac: test\n"))).
+					To(Equal("global-file"))
+			})
+
+			It("should record the key Secret of the age-key-secret annotation", func() {
+				mockReconciler.GlobalKeySource = secretsv1alpha1.KeySourceGlobalEnv
+				Expect(mockReconciler.Create(ctx, &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: "source-key", Namespace: "default"},
+					Data:       map[string][]byte{"age.agekey": []byte("AGE-SECRET-KEY-SOURCE\n")},
+				})).To(Succeed())
+				mockReconciler.NewDecryptor = func(_ []string) sops.DecryptorInterface {
+					return mockDecryptor
+				}
+				sopsSecret := newSopsSecret("source-annotation", "token: ENC[test]\nsops:\n    mac: test\n")
+				sopsSecret.Annotations = map[string]string{ageKeySecretAnnotation: "source-key"}
+				Expect(reconcileKeySource(sopsSecret)).To(Equal("annotation"))
+			})
+
+			It("should record no source for a plaintext document", func() {
+				mockReconciler.GlobalKeySource = secretsv1alpha1.KeySourceGlobalEnv
+				sopsSecret := newSopsSecret("source-plaintext", "token: abc\n")
+				sopsSecret.Spec.AllowPlaintext = true
+				Expect(reconcileKeySource(sopsSecret)).To(BeEmpty())
+			})
+		})
+
+		Describe("Initial grace period", func() {
+			var (
+				recorder   *events.FakeRecorder
+				created    time.Time
+				now        time.Time
+				sopsSecret *secretsv1alpha1.SopsSecret
+			)
+
+			BeforeEach(func() {
+				recorder = events.NewFakeRecorder(10)
+				mockReconciler.Recorder = recorder
+				mockReconciler.InitialGracePeriod = time.Minute
+				created = time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+				mockReconciler.clock = func() time.Time { return now }
+				mockDecryptor.DecryptFunc = func(_ []byte) (*sops.DecryptedData, error) {
+					return nil, fmt.Errorf("sops decrypt failed: no key could decrypt the data")
+				}
+				sopsSecret = &secretsv1alpha1.SopsSecret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:              "new-secret",
+						Namespace:         "default",
+						Finalizers:        []string{finalizerName},
+						CreationTimestamp: metav1.NewTime(created),
+					},
+					Spec: secretsv1alpha1.SopsSecretSpec{
+						SopsSecret: "token: ENC[test]\nsops:\n    mac: test\n",
+					},
+				}
+				Expect(mockReconciler.Create(ctx, sopsSecret)).To(Succeed())
+			})
+
+			reconcileAt := func(at time.Time) ctrl.Result {
+				now = at
+				result, err := mockReconciler.Reconcile(ctx,
+					reconcile.Request{NamespacedName: client.ObjectKeyFromObject(sopsSecret)})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(mockReconciler.Get(ctx, client.ObjectKeyFromObject(sopsSecret), sopsSecret)).To(Succeed())
+				return result
+			}
+
+			It("should retry a failure within the grace period quietly", func() {
+				result := reconcileAt(created.Add(10 * time.Second))
+				Expect(result.RequeueAfter).To(Equal(pendingRequeueInterval))
+				Expect(recorder.Events).To(BeEmpty())
+
+				cond := meta.FindStatusCondition(sopsSecret.Status.Conditions, secretsv1alpha1.ConditionTypePending)
+				Expect(cond).NotTo(BeNil())
+				Expect(cond.Status).To(Equal(metav1.ConditionTrue))
+				Expect(cond.Message).To(ContainSubstring("no key could decrypt the data"))
+				ready := meta.FindStatusCondition(sopsSecret.Status.Conditions, secretsv1alpha1.ConditionTypeReady)
+				Expect(ready).NotTo(BeNil())
+				Expect(ready.Reason).To(Equal(ReasonPending))
+
+				By("requeueing no later than the end of the grace period")
+				result = reconcileAt(created.Add(58 * time.Second))
+				Expect(result.RequeueAfter).To(Equal(2 * time.Second))
+			})
+
+			It("should report a failure after the grace period", func() {
+				reconcileAt(created.Add(10 * time.Second))
+				reconcileAt(created.Add(time.Minute))
+
+				Expect(recorder.Events).To(Receive(ContainSubstring(ReasonDecryptFailed)))
+				Expect(meta.FindStatusCondition(sopsSecret.Status.Conditions,
+					secretsv1alpha1.ConditionTypePending)).To(BeNil())
+				ready := meta.FindStatusCondition(sopsSecret.Status.Conditions, secretsv1alpha1.ConditionTypeReady)
+				Expect(ready).NotTo(BeNil())
+				Expect(ready.Reason).To(Equal("DecryptFailed"))
+			})
+
+			It("should report a failure of a SopsSecret that was decrypted before", func() {
+				sopsSecret.Status.LastDecryptedHash = "earlier"
+				Expect(mockReconciler.Status().Update(ctx, sopsSecret)).To(Succeed())
+
+				reconcileAt(created.Add(10 * time.Second))
+				Expect(recorder.Events).To(Receive(ContainSubstring(ReasonDecryptFailed)))
+				Expect(meta.FindStatusCondition(sopsSecret.Status.Conditions,
+					secretsv1alpha1.ConditionTypePending)).To(BeNil())
+			})
+		})
+
+		Describe("Key order annotation", func() {
+			reconcileDocument := func(name, document string, omitNull bool) *corev1.Secret {
+				sopsSecret := &secretsv1alpha1.SopsSecret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:       name,
+						Namespace:  "default",
+						Finalizers: []string{finalizerName},
+					},
+					Spec: secretsv1alpha1.SopsSecretSpec{
+						SopsSecret:     document,
+						AllowPlaintext: true,
+						OmitNullValues: omitNull,
+					},
+				}
+				Expect(mockReconciler.Create(ctx, sopsSecret)).To(Succeed())
+				key := client.ObjectKeyFromObject(sopsSecret)
+				_, err := mockReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+				Expect(err).NotTo(HaveOccurred())
+				secret := &corev1.Secret{}
+				Expect(mockReconciler.Get(ctx, key, secret)).To(Succeed())
+				return secret
+			}
+
+			It("should list the keys in document order", func() {
+				secret := reconcileDocument("key-order", "zeta: 1\nalpha: 2\nmid:\n  nested: 3\n", false)
+				Expect(secret.Annotations).To(HaveKeyWithValue(keyOrderAnnotation, "zeta,alpha,mid"))
+				Expect(strings.Split(secret.Annotations[managedAnnotationsAnnotation], ",")).
+					To(ContainElement(keyOrderAnnotation))
+			})
+
+			It("should leave out keys that are not written", func() {
+				secret := reconcileDocument("key-order-null", "zeta: 1\nalpha: null\nmid: 3\n", true)
+				Expect(secret.Annotations).To(HaveKeyWithValue(keyOrderAnnotation, "zeta,mid"))
+			})
+
+			It("should not annotate Secrets whose key order is unknown", func() {
+				mockDecryptor.DecryptFunc = func(_ []byte) (*sops.DecryptedData, error) {
+					return &sops.DecryptedData{Data: map[string][]byte{"token": []byte("token: abc")}}, nil
+				}
+				sopsSecret := &secretsv1alpha1.SopsSecret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:       "key-order-unknown",
+						Namespace:  "default",
+						Finalizers: []string{finalizerName},
+					},
+					Spec: secretsv1alpha1.SopsSecretSpec{SopsSecret: "token: ENC[test]\nsops:\n    mac: test\n"},
+				}
+				Expect(mockReconciler.Create(ctx, sopsSecret)).To(Succeed())
+				key := client.ObjectKeyFromObject(sopsSecret)
+				_, err := mockReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+				Expect(err).NotTo(HaveOccurred())
+				secret := &corev1.Secret{}
+				Expect(mockReconciler.Get(ctx, key, secret)).To(Succeed())
+				Expect(secret.Annotations).NotTo(HaveKey(keyOrderAnnotation))
+			})
+		})
+
+		Describe("Audit logging", func() {
+			const plaintext = "hunter2-plaintext"
+			var auditLogger *recordingAuditLogger
+
+			BeforeEach(func() {
+				auditLogger = &recordingAuditLogger{}
+				mockReconciler.AuditLogger = auditLogger
+				mockReconciler.GlobalKeySource = secretsv1alpha1.KeySourceGlobalEnv
+			})
+
+			It("should record one event per decrypt attempt without values", func() {
+				mockDecryptor.DecryptFunc = func(_ []byte) (*sops.DecryptedData, error) {
+					return &sops.DecryptedData{Data: map[string][]byte{"password": []byte(plaintext)}}, nil
+				}
+				sopsSecret := &secretsv1alpha1.SopsSecret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:       "audited",
+						Namespace:  "default",
+						Finalizers: []string{finalizerName},
+					},
+					Spec: secretsv1alpha1.SopsSecretSpec{SopsSecret: "password: ENC[v1]\nsops:\n    mac: test\n"},
+				}
+				Expect(mockReconciler.Create(ctx, sopsSecret)).To(Succeed())
+				key := client.ObjectKeyFromObject(sopsSecret)
+
+				_, err := mockReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(auditLogger.events).To(HaveLen(1))
+				event := auditLogger.events[0]
+				Expect(event.Namespace).To(Equal("default"))
+				Expect(event.Name).To(Equal("audited"))
+				Expect(event.Outcome).To(Equal(AuditOutcomeSuccess))
+				Expect(event.KeySource).To(Equal("global-env"))
+				Expect(event.Time).NotTo(BeZero())
+				encoded, err := json.Marshal(event)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(encoded)).NotTo(ContainSubstring(plaintext))
+
+				By("not recording reconciles that do not decrypt")
+				_, err = mockReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(auditLogger.events).To(HaveLen(1))
+
+				By("recording a failed decrypt")
+				mockDecryptor.DecryptFunc = func(_ []byte) (*sops.DecryptedData, error) {
+					return nil, fmt.Errorf("sops decrypt failed: no key could decrypt the data")
+				}
+				Expect(mockReconciler.Get(ctx, key, sopsSecret)).To(Succeed())
+				sopsSecret.Spec.SopsSecret = "password: ENC[v2]\nsops:\n    mac: test\n"
+				sopsSecret.Generation = 2
+				Expect(mockReconciler.Update(ctx, sopsSecret)).To(Succeed())
+				_, err = mockReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(auditLogger.events).To(HaveLen(2))
+				Expect(auditLogger.events[1].Outcome).To(Equal(AuditOutcomeFailure))
+				Expect(auditLogger.events[1].Name).To(Equal("audited"))
+			})
+
+			It("should record a key Secret that cannot be loaded as a failure", func() {
+				sopsSecret := &secretsv1alpha1.SopsSecret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "audited-key",
+						Namespace:   "default",
+						Finalizers:  []string{finalizerName},
+						Annotations: map[string]string{ageKeySecretAnnotation: "missing-key"},
+					},
+					Spec: secretsv1alpha1.SopsSecretSpec{SopsSecret: "password: ENC[v1]\nsops:\n    mac: test\n"},
+				}
+				Expect(mockReconciler.Create(ctx, sopsSecret)).To(Succeed())
+
+				_, err := mockReconciler.Reconcile(ctx,
+					reconcile.Request{NamespacedName: client.ObjectKeyFromObject(sopsSecret)})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(auditLogger.events).To(HaveLen(1))
+				Expect(auditLogger.events[0].Outcome).To(Equal(AuditOutcomeFailure))
+				Expect(auditLogger.events[0].KeySource).To(Equal("annotation"))
+			})
+		})
+
+		Describe("Polling file sources", func() {
+			var (
+				path     string
+				decrypts int
+			)
+
+			BeforeEach(func() {
+				dir := GinkgoT().TempDir()
+				path = filepath.Join(dir, "secret.enc.yaml")
+				Expect(os.WriteFile(path, []byte("token: ENC[v1]\nsops:\n    mac: test\n"), 0600)).To(Succeed())
+				mockReconciler.EncryptedFileDirs = []string{dir}
+				mockReconciler.FilePollInterval = 30 * time.Second
+				decrypts = 0
+				mockDecryptor.DecryptFunc = func(data []byte) (*sops.DecryptedData, error) {
+					decrypts++
+					version := strings.TrimSuffix(strings.TrimPrefix(strings.SplitN(string(data), "\n", 2)[0],
+						"token: ENC["), "]")
+					return &sops.DecryptedData{Data: map[string][]byte{"token": []byte(version)}}, nil
+				}
+			})
+
+			It("should decrypt the file again once it changes", func() {
+				sopsSecret := &secretsv1alpha1.SopsSecret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:       "polled",
+						Namespace:  "default",
+						Finalizers: []string{finalizerName},
+					},
+					Spec: secretsv1alpha1.SopsSecretSpec{EncryptedFromFile: path},
+				}
+				Expect(mockReconciler.Create(ctx, sopsSecret)).To(Succeed())
+				key := client.ObjectKeyFromObject(sopsSecret)
+				secret := &corev1.Secret{}
+
+				result, err := mockReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.RequeueAfter).To(Equal(30 * time.Second))
+				Expect(mockReconciler.Get(ctx, key, secret)).To(Succeed())
+				Expect(secret.Data).To(HaveKeyWithValue("token", []byte("v1")))
+
+				By("only reading an unchanged file")
+				result, err = mockReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.RequeueAfter).To(Equal(30 * time.Second))
+				Expect(decrypts).To(Equal(1))
+
+				By("decrypting a rewritten file")
+				Expect(os.WriteFile(path, []byte("token: ENC[v2]\nsops:\n    mac: test\n"), 0600)).To(Succeed())
+				result, err = mockReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.RequeueAfter).To(Equal(30 * time.Second))
+				Expect(decrypts).To(Equal(2))
+				Expect(mockReconciler.Get(ctx, key, secret)).To(Succeed())
+				Expect(secret.Data).To(HaveKeyWithValue("token", []byte("v2")))
+			})
+
+			It("should not poll inline documents", func() {
+				sopsSecret := &secretsv1alpha1.SopsSecret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:       "not-polled",
+						Namespace:  "default",
+						Finalizers: []string{finalizerName},
+					},
+					Spec: secretsv1alpha1.SopsSecretSpec{SopsSecret: "token: ENC[v1]\nsops:\n    mac: test\n"},
+				}
+				Expect(mockReconciler.Create(ctx, sopsSecret)).To(Succeed())
+
+				result, err := mockReconciler.Reconcile(ctx,
+					reconcile.Request{NamespacedName: client.ObjectKeyFromObject(sopsSecret)})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.RequeueAfter).To(Equal(5 * time.Minute))
+			})
+		})
+
+		Describe("Namespace metric labels", func() {
+			reconcileOnce := func(name string, decryptErr error) {
+				mockDecryptor.DecryptFunc = func(_ []byte) (*sops.DecryptedData, error) {
+					if decryptErr != nil {
+						return nil, decryptErr
+					}
+					return &sops.DecryptedData{Data: map[string][]byte{"password": []byte("secret")}}, nil
+				}
+				sopsSecret := &secretsv1alpha1.SopsSecret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:       name,
+						Namespace:  "team-metrics",
+						Finalizers: []string{finalizerName},
+					},
+					Spec: secretsv1alpha1.SopsSecretSpec{SopsSecret: "password: ENC[test]\nsops:\n    mac: test\n"},
+				}
+				Expect(mockReconciler.Create(ctx, sopsSecret)).To(Succeed())
+				_, err := mockReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(sopsSecret)})
+				Expect(err).NotTo(HaveOccurred())
+			}
+			decrypts := func(namespace, outcome string) float64 {
+				return testutil.ToFloat64(decryptAttempts.WithLabelValues(namespace, outcome))
+			}
+			triggers := func(namespace string) float64 {
+				return testutil.ToFloat64(reconcileTriggers.WithLabelValues(namespace, triggerInitial))
+			}
+
+			It("should label reconcile and decrypt counters with the namespace", func() {
+				successes, failures := decrypts("team-metrics", AuditOutcomeSuccess), decrypts("team-metrics", AuditOutcomeFailure)
+				initial, unlabeled := triggers("team-metrics"), triggers("")
+
+				reconcileOnce("labeled-ok", nil)
+				reconcileOnce("labeled-fail", fmt.Errorf("sops decrypt failed: no key could decrypt the data"))
+
+				Expect(decrypts("team-metrics", AuditOutcomeSuccess)).To(Equal(successes + 1))
+				Expect(decrypts("team-metrics", AuditOutcomeFailure)).To(Equal(failures + 1))
+				Expect(triggers("team-metrics")).To(Equal(initial + 2))
+				Expect(triggers("")).To(Equal(unlabeled))
+			})
+
+			It("should leave the namespace label empty with DisableNamespaceLabels", func() {
+				mockReconciler.DisableNamespaceLabels = true
+				labeled := decrypts("team-metrics", AuditOutcomeSuccess) + decrypts("team-metrics", AuditOutcomeFailure)
+				successes, failures := decrypts("", AuditOutcomeSuccess), decrypts("", AuditOutcomeFailure)
+				initial, labeledTriggers := triggers(""), triggers("team-metrics")
+
+				reconcileOnce("unlabeled-ok", nil)
+				reconcileOnce("unlabeled-fail", fmt.Errorf("sops decrypt failed: no key could decrypt the data"))
+
+				Expect(decrypts("", AuditOutcomeSuccess)).To(Equal(successes + 1))
+				Expect(decrypts("", AuditOutcomeFailure)).To(Equal(failures + 1))
+				Expect(triggers("")).To(Equal(initial + 2))
+				Expect(decrypts("team-metrics", AuditOutcomeSuccess) + decrypts("team-metrics", AuditOutcomeFailure)).To(Equal(labeled))
+				Expect(triggers("team-metrics")).To(Equal(labeledTriggers))
+			})
+		})
+
+		Describe("Reconcile interval", func() {
+			reconcileOnce := func(name string, interval *metav1.Duration) time.Duration {
+				mockDecryptor.DecryptFunc = func(_ []byte) (*sops.DecryptedData, error) {
+					return &sops.DecryptedData{Data: map[string][]byte{"password": []byte("secret")}}, nil
+				}
+				sopsSecret := &secretsv1alpha1.SopsSecret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:       name,
+						Namespace:  "default",
+						Finalizers: []string{finalizerName},
+					},
+					Spec: secretsv1alpha1.SopsSecretSpec{
+						SopsSecret:        "password: ENC[test]\nsops:\n    mac: test\n",
+						ReconcileInterval: interval,
+					},
+				}
+				Expect(mockReconciler.Create(ctx, sopsSecret)).To(Succeed())
+				result, err := mockReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(sopsSecret)})
+				Expect(err).NotTo(HaveOccurred())
+				return result.RequeueAfter
+			}
+
+			It("should requeue after ReconcileInterval", func() {
+				mockReconciler.ReconcileInterval = 30 * time.Second
+				Expect(reconcileOnce("interval-flag", nil)).To(Equal(30 * time.Second))
+			})
+
+			It("should prefer spec.reconcileInterval", func() {
+				mockReconciler.ReconcileInterval = 30 * time.Second
+				Expect(reconcileOnce("interval-spec", &metav1.Duration{Duration: time.Hour})).To(Equal(time.Hour))
+			})
+		})
+
+		Describe("Secret verifier", func() {
+			password := "first"
+			var verified []string
+
+			BeforeEach(func() {
+				password = "first"
+				verified = nil
+				mockDecryptor.DecryptFunc = func(_ []byte) (*sops.DecryptedData, error) {
+					return &sops.DecryptedData{Data: map[string][]byte{"password": []byte(password)}}, nil
+				}
+			})
+
+			newVerified := func(name string) (*secretsv1alpha1.SopsSecret, types.NamespacedName) {
+				sopsSecret := &secretsv1alpha1.SopsSecret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:       name,
+						Namespace:  "default",
+						Finalizers: []string{finalizerName},
+					},
+					Spec: secretsv1alpha1.SopsSecretSpec{SopsSecret: "password: ENC[v1]\nsops:\n    mac: test\n"},
+				}
+				Expect(mockReconciler.Create(ctx, sopsSecret)).To(Succeed())
+				return sopsSecret, client.ObjectKeyFromObject(sopsSecret)
+			}
+
+			It("should write the Secret when the verifier accepts the data", func() {
+				mockReconciler.Verifier = verifierFunc(func(_ context.Context, data *sops.DecryptedData) error {
+					verified = append(verified, string(data.Data["password"]))
+					return nil
+				})
+				sopsSecret, key := newVerified("verified")
+
+				_, err := mockReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(verified).To(Equal([]string{"first"}))
+
+				secret := &corev1.Secret{}
+				Expect(mockReconciler.Get(ctx, key, secret)).To(Succeed())
+				Expect(secret.Data).To(HaveKeyWithValue("password", []byte("first")))
+				Expect(mockReconciler.Get(ctx, key, sopsSecret)).To(Succeed())
+				Expect(meta.IsStatusConditionTrue(sopsSecret.Status.Conditions, secretsv1alpha1.ConditionTypeReady)).To(BeTrue())
+				Expect(meta.FindStatusCondition(sopsSecret.Status.Conditions,
+					secretsv1alpha1.ConditionTypeVerificationFailed)).To(BeNil())
+			})
+
+			It("should keep the last verified Secret when the verifier rejects the data", func() {
+				var rejectErr error
+				mockReconciler.Verifier = verifierFunc(func(_ context.Context, data *sops.DecryptedData) error {
+					verified = append(verified, string(data.Data["password"]))
+					return rejectErr
+				})
+				sopsSecret, key := newVerified("verify-rejected")
+
+				_, err := mockReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+				Expect(err).NotTo(HaveOccurred())
+
+				By("rejecting a new password")
+				recorder := events.NewFakeRecorder(20)
+				mockReconciler.Recorder = recorder
+				password = "second"
+				rejectErr = fmt.Errorf("connection refused")
+				Expect(mockReconciler.Get(ctx, key, sopsSecret)).To(Succeed())
+				sopsSecret.Spec.SopsSecret = "password: ENC[v2]\nsops:\n    mac: test\n"
+				sopsSecret.Generation = 2
+				Expect(mockReconciler.Update(ctx, sopsSecret)).To(Succeed())
+				result, err := mockReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.RequeueAfter).To(BeNumerically(">", 0))
+				Expect(verified).To(Equal([]string{"first", "second"}))
+
+				secret := &corev1.Secret{}
+				Expect(mockReconciler.Get(ctx, key, secret)).To(Succeed())
+				Expect(secret.Data).To(HaveKeyWithValue("password", []byte("first")))
+				Expect(mockReconciler.Get(ctx, key, sopsSecret)).To(Succeed())
+				cond := meta.FindStatusCondition(sopsSecret.Status.Conditions, secretsv1alpha1.ConditionTypeVerificationFailed)
+				Expect(cond).NotTo(BeNil())
+				Expect(cond.Status).To(Equal(metav1.ConditionTrue))
+				Expect(cond.Message).To(ContainSubstring("connection refused"))
+				ready := meta.FindStatusCondition(sopsSecret.Status.Conditions, secretsv1alpha1.ConditionTypeReady)
+				Expect(ready.Status).To(Equal(metav1.ConditionFalse))
+				Expect(ready.Reason).To(Equal(ReasonVerificationFailed))
+				Expect(recorder.Events).To(Receive(ContainSubstring("Decrypted")))
+				Expect(recorder.Events).To(Receive(ContainSubstring(ReasonVerificationFailed)))
+
+				By("writing the password once the verifier accepts it")
+				rejectErr = nil
+				_, err = mockReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(mockReconciler.Get(ctx, key, secret)).To(Succeed())
+				Expect(secret.Data).To(HaveKeyWithValue("password", []byte("second")))
+				Expect(mockReconciler.Get(ctx, key, sopsSecret)).To(Succeed())
+				Expect(meta.FindStatusCondition(sopsSecret.Status.Conditions,
+					secretsv1alpha1.ConditionTypeVerificationFailed)).To(BeNil())
+				Expect(meta.IsStatusConditionTrue(sopsSecret.Status.Conditions, secretsv1alpha1.ConditionTypeReady)).To(BeTrue())
+			})
+		})
+
+		Describe("Verbose errors", func() {
+			commandErr := &sops.CommandError{
+				Args:     []string{"-d", "/tmp/sops-1.yaml"},
+				EnvNames: []string{"PATH", "SOPS_AGE_KEY"},
+				Err:      fmt.Errorf("sops decrypt failed: exit status 128: no key could decrypt the data"),
+			}
+
+			reconcileFailing := func(name string) (*secretsv1alpha1.SopsSecret, types.NamespacedName) {
+				mockDecryptor.DecryptFunc = func(_ []byte) (*sops.DecryptedData, error) {
+					return nil, commandErr
+				}
+				sopsSecret := &secretsv1alpha1.SopsSecret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:       name,
+						Namespace:  "default",
+						Finalizers: []string{finalizerName},
+					},
+					Spec: secretsv1alpha1.SopsSecretSpec{SopsSecret: "password: ENC[test]\nsops:\n    mac: test\n"},
+				}
+				Expect(mockReconciler.Create(ctx, sopsSecret)).To(Succeed())
+				key := client.ObjectKeyFromObject(sopsSecret)
+				_, err := mockReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(mockReconciler.Get(ctx, key, sopsSecret)).To(Succeed())
+				return sopsSecret, key
+			}
+
+			It("should record the sops invocation with VerboseErrors", func() {
+				mockReconciler.VerboseErrors = true
+				sopsSecret, key := reconcileFailing("verbose")
+
+				Expect(sopsSecret.Status.LastErrorDetail).To(Equal(commandErr.Detail()))
+				Expect(sopsSecret.Status.LastErrorDetail).To(ContainSubstring("command: sops -d /tmp/sops-1.yaml"))
+				Expect(sopsSecret.Status.LastErrorDetail).To(ContainSubstring("env: PATH SOPS_AGE_KEY"))
+				decrypted := meta.FindStatusCondition(sopsSecret.Status.Conditions, secretsv1alpha1.ConditionTypeDecrypted)
+				Expect(decrypted.Message).To(Equal(commandErr.Error()))
+
+				By("clearing the detail after a successful decrypt")
+				mockDecryptor.DecryptFunc = func(_ []byte) (*sops.DecryptedData, error) {
+					return &sops.DecryptedData{Data: map[string][]byte{"password": []byte("secret")}}, nil
+				}
+				_, err := mockReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(mockReconciler.Get(ctx, key, sopsSecret)).To(Succeed())
+				Expect(sopsSecret.Status.LastErrorDetail).To(BeEmpty())
+			})
+
+			It("should leave the detail empty without VerboseErrors", func() {
+				sopsSecret, _ := reconcileFailing("not-verbose")
+
+				Expect(sopsSecret.Status.LastErrorDetail).To(BeEmpty())
+				Expect(meta.IsStatusConditionFalse(sopsSecret.Status.Conditions,
+					secretsv1alpha1.ConditionTypeDecrypted)).To(BeTrue())
+			})
+		})
+
+		Describe("Weak MAC warning", func() {
+			document := func(version string) string {
+				return "password: ENC[test]\nsops:\n    mac: ENC[test]\n    version: " + version + "\n"
+			}
+
+			BeforeEach(func() {
+				mockDecryptor.DecryptFunc = func(_ []byte) (*sops.DecryptedData, error) {
+					return &sops.DecryptedData{Data: map[string][]byte{"password": []byte("secret")}}, nil
+				}
+			})
+
+			reconcileDocument := func(name, doc string) *secretsv1alpha1.SopsSecret {
+				sopsSecret := &secretsv1alpha1.SopsSecret{}
+				key := types.NamespacedName{Namespace: "default", Name: name}
+				if err := mockReconciler.Get(ctx, key, sopsSecret); errors.IsNotFound(err) {
+					sopsSecret = &secretsv1alpha1.SopsSecret{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:       name,
+							Namespace:  "default",
+							Finalizers: []string{finalizerName},
+						},
+						Spec: secretsv1alpha1.SopsSecretSpec{SopsSecret: doc},
+					}
+					Expect(mockReconciler.Create(ctx, sopsSecret)).To(Succeed())
+				} else {
+					Expect(err).NotTo(HaveOccurred())
+					sopsSecret.Spec.SopsSecret = doc
+					sopsSecret.Generation++
+					Expect(mockReconciler.Update(ctx, sopsSecret)).To(Succeed())
+				}
+				_, err := mockReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(mockReconciler.Get(ctx, key, sopsSecret)).To(Succeed())
+				return sopsSecret
+			}
+
+			It("should warn about documents from sops releases older than the minimum", func() {
+				mockReconciler.MinSopsVersion = "3.8.0"
+
+				sopsSecret := reconcileDocument("old-sops", document("3.7.3"))
+				cond := meta.FindStatusCondition(sopsSecret.Status.Conditions, secretsv1alpha1.ConditionTypeWeakMACWarning)
+				Expect(cond).NotTo(BeNil())
+				Expect(cond.Status).To(Equal(metav1.ConditionTrue))
+				Expect(cond.Message).To(ContainSubstring("sops 3.7.3, older than 3.8.0"))
+				Expect(meta.IsStatusConditionTrue(sopsSecret.Status.Conditions, secretsv1alpha1.ConditionTypeReady)).To(BeTrue())
+
+				By("clearing the warning once the document is re-encrypted")
+				sopsSecret = reconcileDocument("old-sops", document("3.9.0"))
+				Expect(meta.FindStatusCondition(sopsSecret.Status.Conditions,
+					secretsv1alpha1.ConditionTypeWeakMACWarning)).To(BeNil())
+
+				By("warning about a MAC that only covers encrypted values")
+				sopsSecret = reconcileDocument("old-sops", document("3.9.0")+"    mac_only_encrypted: true\n")
+				cond = meta.FindStatusCondition(sopsSecret.Status.Conditions, secretsv1alpha1.ConditionTypeWeakMACWarning)
+				Expect(cond).NotTo(BeNil())
+				Expect(cond.Message).To(ContainSubstring("only covers the encrypted values"))
+			})
+
+			It("should not warn without a minimum version", func() {
+				sopsSecret := reconcileDocument("old-sops-unchecked", document("3.0.0"))
+				Expect(meta.FindStatusCondition(sopsSecret.Status.Conditions,
+					secretsv1alpha1.ConditionTypeWeakMACWarning)).To(BeNil())
+			})
+		})
+
+		Describe("Event references", func() {
+			It("should name the SopsSecret as regarding and the Secret as related", func() {
+				recorder := &referenceRecorder{}
+				mockReconciler.Recorder = recorder
+				password := "first"
+				mockDecryptor.DecryptFunc = func(_ []byte) (*sops.DecryptedData, error) {
+					return &sops.DecryptedData{Data: map[string][]byte{"password": []byte(password)}}, nil
+				}
+				sopsSecret := &secretsv1alpha1.SopsSecret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:       "referenced",
+						Namespace:  "default",
+						Finalizers: []string{finalizerName},
+					},
+					Spec: secretsv1alpha1.SopsSecretSpec{
+						SopsSecret: "password: ENC[v1]\nsops:\n    mac: test\n",
+						SecretName: "referenced-secret",
+					},
+				}
+				Expect(mockReconciler.Create(ctx, sopsSecret)).To(Succeed())
+				key := client.ObjectKeyFromObject(sopsSecret)
+				_, err := mockReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+				Expect(err).NotTo(HaveOccurred())
+
+				password = "second"
+				Expect(mockReconciler.Get(ctx, key, sopsSecret)).To(Succeed())
+				sopsSecret.Spec.SopsSecret = "password: ENC[v2]\nsops:\n    mac: test\n"
+				sopsSecret.Generation = 2
+				Expect(mockReconciler.Update(ctx, sopsSecret)).To(Succeed())
+				_, err = mockReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+				Expect(err).NotTo(HaveOccurred())
+
+				byReason := map[string]referencedEvent{}
+				for _, event := range recorder.events {
+					byReason[event.reason] = event
+				}
+				for _, reason := range []string{ReasonSecretCreated, ReasonSecretUpdated} {
+					Expect(byReason).To(HaveKey(reason))
+					regarding, ok := byReason[reason].regarding.(*secretsv1alpha1.SopsSecret)
+					Expect(ok).To(BeTrue(), reason)
+					Expect(regarding.Name).To(Equal("referenced"))
+					related, ok := byReason[reason].related.(*corev1.Secret)
+					Expect(ok).To(BeTrue(), reason)
+					Expect(related.Name).To(Equal("referenced-secret"))
+					Expect(related.Namespace).To(Equal("default"))
+				}
+				Expect(byReason[ReasonDecrypted].related).To(BeNil())
+			})
+		})
+	})
+
+	Context("Error handling with ErrorClient", func() {
+		var (
+			ctx context.Context
+		)
+
+		BeforeEach(func() {
+			ctx = context.Background()
+		})
+
+		Describe("Reconcile error paths", func() {
+			It("should return error when Get SopsSecret fails (non-NotFound)", func() {
+				fakeClient := fake.NewClientBuilder().
+					WithScheme(scheme.Scheme).
+					Build()
+
+				errorClient := &ErrorClient{
+					Client:   fakeClient,
+					GetError: fmt.Errorf("connection refused"),
+				}
+
+				reconciler := &SopsSecretReconciler{
+					Client:    errorClient,
+					Scheme:    scheme.Scheme,
+					Recorder:  &events.FakeRecorder{},
+					Decryptor: &MockDecryptor{},
+				}
+
+				req := reconcile.Request{
+					NamespacedName: types.NamespacedName{
+						Name:      "test-secret",
+						Namespace: "default",
+					},
+				}
+
+				result, err := reconciler.Reconcile(ctx, req)
+
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("connection refused"))
+				Expect(result).To(Equal(ctrl.Result{}))
+			})
+
+			It("should return error when adding finalizer fails", func() {
+				fakeClient := fake.NewClientBuilder().
+					WithScheme(scheme.Scheme).
+					WithStatusSubresource(&secretsv1alpha1.SopsSecret{}).
+					Build()
+
+				// Create the SopsSecret first
+				sopsSecret := &secretsv1alpha1.SopsSecret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "finalizer-error",
+						Namespace: "default",
+					},
+					Spec: secretsv1alpha1.SopsSecretSpec{
+						SopsSecret: `test: value
+sops:
+    mac: test
+`,
+					},
+				}
+				Expect(fakeClient.Create(ctx, sopsSecret)).To(Succeed())
+
+				errorClient := &ErrorClient{
+					Client:      fakeClient,
+					UpdateError: fmt.Errorf("update failed"),
+				}
+
+				reconciler := &SopsSecretReconciler{
+					Client:    errorClient,
+					Scheme:    scheme.Scheme,
+					Recorder:  &events.FakeRecorder{},
+					Decryptor: &MockDecryptor{},
+				}
+
+				req := reconcile.Request{
+					NamespacedName: types.NamespacedName{
+						Name:      "finalizer-error",
+						Namespace: "default",
+					},
+				}
+
+				result, err := reconciler.Reconcile(ctx, req)
+
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("update failed"))
+				Expect(result).To(Equal(ctrl.Result{}))
+			})
+
+			It("should return error when checking existing secret fails (non-NotFound)", func() {
+				fakeClient := fake.NewClientBuilder().
+					WithScheme(scheme.Scheme).
+					WithStatusSubresource(&secretsv1alpha1.SopsSecret{}).
+					Build()
+
+				hash := calculateHash(`test: value
+sops:
+    mac: test
+`)
+				sopsSecret := &secretsv1alpha1.SopsSecret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:       "get-secret-error",
+						Namespace:  "default",
+						Finalizers: []string{finalizerName},
+						Generation: 1,
+					},
+					Spec: secretsv1alpha1.SopsSecretSpec{
+						SopsSecret: `test: value
+sops:
+    mac: test
+`,
+					},
+					Status: secretsv1alpha1.SopsSecretStatus{
+						LastDecryptedHash:  hash,
+						ObservedGeneration: 1,
+					},
+				}
+				Expect(fakeClient.Create(ctx, sopsSecret)).To(Succeed())
+
+				errorClient := &ErrorClient{
+					Client:         fakeClient,
+					GetError:       fmt.Errorf("network timeout"),
+					GetErrorOnCall: 2, // Error on second Get (checking secret)
+				}
+
+				reconciler := &SopsSecretReconciler{
+					Client:    errorClient,
+					Scheme:    scheme.Scheme,
+					Recorder:  &events.FakeRecorder{},
+					Decryptor: &MockDecryptor{},
+				}
+
+				req := reconcile.Request{
+					NamespacedName: types.NamespacedName{
+						Name:      "get-secret-error",
+						Namespace: "default",
+					},
+				}
+
+				result, err := reconciler.Reconcile(ctx, req)
+
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("network timeout"))
+				Expect(result).To(Equal(ctrl.Result{}))
 			})
 
 			It("should return error when creating secret fails", func() {
@@ -1488,6 +6735,62 @@ missing: sops_block
 			})
 		})
 
+		Describe("Status subresource detection", func() {
+			newSopsSecret := func(name string) *secretsv1alpha1.SopsSecret {
+				return &secretsv1alpha1.SopsSecret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:       name,
+						Namespace:  "default",
+						Finalizers: []string{finalizerName},
+					},
+					Spec: secretsv1alpha1.SopsSecretSpec{
+						SopsSecret: "invalid",
+					},
+				}
+			}
+
+			expectStatusNotPersisted := func(c client.Client, sopsSecret *secretsv1alpha1.SopsSecret) {
+				recorder := events.NewFakeRecorder(10)
+				reconciler := &SopsSecretReconciler{
+					Client:    c,
+					Scheme:    scheme.Scheme,
+					Recorder:  recorder,
+					Decryptor: &MockDecryptor{},
+				}
+
+				result, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(sopsSecret)})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.RequeueAfter).To(Equal(5 * time.Minute))
+
+				var recorded []string
+				for len(recorder.Events) > 0 {
+					recorded = append(recorded, <-recorder.Events)
+				}
+				Expect(recorded).To(ContainElement(ContainSubstring(ReasonStatusNotPersisted)))
+			}
+
+			It("should report status updates the API server silently drops", func() {
+				sopsSecret := newSopsSecret("status-discarded")
+				fakeClient := fake.NewClientBuilder().
+					WithScheme(scheme.Scheme).
+					WithStatusSubresource(&secretsv1alpha1.SopsSecret{}).
+					WithObjects(sopsSecret).
+					Build()
+
+				expectStatusNotPersisted(&DiscardingStatusClient{Client: fakeClient}, sopsSecret)
+			})
+
+			It("should report a missing status endpoint", func() {
+				sopsSecret := newSopsSecret("status-missing")
+				fakeClient := fake.NewClientBuilder().
+					WithScheme(scheme.Scheme).
+					WithObjects(sopsSecret).
+					Build()
+
+				expectStatusNotPersisted(fakeClient, sopsSecret)
+			})
+		})
+
 		Describe("SetupWithManager", func() {
 			It("should return error with nil manager", func() {
 				reconciler := &SopsSecretReconciler{
@@ -1552,3 +6855,55 @@ sops:
 		})
 	})
 })
+
+// transformerFunc adapts a function to SecretValueTransformer.
+type transformerFunc func(ctx context.Context, key string, value []byte) ([]byte, error)
+
+func (f transformerFunc) Transform(ctx context.Context, key string, value []byte) ([]byte, error) {
+	return f(ctx, key, value)
+}
+
+// verifierFunc adapts a function to SecretVerifier.
+type verifierFunc func(ctx context.Context, data *sops.DecryptedData) error
+
+func (f verifierFunc) Verify(ctx context.Context, data *sops.DecryptedData) error {
+	return f(ctx, data)
+}
+
+// referencedEvent is an event captured by referenceRecorder.
+type referencedEvent struct {
+	regarding runtime.Object
+	related   runtime.Object
+	reason    string
+}
+
+// referenceRecorder captures the objects events refer to, which
+// events.FakeRecorder drops.
+type referenceRecorder struct {
+	events []referencedEvent
+}
+
+func (r *referenceRecorder) Eventf(regarding, related runtime.Object, _, reason, _, _ string, _ ...any) {
+	r.events = append(r.events, referencedEvent{regarding: regarding, related: related, reason: reason})
+}
+
+// conditionValue returns the sopssecret_condition value for one status of a condition.
+func conditionValue(key types.NamespacedName, condType string, status metav1.ConditionStatus) float64 {
+	return testutil.ToFloat64(conditionGauge.WithLabelValues(key.Namespace, key.Name, condType, string(status)))
+}
+
+// recipientEncryptor stands in for sops: it binds the ciphertext to the
+// recipient so a test can check the backup only opens with that recipient.
+type recipientEncryptor struct{}
+
+func (recipientEncryptor) EncryptWithContext(_ context.Context, plaintext []byte, recipients []string) ([]byte, error) {
+	return []byte(recipients[0] + ":" + base64.StdEncoding.EncodeToString(plaintext)), nil
+}
+
+func (recipientEncryptor) decrypt(ciphertext []byte, recipient string) ([]byte, error) {
+	encoded, ok := strings.CutPrefix(string(ciphertext), recipient+":")
+	if !ok {
+		return nil, fmt.Errorf("not encrypted to %s", recipient)
+	}
+	return base64.StdEncoding.DecodeString(encoded)
+}
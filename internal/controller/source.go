@@ -0,0 +1,186 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	secretsv1alpha1 "github.com/scalaric/sops-operator/api/v1alpha1"
+	"github.com/scalaric/sops-operator/pkg/sops"
+)
+
+// encryptedPayload returns the SOPS-encrypted document for the SopsSecret,
+// either inline from spec.sopsSecret, read from spec.encryptedFromFile or
+// assembled from spec.encryptedFromChunks.
+func (r *SopsSecretReconciler) encryptedPayload(ctx context.Context, sopsSecret *secretsv1alpha1.SopsSecret) ([]byte, error) {
+	if len(sopsSecret.Spec.EncryptedFromChunks) > 0 {
+		return r.assembleChunks(ctx, sopsSecret)
+	}
+	if sopsSecret.Spec.EncryptedFromFile == "" {
+		return []byte(sopsSecret.Spec.SopsSecret), nil
+	}
+
+	dir, rel, err := r.resolveEncryptedFile(sopsSecret.Spec.EncryptedFromFile)
+	if err != nil {
+		return nil, err
+	}
+	data, err := readInDir(dir, rel)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read encrypted file %s: %w", sopsSecret.Spec.EncryptedFromFile, err)
+	}
+	return data, nil
+}
+
+// decryptPayload decrypts payload. A MAC mismatch on a document read from a
+// file or chunks may come from a read that overlapped an update of the
+// source, so the source is read once more and decrypted again if it changed.
+// It returns the payload that was decrypted last.
+func (r *SopsSecretReconciler) decryptPayload(
+	ctx context.Context, sopsSecret *secretsv1alpha1.SopsSecret, decryptor sops.DecryptorInterface, payload []byte,
+) ([]byte, *sops.DecryptedData, error) {
+	decrypted, err := decryptor.DecryptWithContext(ctx, payload)
+	external := sopsSecret.Spec.EncryptedFromFile != "" || len(sopsSecret.Spec.EncryptedFromChunks) > 0
+	if !external || !sops.IsMACMismatch(err) {
+		return payload, decrypted, err
+	}
+
+	// Report the mismatch if the source cannot be read or is unchanged
+	refreshed, readErr := r.encryptedPayload(ctx, sopsSecret)
+	if readErr != nil || bytes.Equal(refreshed, payload) {
+		return payload, nil, err
+	}
+	logf.FromContext(ctx).Info("Source changed during decrypt, decrypting it again")
+	decrypted, err = decryptor.DecryptWithContext(ctx, refreshed)
+	return refreshed, decrypted, err
+}
+
+// fileRequeue returns when to reconcile a SopsSecret with an encryptedFromFile
+// source again, at the latest after FilePollInterval. A changed file has a
+// new hash and is decrypted again, an unchanged one only costs a read.
+func (r *SopsSecretReconciler) fileRequeue(sopsSecret *secretsv1alpha1.SopsSecret, after time.Duration) time.Duration {
+	if r.FilePollInterval <= 0 || sopsSecret.Spec.EncryptedFromFile == "" || sopsSecret.Spec.Suspend ||
+		!sopsSecret.DeletionTimestamp.IsZero() {
+		return after
+	}
+	if after == 0 || r.FilePollInterval < after {
+		return r.FilePollInterval
+	}
+	return after
+}
+
+// resolveEncryptedFile resolves path, following symlinks, and checks that it
+// lies inside one of EncryptedFileDirs. It returns the resolved directory and
+// the path of the file relative to it.
+func (r *SopsSecretReconciler) resolveEncryptedFile(path string) (string, string, error) {
+	if len(r.EncryptedFileDirs) == 0 {
+		return "", "", fmt.Errorf("encryptedFromFile is disabled, start the operator with --encrypted-file-dirs to allow it")
+	}
+	if !filepath.IsAbs(path) {
+		return "", "", fmt.Errorf("encryptedFromFile %s must be an absolute path", path)
+	}
+
+	resolved, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to resolve encrypted file %s: %w", path, err)
+	}
+	for _, dir := range r.EncryptedFileDirs {
+		allowed, err := filepath.EvalSymlinks(dir)
+		if err != nil {
+			continue
+		}
+		if rel, err := filepath.Rel(allowed, resolved); err == nil && filepath.IsLocal(rel) {
+			return allowed, rel, nil
+		}
+	}
+	return "", "", fmt.Errorf("encryptedFromFile %s is outside the allowed directories", path)
+}
+
+// readInDir reads the file rel inside dir through an os.Root, so a path
+// component swapped for a symlink after resolveEncryptedFile checked it cannot
+// lead the read out of dir.
+func readInDir(dir, rel string) ([]byte, error) {
+	root, err := os.OpenRoot(dir)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = root.Close() }()
+	return root.ReadFile(rel)
+}
+
+// chunkError reports encryptedFromChunks chunks that are missing or do not
+// form a complete, ordered document. Reason is used for the ChunksComplete
+// condition.
+type chunkError struct {
+	reason  string
+	message string
+}
+
+func (e *chunkError) Error() string {
+	return e.message
+}
+
+// assembleChunks concatenates the encryptedFromChunks chunks in index order.
+// The indexes must run from 0 without gaps or duplicates, the order in the
+// spec does not matter.
+func (r *SopsSecretReconciler) assembleChunks(ctx context.Context, sopsSecret *secretsv1alpha1.SopsSecret) ([]byte, error) {
+	chunks := slices.Clone(sopsSecret.Spec.EncryptedFromChunks)
+	slices.SortStableFunc(chunks, func(a, b secretsv1alpha1.ConfigMapChunkRef) int {
+		return cmp.Compare(a.Index, b.Index)
+	})
+	for i, chunk := range chunks {
+		if i > 0 && chunk.Index == chunks[i-1].Index {
+			return nil, &chunkError{"DuplicateChunk", fmt.Sprintf("Chunk %d is listed more than once", chunk.Index)}
+		}
+		if int(chunk.Index) != i {
+			return nil, &chunkError{"MissingChunk", fmt.Sprintf("Chunk %d is missing", i)}
+		}
+	}
+
+	var payload []byte
+	for _, chunk := range chunks {
+		configMap := &corev1.ConfigMap{}
+		err := r.Get(ctx, types.NamespacedName{Namespace: sopsSecret.Namespace, Name: chunk.Name}, configMap)
+		if apierrors.IsNotFound(err) {
+			return nil, &chunkError{"ChunkNotFound",
+				fmt.Sprintf("ConfigMap %s for chunk %d not found", chunk.Name, chunk.Index)}
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get ConfigMap %s for chunk %d: %w", chunk.Name, chunk.Index, err)
+		}
+		if data, ok := configMap.Data[chunk.Key]; ok {
+			payload = append(payload, data...)
+		} else if data, ok := configMap.BinaryData[chunk.Key]; ok {
+			payload = append(payload, data...)
+		} else {
+			return nil, &chunkError{"ChunkNotFound",
+				fmt.Sprintf("ConfigMap %s has no key %s for chunk %d", chunk.Name, chunk.Key, chunk.Index)}
+		}
+	}
+	return payload, nil
+}
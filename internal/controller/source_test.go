@@ -0,0 +1,58 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestReadInDirRejectsSwappedSymlink(t *testing.T) {
+	allowed := t.TempDir()
+	outside := t.TempDir()
+	if err := os.WriteFile(filepath.Join(outside, "secret.enc.yaml"), []byte("outside"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	sub := filepath.Join(allowed, "sub")
+	if err := os.Mkdir(sub, 0o700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(sub, "secret.enc.yaml"), []byte("inside"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	r := &SopsSecretReconciler{EncryptedFileDirs: []string{allowed}}
+	dir, rel, err := r.resolveEncryptedFile(filepath.Join(sub, "secret.enc.yaml"))
+	if err != nil {
+		t.Fatalf("resolveEncryptedFile() error = %v", err)
+	}
+	if data, err := readInDir(dir, rel); err != nil || string(data) != "inside" {
+		t.Fatalf("readInDir() = %q, %v, want the file inside", data, err)
+	}
+
+	// Swap the checked directory for a symlink leaving the allowed directory
+	if err := os.RemoveAll(sub); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(outside, sub); err != nil {
+		t.Fatal(err)
+	}
+	if data, err := readInDir(dir, rel); err == nil {
+		t.Errorf("readInDir() after the swap = %q, want an error", data)
+	}
+}
@@ -0,0 +1,60 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	secretsv1alpha1 "github.com/scalaric/sops-operator/api/v1alpha1"
+)
+
+// stabilizeReady records the start of a failure streak in
+// status.firstFailureTime and reports whether Ready may change to status now.
+// Recovering is immediate. Leaving Ready=True is held back until the failures
+// have lasted for ConditionStabilizationWindow, so a single transient error
+// does not flap the condition.
+func (r *SopsSecretReconciler) stabilizeReady(sopsSecret *secretsv1alpha1.SopsSecret, status metav1.ConditionStatus, now time.Time) bool {
+	if status == metav1.ConditionTrue {
+		sopsSecret.Status.FirstFailureTime = nil
+		return true
+	}
+	if sopsSecret.Status.FirstFailureTime == nil {
+		sopsSecret.Status.FirstFailureTime = &metav1.Time{Time: now}
+	}
+	if r.ConditionStabilizationWindow <= 0 ||
+		!meta.IsStatusConditionTrue(sopsSecret.Status.Conditions, secretsv1alpha1.ConditionTypeReady) {
+		return true
+	}
+	return now.Sub(sopsSecret.Status.FirstFailureTime.Time) >= r.ConditionStabilizationWindow
+}
+
+// stabilizationRequeue shortens after so that a held back Ready=False is
+// applied once the stabilization window has elapsed.
+func (r *SopsSecretReconciler) stabilizationRequeue(sopsSecret *secretsv1alpha1.SopsSecret, after time.Duration, now time.Time) time.Duration {
+	first := sopsSecret.Status.FirstFailureTime
+	if r.ConditionStabilizationWindow <= 0 || first == nil ||
+		!meta.IsStatusConditionTrue(sopsSecret.Status.Conditions, secretsv1alpha1.ConditionTypeReady) {
+		return after
+	}
+	if remaining := r.ConditionStabilizationWindow - now.Sub(first.Time); remaining > 0 && remaining < after {
+		return remaining
+	}
+	return after
+}
@@ -0,0 +1,112 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	// defaultFailFastSamples is how many initial decrypts are judged.
+	defaultFailFastSamples = 10
+	// defaultFailFastRatio is the fraction of failed initial decrypts that stops the operator.
+	defaultFailFastRatio = 0.5
+	// defaultFailFastWindow is how long after the first decrypt the check stays armed.
+	defaultFailFastWindow = 5 * time.Minute
+)
+
+// startupCheck watches the first decrypts after startup. A high failure
+// ratio usually means the operator runs with the wrong keys, for example
+// after a key rotation, so it exits instead of failing every SopsSecret.
+type startupCheck struct {
+	mu        sync.Mutex
+	startedAt time.Time
+	attempts  int
+	failures  int
+	done      bool
+
+	// now and exit are replaced in tests
+	now  func() time.Time
+	exit func(code int)
+}
+
+// recordStartupDecrypt counts a decrypt result towards the startup check and
+// exits the process once the failure threshold is reached.
+func (r *SopsSecretReconciler) recordStartupDecrypt(ctx context.Context, decryptErr error) {
+	if !r.FailFastOnStartup {
+		return
+	}
+	samples, ratio, window := r.failFastSettings()
+
+	c := &r.startup
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.done {
+		return
+	}
+	if c.now == nil {
+		c.now = time.Now
+	}
+	if c.exit == nil {
+		c.exit = os.Exit
+	}
+
+	now := c.now()
+	if c.startedAt.IsZero() {
+		c.startedAt = now
+	}
+	if now.Sub(c.startedAt) > window {
+		c.done = true
+		return
+	}
+
+	c.attempts++
+	if decryptErr != nil {
+		c.failures++
+	}
+	if c.attempts < samples {
+		return
+	}
+	c.done = true
+
+	if float64(c.failures)/float64(c.attempts) >= ratio {
+		logf.FromContext(ctx).Error(decryptErr, "Too many decrypt failures after startup, check the configured keys",
+			"failures", c.failures, "attempts", c.attempts, "threshold", fmt.Sprintf("%.0f%%", ratio*100))
+		c.exit(1)
+	}
+}
+
+// failFastSettings returns the startup check settings with defaults applied.
+func (r *SopsSecretReconciler) failFastSettings() (int, float64, time.Duration) {
+	samples, ratio, window := r.FailFastSamples, r.FailFastRatio, r.FailFastWindow
+	if samples <= 0 {
+		samples = defaultFailFastSamples
+	}
+	if ratio <= 0 {
+		ratio = defaultFailFastRatio
+	}
+	if window <= 0 {
+		window = defaultFailFastWindow
+	}
+	return samples, ratio, window
+}
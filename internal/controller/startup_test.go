@@ -0,0 +1,103 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// newStartupReconciler returns a reconciler with fail-fast enabled whose exit
+// code is recorded instead of ending the process. The clock advances by step
+// on every decrypt.
+func newStartupReconciler(step time.Duration) (*SopsSecretReconciler, *int) {
+	exitCode := -1
+	now := time.Unix(0, 0)
+	r := &SopsSecretReconciler{
+		FailFastOnStartup: true,
+		FailFastSamples:   4,
+		FailFastRatio:     0.75,
+		FailFastWindow:    time.Minute,
+	}
+	r.startup.exit = func(code int) { exitCode = code }
+	r.startup.now = func() time.Time {
+		now = now.Add(step)
+		return now
+	}
+	return r, &exitCode
+}
+
+func TestStartupCheck(t *testing.T) {
+	errDecrypt := errors.New("no matching key")
+
+	tests := []struct {
+		name     string
+		enabled  bool
+		step     time.Duration
+		results  []error
+		wantExit bool
+	}{
+		{
+			name:     "burst of failures",
+			enabled:  true,
+			results:  []error{errDecrypt, errDecrypt, nil, errDecrypt},
+			wantExit: true,
+		},
+		{
+			name:    "failures below the ratio",
+			enabled: true,
+			results: []error{errDecrypt, nil, errDecrypt, nil},
+		},
+		{
+			name:    "failures after the first samples",
+			enabled: true,
+			results: []error{nil, nil, nil, nil, errDecrypt, errDecrypt, errDecrypt, errDecrypt},
+		},
+		{
+			name:    "failures outside the window",
+			enabled: true,
+			step:    30 * time.Second,
+			results: []error{errDecrypt, errDecrypt, errDecrypt, errDecrypt},
+		},
+		{
+			name:    "disabled",
+			results: []error{errDecrypt, errDecrypt, errDecrypt, errDecrypt},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, exitCode := newStartupReconciler(tt.step)
+			r.FailFastOnStartup = tt.enabled
+			for _, err := range tt.results {
+				r.recordStartupDecrypt(context.Background(), err)
+			}
+			if gotExit := *exitCode == 1; gotExit != tt.wantExit {
+				t.Errorf("exited = %v (code %d), want %v", gotExit, *exitCode, tt.wantExit)
+			}
+		})
+	}
+}
+
+func TestFailFastSettingsDefaults(t *testing.T) {
+	samples, ratio, window := (&SopsSecretReconciler{}).failFastSettings()
+	if samples != defaultFailFastSamples || ratio != defaultFailFastRatio || window != defaultFailFastWindow {
+		t.Errorf("failFastSettings() = %d, %v, %v, want defaults", samples, ratio, window)
+	}
+}
@@ -0,0 +1,44 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"unicode/utf8"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// moveToStringData moves the values of secret that are valid UTF-8 from data
+// to stringData, for spec.stringData. Other values stay in data, since
+// stringData cannot carry arbitrary bytes. The API server merges stringData
+// into data, so the stored Secret has the same data either way.
+func moveToStringData(secret *corev1.Secret) {
+	var data map[string][]byte
+	stringData := make(map[string]string, len(secret.Data))
+	for key, value := range secret.Data {
+		if utf8.Valid(value) {
+			stringData[key] = string(value)
+			continue
+		}
+		if data == nil {
+			data = make(map[string][]byte)
+		}
+		data[key] = value
+	}
+	secret.Data = data
+	secret.StringData = stringData
+}
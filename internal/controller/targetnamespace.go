@@ -0,0 +1,72 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	secretsv1alpha1 "github.com/scalaric/sops-operator/api/v1alpha1"
+)
+
+// sopsSecretNamespaceLabel names the namespace of the SopsSecret on a Secret
+// written to spec.targetNamespace. Owner references cannot cross namespaces,
+// so such a Secret is tracked by this label and the sopssecret label, and
+// deleted by the finalizer.
+//
+// The Secret is only written when the target namespace accepts it, see
+// crossNamespaceAllowed. A Secret in the target namespace that the SopsSecret
+// does not manage is never overwritten.
+const sopsSecretNamespaceLabel = "secrets.scalaric.io/sopssecret-namespace"
+
+// secretNamespace returns the namespace the Secret of sopsSecret is written
+// to, spec.targetNamespace or the namespace of the SopsSecret.
+func secretNamespace(sopsSecret *secretsv1alpha1.SopsSecret) string {
+	if sopsSecret.Spec.TargetNamespace != "" {
+		return sopsSecret.Spec.TargetNamespace
+	}
+	return sopsSecret.Namespace
+}
+
+// crossNamespace reports whether the Secret of sopsSecret is written to
+// another namespace than that of the SopsSecret.
+func crossNamespace(sopsSecret *secretsv1alpha1.SopsSecret) bool {
+	return secretNamespace(sopsSecret) != sopsSecret.Namespace
+}
+
+// ownsTargetSecret reports whether secret, in another namespace than
+// sopsSecret, carries the back-reference labels of sopsSecret.
+func ownsTargetSecret(secret *corev1.Secret, sopsSecret *secretsv1alpha1.SopsSecret) bool {
+	return secret.Labels[sopsSecretLabel] == sopsSecret.Name &&
+		secret.Labels[sopsSecretNamespaceLabel] == sopsSecret.Namespace
+}
+
+// sopsSecretOfTargetSecret maps a Secret written to spec.targetNamespace to
+// the SopsSecret named by its labels, so changes to it are corrected without
+// waiting for the periodic sync.
+func sopsSecretOfTargetSecret(_ context.Context, obj client.Object) []reconcile.Request {
+	labels := obj.GetLabels()
+	name, namespace := labels[sopsSecretLabel], labels[sopsSecretNamespaceLabel]
+	if name == "" || namespace == "" || namespace == obj.GetNamespace() {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: namespace, Name: name}}}
+}
@@ -0,0 +1,133 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"maps"
+
+	secretsv1alpha1 "github.com/scalaric/sops-operator/api/v1alpha1"
+)
+
+// SecretValueTransformer rewrites every Secret value before it is written,
+// for example to keep values wrapped by a cluster-local key instead of
+// storing plaintext.
+type SecretValueTransformer interface {
+	Transform(ctx context.Context, key string, value []byte) ([]byte, error)
+}
+
+// NoopTransformer stores values unchanged. It is used when no transformer is
+// configured.
+type NoopTransformer struct{}
+
+// Transform returns value unchanged.
+func (NoopTransformer) Transform(_ context.Context, _ string, value []byte) ([]byte, error) {
+	return value, nil
+}
+
+// Base64Transformer stores values base64-encoded, so consumers read the
+// encoded form from the Secret. It serves as an example for transformers
+// that call out to a key service.
+type Base64Transformer struct{}
+
+// Transform returns the standard base64 encoding of value.
+func (Base64Transformer) Transform(_ context.Context, _ string, value []byte) ([]byte, error) {
+	return []byte(base64.StdEncoding.EncodeToString(value)), nil
+}
+
+// transformValues runs every value through the configured transformer and
+// returns the transformed data.
+func (r *SopsSecretReconciler) transformValues(ctx context.Context, data map[string][]byte) (map[string][]byte, error) {
+	transformer := r.ValueTransformer
+	if transformer == nil {
+		transformer = NoopTransformer{}
+	}
+
+	transformed := make(map[string][]byte, len(data))
+	for _, key := range sortedKeys(data) {
+		value, err := transformer.Transform(ctx, key, data[key])
+		if err != nil {
+			return nil, fmt.Errorf("failed to transform value for key %s: %w", key, err)
+		}
+		transformed[key] = value
+	}
+	return transformed, nil
+}
+
+// namedTransforms are the transforms spec.transforms can apply to a value.
+var namedTransforms = map[string]func([]byte) ([]byte, error){
+	"trim": func(value []byte) ([]byte, error) {
+		return bytes.TrimSpace(value), nil
+	},
+	"lower": func(value []byte) ([]byte, error) {
+		return bytes.ToLower(value), nil
+	},
+	"upper": func(value []byte) ([]byte, error) {
+		return bytes.ToUpper(value), nil
+	},
+	"base64encode": func(value []byte) ([]byte, error) {
+		return []byte(base64.StdEncoding.EncodeToString(value)), nil
+	},
+	"base64decode": func(value []byte) ([]byte, error) {
+		return base64.StdEncoding.DecodeString(string(value))
+	},
+}
+
+// applyNamedTransforms runs the spec.transforms of each key over its value in
+// order. When wrapped is set, values are YAML-wrapped and a transformed key
+// holds the transformed plain value instead. Keys missing from data are
+// skipped, unknown transforms are an error.
+func applyNamedTransforms(
+	sopsSecret *secretsv1alpha1.SopsSecret, data map[string][]byte, wrapped bool,
+) (map[string][]byte, error) {
+	transforms := sopsSecret.Spec.Transforms
+	for _, key := range sortedKeys(transforms) {
+		for _, name := range transforms[key] {
+			if _, ok := namedTransforms[name]; !ok {
+				return nil, fmt.Errorf("unknown transform %q for key %s", name, key)
+			}
+		}
+	}
+	if len(transforms) == 0 {
+		return data, nil
+	}
+
+	result := maps.Clone(data)
+	for _, key := range sortedKeys(transforms) {
+		value, ok := result[key]
+		if !ok {
+			continue
+		}
+		if wrapped {
+			if unwrapped := wrappedString(key, value); unwrapped != "" {
+				value = []byte(unwrapped)
+			}
+		}
+		for _, name := range transforms[key] {
+			var err error
+			// Errors name the key only, they may quote the plaintext
+			if value, err = namedTransforms[name](value); err != nil {
+				return nil, fmt.Errorf("transform %s failed for key %s", name, key)
+			}
+		}
+		result[key] = value
+	}
+	return result, nil
+}
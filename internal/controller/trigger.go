@@ -0,0 +1,97 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// Reconcile triggers, as inferred from the outcome of the previous reconcile.
+const (
+	// triggerInitial is the first reconcile of a SopsSecret by this process.
+	triggerInitial = "initial"
+
+	// triggerEvent is a reconcile caused by a watch event before any
+	// scheduled requeue was due.
+	triggerEvent = "event"
+
+	// triggerPeriodic is a reconcile at or after the requeue time the previous
+	// reconcile asked for.
+	triggerPeriodic = "periodic"
+
+	// triggerBackoff is a retry after the previous reconcile failed.
+	triggerBackoff = "backoff"
+)
+
+// triggerTracker remembers how each SopsSecret's last reconcile ended, so the
+// next one can be attributed to a trigger. The workqueue does not report why
+// a request was queued, so the trigger is inferred: a watch event that
+// arrives after the requeue time is counted as periodic.
+type triggerTracker struct {
+	mu      sync.Mutex
+	lastRun map[types.NamespacedName]reconcileOutcome
+}
+
+// reconcileOutcome is what the previous reconcile of a SopsSecret returned.
+type reconcileOutcome struct {
+	requeueAt time.Time
+	failed    bool
+}
+
+// infer returns the likely trigger of a reconcile of key starting at now.
+func (t *triggerTracker) infer(key types.NamespacedName, now time.Time) string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	last, ok := t.lastRun[key]
+	switch {
+	case !ok:
+		return triggerInitial
+	case last.failed:
+		return triggerBackoff
+	case !last.requeueAt.IsZero() && !now.Before(last.requeueAt):
+		return triggerPeriodic
+	default:
+		return triggerEvent
+	}
+}
+
+// record stores the outcome of a reconcile of key that started at now.
+func (t *triggerTracker) record(key types.NamespacedName, now time.Time, result ctrl.Result, err error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.lastRun == nil {
+		t.lastRun = make(map[types.NamespacedName]reconcileOutcome)
+	}
+	outcome := reconcileOutcome{failed: err != nil}
+	if err == nil && result.RequeueAfter > 0 {
+		outcome.requeueAt = now.Add(result.RequeueAfter)
+	}
+	t.lastRun[key] = outcome
+}
+
+// forget drops the outcome of a SopsSecret that no longer exists.
+func (t *triggerTracker) forget(key types.NamespacedName) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.lastRun, key)
+}
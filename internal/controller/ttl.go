@@ -0,0 +1,92 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	secretsv1alpha1 "github.com/scalaric/sops-operator/api/v1alpha1"
+)
+
+// now returns the current time, from clock when it is set.
+func (r *SopsSecretReconciler) now() time.Time {
+	if r.clock != nil {
+		return r.clock()
+	}
+	return time.Now()
+}
+
+// secretExpiry returns when the Secret of a SopsSecret with spec.secretTTL
+// expires. It reports false without a TTL or before the first decrypt.
+func secretExpiry(sopsSecret *secretsv1alpha1.SopsSecret) (time.Time, bool) {
+	ttl := sopsSecret.Spec.SecretTTL
+	if ttl == nil || ttl.Duration <= 0 || sopsSecret.Status.LastDecryptedTime == nil {
+		return time.Time{}, false
+	}
+	return sopsSecret.Status.LastDecryptedTime.Add(ttl.Duration), true
+}
+
+// expiryRequeue shortens after so that the SopsSecret is reconciled when its
+// Secret expires. Zero after means no requeue was planned.
+func (r *SopsSecretReconciler) expiryRequeue(sopsSecret *secretsv1alpha1.SopsSecret, after time.Duration) time.Duration {
+	expiry, ok := secretExpiry(sopsSecret)
+	if !ok {
+		return after
+	}
+	if remaining := expiry.Sub(r.now()); remaining > 0 && (after == 0 || remaining < after) {
+		return remaining
+	}
+	return after
+}
+
+// reconcileExpired deletes the managed Secret and its reflected copies once
+// spec.secretTTL has elapsed, and reports Expired until the SopsSecret is
+// updated and decrypted again.
+func (r *SopsSecretReconciler) reconcileExpired(ctx context.Context, sopsSecret *secretsv1alpha1.SopsSecret) (ctrl.Result, error) {
+	secret := &corev1.Secret{}
+	err := r.getManagedSecret(ctx, sopsSecret, secret)
+	if err == nil && ownsSecret(secret, sopsSecret) {
+		if err := r.Delete(ctx, secret); client.IgnoreNotFound(err) != nil {
+			return ctrl.Result{}, err
+		}
+		r.Recorder.Eventf(sopsSecret, secret, corev1.EventTypeNormal, ReasonSecretExpired, "Delete",
+			"Deleted Secret %s after its TTL of %s", secret.Name, sopsSecret.Spec.SecretTTL.Duration)
+	} else if err != nil && !apierrors.IsNotFound(err) {
+		return ctrl.Result{}, err
+	}
+	if err := r.deleteReflections(ctx, sopsSecret); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if meta.IsStatusConditionTrue(sopsSecret.Status.Conditions, secretsv1alpha1.ConditionTypeExpired) {
+		return ctrl.Result{}, nil
+	}
+	msg := fmt.Sprintf("Secret expired %s after the last decrypt, update the SopsSecret to recreate it",
+		sopsSecret.Spec.SecretTTL.Duration)
+	r.setCondition(sopsSecret, secretsv1alpha1.ConditionTypeExpired, metav1.ConditionTrue, "TTLElapsed", msg)
+	r.setCondition(sopsSecret, secretsv1alpha1.ConditionTypeReady, metav1.ConditionFalse, ReasonSecretExpired, msg)
+	return r.updateStatusAndRequeue(ctx, sopsSecret, 0)
+}
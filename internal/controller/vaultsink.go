@@ -0,0 +1,267 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	secretsv1alpha1 "github.com/scalaric/sops-operator/api/v1alpha1"
+	"github.com/scalaric/sops-operator/pkg/vault"
+)
+
+const (
+	// defaultVaultMount is the mount of the KV engine when spec.vaultSink.mount
+	// is empty.
+	defaultVaultMount = "secret"
+
+	// defaultVaultTokenKey is the key of the token Secret when
+	// spec.vaultSink.auth.tokenSecretRef.key is empty.
+	defaultVaultTokenKey = "token"
+
+	// defaultVaultKubernetesMount is the mount of the Kubernetes auth method
+	// when spec.vaultSink.auth.kubernetes.mountPath is empty.
+	defaultVaultKubernetesMount = "kubernetes"
+
+	// serviceAccountTokenFile is where the operator's service account token is
+	// mounted.
+	serviceAccountTokenFile = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+
+	// vaultLoginRenewBefore is how long before its token expires a cached
+	// Kubernetes auth login is replaced by a new one.
+	vaultLoginRenewBefore = time.Minute
+)
+
+// SecretSink receives the values of the managed Secret of a SopsSecret to
+// store them outside the cluster.
+type SecretSink interface {
+	// Write stores data for sopsSecret. Writing unchanged data is a no-op.
+	Write(ctx context.Context, sopsSecret *secretsv1alpha1.SopsSecret, data map[string][]byte) error
+	// Delete removes what Write stored for sopsSecret.
+	Delete(ctx context.Context, sopsSecret *secretsv1alpha1.SopsSecret) error
+}
+
+// writeVaultSink writes data to the Vault secret of spec.vaultSink.
+func (r *SopsSecretReconciler) writeVaultSink(ctx context.Context, sopsSecret *secretsv1alpha1.SopsSecret, data map[string][]byte) error {
+	if r.VaultSink == nil {
+		return errors.New("the Vault sink is disabled, allow the Vault address with --vault-sink-addresses")
+	}
+	return r.VaultSink.Write(ctx, sopsSecret, data)
+}
+
+// vaultSink writes spec.vaultSink to HashiCorp Vault.
+type vaultSink struct {
+	reader           client.Reader
+	allowedAddresses []string
+	tokenFile        string
+	httpClient       *http.Client
+
+	// logins caches the clients logged in through the Kubernetes auth
+	// method by address, mount and role, so reconciles reuse the token
+	// instead of creating a new one each time.
+	mu     sync.Mutex
+	logins map[string]*vault.Client
+}
+
+// NewVaultSink returns a SecretSink for spec.vaultSink that only talks to
+// the Vault servers in allowedAddresses. Tokens are read with reader.
+func NewVaultSink(reader client.Reader, allowedAddresses []string) SecretSink {
+	addresses := make([]string, 0, len(allowedAddresses))
+	for _, address := range allowedAddresses {
+		addresses = append(addresses, strings.TrimSuffix(address, "/"))
+	}
+	return &vaultSink{
+		reader:           reader,
+		allowedAddresses: addresses,
+		tokenFile:        serviceAccountTokenFile,
+		logins:           map[string]*vault.Client{},
+	}
+}
+
+// Write stores data as the Vault secret, unless it already holds the same
+// values. Values must be valid UTF-8, Vault stores strings.
+func (s *vaultSink) Write(ctx context.Context, sopsSecret *secretsv1alpha1.SopsSecret, data map[string][]byte) error {
+	values := make(map[string]string, len(data))
+	for key, value := range data {
+		if !utf8.Valid(value) {
+			return fmt.Errorf("value of key %s is not valid UTF-8 and cannot be written to Vault", key)
+		}
+		values[key] = string(value)
+	}
+
+	spec := sopsSecret.Spec.VaultSink
+	c, err := s.login(ctx, sopsSecret)
+	if err != nil {
+		return err
+	}
+	current, err := c.ReadKV(ctx, vaultMount(spec), spec.Path)
+	if err != nil {
+		return s.forgetRefused(sopsSecret, err)
+	}
+	if current != nil && maps.Equal(current, values) {
+		return nil
+	}
+	return s.forgetRefused(sopsSecret, c.WriteKV(ctx, vaultMount(spec), spec.Path, values))
+}
+
+// Delete deletes the Vault secret with all its versions.
+func (s *vaultSink) Delete(ctx context.Context, sopsSecret *secretsv1alpha1.SopsSecret) error {
+	spec := sopsSecret.Spec.VaultSink
+	c, err := s.login(ctx, sopsSecret)
+	if err != nil {
+		return err
+	}
+	return s.forgetRefused(sopsSecret, c.DeleteKV(ctx, vaultMount(spec), spec.Path))
+}
+
+// login returns a client for the Vault server of spec.vaultSink, logged in
+// with its auth method.
+func (s *vaultSink) login(ctx context.Context, sopsSecret *secretsv1alpha1.SopsSecret) (*vault.Client, error) {
+	spec := sopsSecret.Spec.VaultSink
+	address := strings.TrimSuffix(spec.Address, "/")
+	if !slices.Contains(s.allowedAddresses, address) {
+		return nil, fmt.Errorf("vault address %s is not allowed by --vault-sink-addresses", spec.Address)
+	}
+	var opts []vault.Option
+	if s.httpClient != nil {
+		opts = append(opts, vault.WithHTTPClient(s.httpClient))
+	}
+
+	switch auth := spec.Auth; {
+	case auth.TokenSecretRef != nil:
+		token, err := s.readToken(ctx, sopsSecret.Namespace, auth.TokenSecretRef)
+		if err != nil {
+			return nil, err
+		}
+		return vault.NewClient(address, append(opts, vault.WithToken(token))...), nil
+	case auth.Kubernetes != nil:
+		// The operator's role is shared by every namespace, confine each
+		// SopsSecret to the paths under its own namespace.
+		if !inNamespacePath(sopsSecret.Namespace, spec.Path) {
+			return nil, fmt.Errorf("vault path %s must start with %s/ to log in through the Kubernetes auth method", spec.Path, sopsSecret.Namespace)
+		}
+		key := loginKey(sopsSecret)
+		if c := s.cachedLogin(key); c != nil {
+			return c, nil
+		}
+		jwt, err := os.ReadFile(s.tokenFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read service account token: %w", err)
+		}
+		c := vault.NewClient(address, opts...)
+		if err := c.LoginKubernetes(ctx, kubernetesMount(auth.Kubernetes), auth.Kubernetes.Role, strings.TrimSpace(string(jwt))); err != nil {
+			return nil, err
+		}
+		s.mu.Lock()
+		s.logins[key] = c
+		s.mu.Unlock()
+		return c, nil
+	default:
+		return nil, errors.New("spec.vaultSink.auth sets no auth method")
+	}
+}
+
+// cachedLogin returns the client cached under key, unless its token is about
+// to expire.
+func (s *vaultSink) cachedLogin(key string) *vault.Client {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c, ok := s.logins[key]
+	if !ok {
+		return nil
+	}
+	if expiry := c.TokenExpiry(); !expiry.IsZero() && time.Until(expiry) < vaultLoginRenewBefore {
+		delete(s.logins, key)
+		return nil
+	}
+	return c
+}
+
+// forgetRefused drops the cached login of sopsSecret when Vault refused err,
+// so the next attempt logs in again in case the token was revoked. It
+// returns err.
+func (s *vaultSink) forgetRefused(sopsSecret *secretsv1alpha1.SopsSecret, err error) error {
+	if vault.IsPermissionDenied(err) && sopsSecret.Spec.VaultSink.Auth.Kubernetes != nil {
+		s.mu.Lock()
+		delete(s.logins, loginKey(sopsSecret))
+		s.mu.Unlock()
+	}
+	return err
+}
+
+// loginKey identifies the Kubernetes auth login of sopsSecret.
+func loginKey(sopsSecret *secretsv1alpha1.SopsSecret) string {
+	spec := sopsSecret.Spec.VaultSink
+	return strings.Join([]string{strings.TrimSuffix(spec.Address, "/"), kubernetesMount(spec.Auth.Kubernetes), spec.Auth.Kubernetes.Role}, "|")
+}
+
+// kubernetesMount returns the mount of the Kubernetes auth method of auth.
+func kubernetesMount(auth *secretsv1alpha1.VaultKubernetesAuth) string {
+	if auth.MountPath == "" {
+		return defaultVaultKubernetesMount
+	}
+	return auth.MountPath
+}
+
+// inNamespacePath reports whether the KV path p lies under namespace.
+func inNamespacePath(namespace, p string) bool {
+	segments := strings.Split(strings.Trim(p, "/"), "/")
+	if len(segments) < 2 || segments[0] != namespace {
+		return false
+	}
+	return !slices.ContainsFunc(segments, func(segment string) bool {
+		return segment == "" || segment == "." || segment == ".."
+	})
+}
+
+// readToken reads the Vault token from the Secret ref selects.
+func (s *vaultSink) readToken(ctx context.Context, namespace string, ref *secretsv1alpha1.VaultTokenSecretRef) (string, error) {
+	key := ref.Key
+	if key == "" {
+		key = defaultVaultTokenKey
+	}
+	secret := &corev1.Secret{}
+	if err := s.reader.Get(ctx, types.NamespacedName{Namespace: namespace, Name: ref.Name}, secret); err != nil {
+		return "", fmt.Errorf("failed to read Vault token Secret %s: %w", ref.Name, err)
+	}
+	token := strings.TrimSpace(string(secret.Data[key]))
+	if token == "" {
+		return "", fmt.Errorf("vault token Secret %s has no key %s", ref.Name, key)
+	}
+	return token, nil
+}
+
+// vaultMount returns the mount of the KV engine of spec.
+func vaultMount(spec *secretsv1alpha1.VaultSink) string {
+	if spec.Mount == "" {
+		return defaultVaultMount
+	}
+	return spec.Mount
+}
@@ -0,0 +1,46 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	"github.com/scalaric/sops-operator/pkg/sops"
+)
+
+// SecretVerifier checks that decrypted data is usable before the Secret is
+// written, for example that a database password is accepted by the database.
+type SecretVerifier interface {
+	Verify(ctx context.Context, data *sops.DecryptedData) error
+}
+
+// NoopVerifier accepts all data. It is used when no verifier is configured.
+type NoopVerifier struct{}
+
+// Verify returns nil.
+func (NoopVerifier) Verify(_ context.Context, _ *sops.DecryptedData) error {
+	return nil
+}
+
+// verify runs the decrypted data through the configured verifier.
+func (r *SopsSecretReconciler) verify(ctx context.Context, data *sops.DecryptedData) error {
+	verifier := r.Verifier
+	if verifier == nil {
+		verifier = NoopVerifier{}
+	}
+	return verifier.Verify(ctx, data)
+}
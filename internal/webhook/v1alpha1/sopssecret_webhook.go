@@ -0,0 +1,180 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	secretsv1alpha1 "github.com/scalaric/sops-operator/api/v1alpha1"
+	"github.com/scalaric/sops-operator/pkg/sops"
+)
+
+var sopssecretlog = logf.Log.WithName("sopssecret-resource")
+
+// SetupSopsSecretWebhookWithManager registers the webhook for SopsSecret in the manager.
+// SopsSecrets must carry every label in requiredLabels.
+func SetupSopsSecretWebhookWithManager(mgr ctrl.Manager, requiredLabels []string) error {
+	return ctrl.NewWebhookManagedBy(mgr, &secretsv1alpha1.SopsSecret{}).
+		WithValidator(&SopsSecretCustomValidator{RequiredLabels: requiredLabels}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/validate-secrets-scalaric-io-v1alpha1-sopssecret,mutating=false,failurePolicy=fail,sideEffects=None,groups=secrets.scalaric.io,resources=sopssecrets,verbs=create;update,versions=v1alpha1,name=vsopssecret-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// SopsSecretCustomValidator rejects SopsSecrets whose inline document can
+// never be decrypted, changes to a document locked by spec.lockData, and
+// SopsSecrets without the labels in RequiredLabels. Documents read from
+// encryptedFromFile are only available to the controller and are not checked
+// here.
+type SopsSecretCustomValidator struct {
+	// RequiredLabels are label keys every SopsSecret must carry with a
+	// non-empty value, e.g. owner or environment.
+	RequiredLabels []string
+}
+
+var _ admission.Validator[*secretsv1alpha1.SopsSecret] = &SopsSecretCustomValidator{}
+
+// ValidateCreate implements admission.Validator.
+func (v *SopsSecretCustomValidator) ValidateCreate(_ context.Context, obj *secretsv1alpha1.SopsSecret) (admission.Warnings, error) {
+	sopssecretlog.V(1).Info("validating create", "name", obj.GetName())
+	if err := v.validateRequiredLabels(nil, obj); err != nil {
+		return nil, err
+	}
+	if err := validateReconcileInterval(obj); err != nil {
+		return nil, err
+	}
+	return nil, validateSopsSecret(obj)
+}
+
+// ValidateUpdate implements admission.Validator.
+func (v *SopsSecretCustomValidator) ValidateUpdate(_ context.Context, oldObj, newObj *secretsv1alpha1.SopsSecret) (admission.Warnings, error) {
+	sopssecretlog.V(1).Info("validating update", "name", newObj.GetName())
+	if err := validateDataLock(oldObj, newObj); err != nil {
+		return nil, err
+	}
+	if err := v.validateRequiredLabels(oldObj, newObj); err != nil {
+		return nil, err
+	}
+	if err := validateReconcileInterval(newObj); err != nil {
+		return nil, err
+	}
+	return nil, validateSopsSecret(newObj)
+}
+
+// ValidateDelete implements admission.Validator. Deletion is always allowed.
+func (v *SopsSecretCustomValidator) ValidateDelete(_ context.Context, _ *secretsv1alpha1.SopsSecret) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validateSopsSecret rejects a sops block that has a MAC but no recipients.
+// Documents without a sops block or with a malformed one are left to the
+// controller, which reports them in the status.
+func validateSopsSecret(obj *secretsv1alpha1.SopsSecret) error {
+	if obj.Spec.SopsSecret == "" {
+		return nil
+	}
+	metadata, err := sops.ParseSopsMetadata([]byte(obj.Spec.SopsSecret))
+	if err != nil || metadata.MAC == "" {
+		return nil
+	}
+	if err := sops.ValidateRecipients([]byte(obj.Spec.SopsSecret)); err != nil {
+		return apierrors.NewInvalid(
+			schema.GroupKind{Group: secretsv1alpha1.GroupVersion.Group, Kind: "SopsSecret"},
+			obj.GetName(),
+			field.ErrorList{field.Invalid(field.NewPath("spec", "sopsSecret"), "<encrypted document>", err.Error())},
+		)
+	}
+	return nil
+}
+
+// validateReconcileInterval rejects a spec.reconcileInterval that is not
+// positive.
+func validateReconcileInterval(obj *secretsv1alpha1.SopsSecret) error {
+	interval := obj.Spec.ReconcileInterval
+	if interval == nil || interval.Duration > 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(
+		schema.GroupKind{Group: secretsv1alpha1.GroupVersion.Group, Kind: "SopsSecret"},
+		obj.GetName(),
+		field.ErrorList{field.Invalid(field.NewPath("spec", "reconcileInterval"), interval.Duration.String(), "must be positive")},
+	)
+}
+
+// validateDataLock rejects changes to the encrypted document of a SopsSecret
+// with spec.lockData that was already decrypted. The lock has to be removed
+// in an update of its own before the document can change.
+func validateDataLock(oldObj, newObj *secretsv1alpha1.SopsSecret) error {
+	if !oldObj.Spec.LockData || oldObj.Status.LastDecryptedHash == "" {
+		return nil
+	}
+
+	const detail = "the encrypted document is locked by spec.lockData, remove the lock first"
+	specPath := field.NewPath("spec")
+	var errs field.ErrorList
+	if oldObj.Spec.SopsSecret != newObj.Spec.SopsSecret {
+		errs = append(errs, field.Forbidden(specPath.Child("sopsSecret"), detail))
+	}
+	if oldObj.Spec.EncryptedFromFile != newObj.Spec.EncryptedFromFile {
+		errs = append(errs, field.Forbidden(specPath.Child("encryptedFromFile"), detail))
+	}
+	if !equality.Semantic.DeepEqual(oldObj.Spec.EncryptedFromChunks, newObj.Spec.EncryptedFromChunks) {
+		errs = append(errs, field.Forbidden(specPath.Child("encryptedFromChunks"), detail))
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(
+		schema.GroupKind{Group: secretsv1alpha1.GroupVersion.Group, Kind: "SopsSecret"},
+		newObj.GetName(),
+		errs,
+	)
+}
+
+// validateRequiredLabels rejects a SopsSecret missing one of RequiredLabels.
+// An update is only rejected for labels that oldObj still had, so SopsSecrets
+// created before a label was required can be updated, and deleted, until they
+// are labelled.
+func (v *SopsSecretCustomValidator) validateRequiredLabels(oldObj, newObj *secretsv1alpha1.SopsSecret) error {
+	labelsPath := field.NewPath("metadata", "labels")
+	var errs field.ErrorList
+	for _, key := range v.RequiredLabels {
+		if newObj.Labels[key] != "" {
+			continue
+		}
+		if oldObj != nil && oldObj.Labels[key] == "" {
+			continue
+		}
+		errs = append(errs, field.Required(labelsPath.Key(key), "label is required for SopsSecrets"))
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(
+		schema.GroupKind{Group: secretsv1alpha1.GroupVersion.Group, Kind: "SopsSecret"},
+		newObj.GetName(),
+		errs,
+	)
+}
@@ -0,0 +1,300 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	secretsv1alpha1 "github.com/scalaric/sops-operator/api/v1alpha1"
+)
+
+func newSopsSecret(document string) *secretsv1alpha1.SopsSecret {
+	return &secretsv1alpha1.SopsSecret{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+		Spec:       secretsv1alpha1.SopsSecretSpec{SopsSecret: document},
+	}
+}
+
+func TestSopsSecretCustomValidator(t *testing.T) {
+	tests := []struct {
+		name    string
+		obj     *secretsv1alpha1.SopsSecret
+		wantErr bool
+	}{
+		{
+			name: "age recipient",
+			obj: newSopsSecret(`password: ENC[AES256_GCM,data:abc,type:str]
+sops:
+    age:
+        - recipient: age1ql3z7hjy54pw3hyww5ayyfg7zqgvc7w3j2elw8zmrj2kg5sfn9aqmcac8p
+          enc: |
+            -----BEGIN AGE ENCRYPTED FILE-----
+            -----END AGE ENCRYPTED FILE-----
+    mac: ENC[AES256_GCM,data:mac,type:str]
+    version: 3.9.0
+`),
+		},
+		{
+			name: "no recipients",
+			obj: newSopsSecret(`password: ENC[AES256_GCM,data:abc,type:str]
+sops:
+    age: []
+    pgp: []
+    mac: ENC[AES256_GCM,data:mac,type:str]
+    version: 3.9.0
+`),
+			wantErr: true,
+		},
+		{
+			name: "no sops block",
+			obj:  newSopsSecret("password: plain\n"),
+		},
+		{
+			name: "file source",
+			obj: &secretsv1alpha1.SopsSecret{
+				Spec: secretsv1alpha1.SopsSecretSpec{EncryptedFromFile: "/var/run/sops/doc.yaml"},
+			},
+		},
+	}
+
+	validator := &SopsSecretCustomValidator{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, createErr := validator.ValidateCreate(context.Background(), tt.obj)
+			_, updateErr := validator.ValidateUpdate(context.Background(), tt.obj, tt.obj)
+			for op, err := range map[string]error{"create": createErr, "update": updateErr} {
+				if (err != nil) != tt.wantErr {
+					t.Fatalf("validate %s error = %v, wantErr %v", op, err, tt.wantErr)
+				}
+				if err != nil && (!apierrors.IsInvalid(err) || !strings.Contains(err.Error(), "no recipients")) {
+					t.Errorf("validate %s error = %v, want Invalid error mentioning missing recipients", op, err)
+				}
+			}
+		})
+	}
+}
+
+func TestSopsSecretCustomValidatorAllowsDelete(t *testing.T) {
+	obj := newSopsSecret("sops:\n    mac: ENC[test]\n")
+	if _, err := (&SopsSecretCustomValidator{}).ValidateDelete(context.Background(), obj); err != nil {
+		t.Errorf("ValidateDelete() error = %v, want nil", err)
+	}
+}
+
+func TestSopsSecretCustomValidatorDataLock(t *testing.T) {
+	const original = "password: ENC[original]\n"
+	const rotated = "password: ENC[rotated]\n"
+
+	newLocked := func(document string, lock bool, decryptedHash string) *secretsv1alpha1.SopsSecret {
+		obj := newSopsSecret(document)
+		obj.Spec.LockData = lock
+		obj.Status.LastDecryptedHash = decryptedHash
+		return obj
+	}
+
+	tests := []struct {
+		name    string
+		oldObj  *secretsv1alpha1.SopsSecret
+		newObj  *secretsv1alpha1.SopsSecret
+		wantErr bool
+	}{
+		{
+			name:   "unlocked change",
+			oldObj: newLocked(original, false, "abc"),
+			newObj: newLocked(rotated, false, "abc"),
+		},
+		{
+			name:   "locked before the first decrypt",
+			oldObj: newLocked(original, true, ""),
+			newObj: newLocked(rotated, true, ""),
+		},
+		{
+			name:    "locked change",
+			oldObj:  newLocked(original, true, "abc"),
+			newObj:  newLocked(rotated, true, "abc"),
+			wantErr: true,
+		},
+		{
+			name:    "locked change that also removes the lock",
+			oldObj:  newLocked(original, true, "abc"),
+			newObj:  newLocked(rotated, false, "abc"),
+			wantErr: true,
+		},
+		{
+			name:   "removing the lock",
+			oldObj: newLocked(original, true, "abc"),
+			newObj: newLocked(original, false, "abc"),
+		},
+		{
+			name:   "locked update of other fields",
+			oldObj: newLocked(original, true, "abc"),
+			newObj: func() *secretsv1alpha1.SopsSecret {
+				obj := newLocked(original, true, "abc")
+				obj.Spec.SecretLabels = map[string]string{"team": "payments"}
+				return obj
+			}(),
+		},
+		{
+			name:   "locked change of chunks",
+			oldObj: newLocked("", true, "abc"),
+			newObj: func() *secretsv1alpha1.SopsSecret {
+				obj := newLocked("", true, "abc")
+				obj.Spec.EncryptedFromChunks = []secretsv1alpha1.ConfigMapChunkRef{{Name: "part", Key: "doc"}}
+				return obj
+			}(),
+			wantErr: true,
+		},
+	}
+
+	validator := &SopsSecretCustomValidator{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := validator.ValidateUpdate(context.Background(), tt.oldObj, tt.newObj)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ValidateUpdate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && (!apierrors.IsInvalid(err) || !strings.Contains(err.Error(), "spec.lockData")) {
+				t.Errorf("ValidateUpdate() error = %v, want Invalid error mentioning spec.lockData", err)
+			}
+		})
+	}
+}
+
+func TestSopsSecretCustomValidatorRequiredLabels(t *testing.T) {
+	withLabels := func(labels map[string]string) *secretsv1alpha1.SopsSecret {
+		obj := newSopsSecret("password: plain\n")
+		obj.Labels = labels
+		return obj
+	}
+
+	tests := []struct {
+		name     string
+		required []string
+		oldObj   *secretsv1alpha1.SopsSecret
+		newObj   *secretsv1alpha1.SopsSecret
+		missing  []string
+	}{
+		{
+			name:     "all present",
+			required: []string{"owner", "environment"},
+			newObj:   withLabels(map[string]string{"owner": "payments", "environment": "prod", "extra": "x"}),
+		},
+		{
+			name:     "one missing",
+			required: []string{"owner", "environment"},
+			newObj:   withLabels(map[string]string{"owner": "payments"}),
+			missing:  []string{"environment"},
+		},
+		{
+			name:     "empty value",
+			required: []string{"owner"},
+			newObj:   withLabels(map[string]string{"owner": ""}),
+			missing:  []string{"owner"},
+		},
+		{
+			name:   "none required",
+			newObj: withLabels(nil),
+		},
+		{
+			name:     "configured keys only",
+			required: []string{"cost-center"},
+			newObj:   withLabels(map[string]string{"owner": "payments", "environment": "prod"}),
+			missing:  []string{"cost-center"},
+		},
+		{
+			name:     "update removing a label",
+			required: []string{"owner"},
+			oldObj:   withLabels(map[string]string{"owner": "payments"}),
+			newObj:   withLabels(nil),
+			missing:  []string{"owner"},
+		},
+		{
+			name:     "update of a SopsSecret created before the label was required",
+			required: []string{"owner"},
+			oldObj:   withLabels(nil),
+			newObj:   withLabels(map[string]string{"team": "payments"}),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			validator := &SopsSecretCustomValidator{RequiredLabels: tt.required}
+			var err error
+			if tt.oldObj == nil {
+				_, err = validator.ValidateCreate(context.Background(), tt.newObj)
+			} else {
+				_, err = validator.ValidateUpdate(context.Background(), tt.oldObj, tt.newObj)
+			}
+			if (err != nil) != (len(tt.missing) > 0) {
+				t.Fatalf("validate error = %v, want missing labels %v", err, tt.missing)
+			}
+			if err == nil {
+				return
+			}
+			if !apierrors.IsInvalid(err) {
+				t.Errorf("validate error = %v, want Invalid", err)
+			}
+			for _, key := range tt.missing {
+				if !strings.Contains(err.Error(), "metadata.labels["+key+"]") {
+					t.Errorf("validate error = %v, want it to name label %s", err, key)
+				}
+			}
+		})
+	}
+}
+
+func TestSopsSecretCustomValidatorReconcileInterval(t *testing.T) {
+	withInterval := func(interval *metav1.Duration) *secretsv1alpha1.SopsSecret {
+		obj := newSopsSecret("password: plain\n")
+		obj.Spec.ReconcileInterval = interval
+		return obj
+	}
+
+	tests := []struct {
+		name     string
+		interval *metav1.Duration
+		wantErr  bool
+	}{
+		{name: "unset", interval: nil},
+		{name: "positive", interval: &metav1.Duration{Duration: time.Minute}},
+		{name: "zero", interval: &metav1.Duration{}, wantErr: true},
+		{name: "negative", interval: &metav1.Duration{Duration: -time.Minute}, wantErr: true},
+	}
+
+	validator := &SopsSecretCustomValidator{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			obj := withInterval(tt.interval)
+			_, createErr := validator.ValidateCreate(context.Background(), obj)
+			_, updateErr := validator.ValidateUpdate(context.Background(), withInterval(nil), obj)
+			for _, err := range []error{createErr, updateErr} {
+				if (err != nil) != tt.wantErr {
+					t.Fatalf("validate error = %v, wantErr %v", err, tt.wantErr)
+				}
+				if err != nil && (!apierrors.IsInvalid(err) || !strings.Contains(err.Error(), "spec.reconcileInterval")) {
+					t.Errorf("validate error = %v, want Invalid naming spec.reconcileInterval", err)
+				}
+			}
+		})
+	}
+}
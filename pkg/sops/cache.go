@@ -0,0 +1,232 @@
+package sops
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// CachingDecryptor wraps a DecryptorInterface and caches decrypted documents
+// keyed by the hash of the encrypted payload. Once the cache holds maxEntries
+// documents the least recently used one is evicted.
+//
+// When next implements KeyFingerprinter, an entry is only served while the
+// keys it was decrypted with are still in use. This keeps a payload that was
+// re-encrypted to new keys and later reverted from being served after the old
+// keys were removed.
+//
+// Concurrent misses for the same payload share a single decrypt, so a burst
+// of reconciles for one document runs sops once.
+type CachingDecryptor struct {
+	next       DecryptorInterface
+	maxEntries int
+	maxAge     time.Duration
+
+	// now replaces time.Now in tests
+	now func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	lru     *list.List
+	bytes   int64
+
+	flights singleflight.Group
+}
+
+var _ DecryptorInterface = &CachingDecryptor{}
+
+// cacheEntry is a cached decrypted document.
+type cacheEntry struct {
+	key         string
+	data        *DecryptedData
+	size        int64
+	fingerprint string
+	addedAt     time.Time
+}
+
+// CacheOption configures a CachingDecryptor.
+type CacheOption func(*CachingDecryptor)
+
+// WithCacheMaxAge drops cached documents older than d, so they are decrypted
+// again. Zero keeps documents until they are evicted for space.
+func WithCacheMaxAge(d time.Duration) CacheOption {
+	return func(c *CachingDecryptor) {
+		c.maxAge = d
+	}
+}
+
+// NewCachingDecryptor returns a decryptor that caches up to maxEntries results of next.
+func NewCachingDecryptor(next DecryptorInterface, maxEntries int, opts ...CacheOption) *CachingDecryptor {
+	c := &CachingDecryptor{
+		next:       next,
+		maxEntries: maxEntries,
+		now:        time.Now,
+		entries:    make(map[string]*list.Element),
+		lru:        list.New(),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Decrypt decrypts SOPS-encrypted YAML, serving repeated payloads from the cache.
+func (c *CachingDecryptor) Decrypt(encryptedYAML []byte) (*DecryptedData, error) {
+	return c.DecryptWithContext(context.Background(), encryptedYAML)
+}
+
+// DecryptWithContext decrypts SOPS-encrypted YAML, serving repeated payloads from the cache.
+func (c *CachingDecryptor) DecryptWithContext(ctx context.Context, encryptedYAML []byte) (*DecryptedData, error) {
+	fingerprint, err := c.keyFingerprint(ctx)
+	if err != nil {
+		// Without the current keys the cache cannot be trusted, leave the
+		// error to the decrypt
+		return c.next.DecryptWithContext(ctx, encryptedYAML)
+	}
+
+	key := payloadHash(encryptedYAML)
+	if data, ok := c.get(key, fingerprint); ok {
+		return data, nil
+	}
+
+	flight := c.flights.DoChan(key+"/"+fingerprint, func() (any, error) {
+		// A decrypt that finished between the lookup above and this flight
+		// has already filled the cache
+		if data, ok := c.get(key, fingerprint); ok {
+			return data, nil
+		}
+		// Every caller waiting for the payload shares this decrypt, so it
+		// must not end when the one that started it is canceled. The
+		// wrapped decryptor still bounds it with its own timeout
+		data, err := c.next.DecryptWithContext(context.WithoutCancel(ctx), encryptedYAML)
+		if err != nil {
+			return nil, err
+		}
+		c.add(key, fingerprint, data)
+		return data, nil
+	})
+	select {
+	case res := <-flight:
+		if res.Err != nil {
+			return nil, res.Err
+		}
+		return cloneDecryptedData(res.Val.(*DecryptedData)), nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// keyFingerprint returns the fingerprint of the keys next decrypts with, or
+// an empty string when next does not report one.
+func (c *CachingDecryptor) keyFingerprint(ctx context.Context) (string, error) {
+	if f, ok := c.next.(KeyFingerprinter); ok {
+		return f.KeyFingerprint(ctx)
+	}
+	return "", nil
+}
+
+// Len returns the number of cached documents.
+func (c *CachingDecryptor) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lru.Len()
+}
+
+// get returns the cached document for key. Entries decrypted with other keys
+// or older than maxAge are dropped.
+func (c *CachingDecryptor) get(key, fingerprint string) (*DecryptedData, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	entry := elem.Value.(*cacheEntry)
+	if entry.fingerprint != fingerprint || c.expired(entry) {
+		c.removeElement(elem)
+		c.updateMetrics()
+		return nil, false
+	}
+	c.lru.MoveToFront(elem)
+	return cloneDecryptedData(entry.data), true
+}
+
+func (c *CachingDecryptor) add(key, fingerprint string, data *DecryptedData) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.entries[key]; ok {
+		c.removeElement(elem)
+	}
+
+	entry := &cacheEntry{
+		key:         key,
+		data:        cloneDecryptedData(data),
+		size:        decryptedSize(data),
+		fingerprint: fingerprint,
+		addedAt:     c.now(),
+	}
+	c.entries[key] = c.lru.PushFront(entry)
+	c.bytes += entry.size
+
+	for c.maxEntries > 0 && c.lru.Len() > c.maxEntries {
+		c.removeElement(c.lru.Back())
+		cacheEvictions.Inc()
+	}
+	c.updateMetrics()
+}
+
+// expired reports whether entry is older than maxAge.
+func (c *CachingDecryptor) expired(entry *cacheEntry) bool {
+	return c.maxAge > 0 && c.now().Sub(entry.addedAt) >= c.maxAge
+}
+
+func (c *CachingDecryptor) removeElement(elem *list.Element) {
+	entry := c.lru.Remove(elem).(*cacheEntry)
+	delete(c.entries, entry.key)
+	c.bytes -= entry.size
+}
+
+func (c *CachingDecryptor) updateMetrics() {
+	cacheEntries.Set(float64(c.lru.Len()))
+	cacheBytes.Set(float64(c.bytes))
+}
+
+// payloadHash returns the cache key for an encrypted payload.
+func payloadHash(encryptedYAML []byte) string {
+	hash := sha256.Sum256(encryptedYAML)
+	return hex.EncodeToString(hash[:])
+}
+
+// decryptedSize estimates the memory held by decrypted data as the sum of its key and value lengths.
+func decryptedSize(data *DecryptedData) int64 {
+	var size int64
+	for k, v := range data.Data {
+		size += int64(len(k) + len(v))
+	}
+	for k, v := range data.StringData {
+		size += int64(len(k) + len(v))
+	}
+	return size
+}
+
+// cloneDecryptedData copies data so callers cannot modify cached entries.
+func cloneDecryptedData(data *DecryptedData) *DecryptedData {
+	clone := &DecryptedData{
+		Data:       make(map[string][]byte, len(data.Data)),
+		StringData: maps.Clone(data.StringData),
+		KeyOrder:   slices.Clone(data.KeyOrder),
+	}
+	for k, v := range data.Data {
+		clone.Data[k] = append([]byte(nil), v...)
+	}
+	return clone
+}
@@ -0,0 +1,333 @@
+package sops
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+// countingDecryptor returns the payload as the value of key "payload" and counts calls.
+type countingDecryptor struct {
+	calls int
+}
+
+func (d *countingDecryptor) Decrypt(encryptedYAML []byte) (*DecryptedData, error) {
+	return d.DecryptWithContext(context.Background(), encryptedYAML)
+}
+
+func (d *countingDecryptor) DecryptWithContext(_ context.Context, encryptedYAML []byte) (*DecryptedData, error) {
+	d.calls++
+	return &DecryptedData{
+		Data:       map[string][]byte{"payload": encryptedYAML},
+		StringData: map[string]string{"payload": string(encryptedYAML)},
+	}, nil
+}
+
+// metricValue returns the current value of a gauge or counter.
+func metricValue(t *testing.T, c prometheus.Collector) float64 {
+	t.Helper()
+	ch := make(chan prometheus.Metric, 1)
+	c.Collect(ch)
+	m := &dto.Metric{}
+	if err := (<-ch).Write(m); err != nil {
+		t.Fatalf("failed to read metric: %v", err)
+	}
+	if m.Gauge != nil {
+		return m.Gauge.GetValue()
+	}
+	return m.Counter.GetValue()
+}
+
+func TestCachingDecryptorHit(t *testing.T) {
+	next := &countingDecryptor{}
+	c := NewCachingDecryptor(next, 10)
+
+	for range 3 {
+		data, err := c.Decrypt([]byte("doc"))
+		if err != nil {
+			t.Fatalf("Decrypt() error = %v", err)
+		}
+		if string(data.Data["payload"]) != "doc" {
+			t.Errorf("Decrypt() payload = %q, want doc", data.Data["payload"])
+		}
+		// Callers must not be able to corrupt the cached entry
+		data.Data["payload"][0] = 'X'
+		data.StringData["payload"] = "X"
+	}
+
+	if next.calls != 1 {
+		t.Errorf("underlying decryptor called %d times, want 1", next.calls)
+	}
+}
+
+func TestCachingDecryptorEvictions(t *testing.T) {
+	const maxEntries = 3
+	evictionsBefore := metricValue(t, cacheEvictions)
+
+	next := &countingDecryptor{}
+	c := NewCachingDecryptor(next, maxEntries)
+
+	for i := range maxEntries + 2 {
+		if _, err := c.Decrypt(fmt.Appendf(nil, "doc-%d", i)); err != nil {
+			t.Fatalf("Decrypt() error = %v", err)
+		}
+	}
+
+	if c.Len() != maxEntries {
+		t.Errorf("Len() = %d, want %d", c.Len(), maxEntries)
+	}
+	if got := metricValue(t, cacheEvictions) - evictionsBefore; got != 2 {
+		t.Errorf("sopssecret_cache_evictions_total increased by %v, want 2", got)
+	}
+	if got := metricValue(t, cacheEntries); got != maxEntries {
+		t.Errorf("sopssecret_cache_entries = %v, want %d", got, maxEntries)
+	}
+	// Each entry holds "payload" twice (Data and StringData) plus the 5 byte document
+	if got, want := metricValue(t, cacheBytes), float64(maxEntries*2*(len("payload")+5)); got != want {
+		t.Errorf("sopssecret_cache_bytes = %v, want %v", got, want)
+	}
+
+	// The oldest documents were evicted and must be decrypted again
+	if _, err := c.Decrypt([]byte("doc-0")); err != nil {
+		t.Fatalf("Decrypt() error = %v", err)
+	}
+	if next.calls != maxEntries+3 {
+		t.Errorf("underlying decryptor called %d times, want %d", next.calls, maxEntries+3)
+	}
+}
+
+func TestCachingDecryptorError(t *testing.T) {
+	runner := func(ctx context.Context, name string, args []string, env []string, input []byte) ([]byte, error) {
+		return nil, errors.New("sops failed")
+	}
+	c := NewCachingDecryptor(NewDecryptor(nil, withCommandRunner(runner)), 10)
+	if _, err := c.Decrypt([]byte("key: ENC[test]")); err == nil {
+		t.Fatal("Decrypt() expected error")
+	}
+	if c.Len() != 0 {
+		t.Errorf("failed decrypts must not be cached, Len() = %d", c.Len())
+	}
+}
+
+// rotatingKeysDecryptor decrypts documents only with the key they were
+// encrypted to, and reports the current key as its fingerprint.
+type rotatingKeysDecryptor struct {
+	key   string
+	calls int
+}
+
+func (d *rotatingKeysDecryptor) Decrypt(encryptedYAML []byte) (*DecryptedData, error) {
+	return d.DecryptWithContext(context.Background(), encryptedYAML)
+}
+
+func (d *rotatingKeysDecryptor) DecryptWithContext(_ context.Context, encryptedYAML []byte) (*DecryptedData, error) {
+	d.calls++
+	recipient, value, _ := strings.Cut(string(encryptedYAML), ":")
+	if recipient != d.key {
+		return nil, errors.New("no matching key")
+	}
+	return &DecryptedData{Data: map[string][]byte{"value": []byte(value)}}, nil
+}
+
+func (d *rotatingKeysDecryptor) KeyFingerprint(_ context.Context) (string, error) {
+	return "fingerprint-" + d.key, nil
+}
+
+func TestCachingDecryptorKeyRotationRevert(t *testing.T) {
+	next := &rotatingKeysDecryptor{key: "old"}
+	c := NewCachingDecryptor(next, 10)
+
+	original := []byte("old:password")
+	if _, err := c.Decrypt(original); err != nil {
+		t.Fatalf("Decrypt() error = %v", err)
+	}
+
+	// Keys are rotated and the document is re-encrypted to the new key
+	next.key = "new"
+	if _, err := c.Decrypt([]byte("new:password")); err != nil {
+		t.Fatalf("Decrypt() after rotation error = %v", err)
+	}
+
+	// Reverting to the document encrypted to the removed key must not be
+	// served from the cache
+	if _, err := c.Decrypt(original); err == nil {
+		t.Fatal("Decrypt() of the reverted document expected error, got cached plaintext")
+	}
+	if next.calls != 3 {
+		t.Errorf("underlying decryptor called %d times, want 3", next.calls)
+	}
+	if c.Len() != 1 {
+		t.Errorf("Len() = %d, want 1 after dropping the stale entry", c.Len())
+	}
+}
+
+func TestCachingDecryptorMaxAge(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	next := &countingDecryptor{}
+	c := NewCachingDecryptor(next, 10, WithCacheMaxAge(time.Minute))
+	c.now = func() time.Time { return now }
+
+	decrypt := func() {
+		t.Helper()
+		if _, err := c.Decrypt([]byte("doc")); err != nil {
+			t.Fatalf("Decrypt() error = %v", err)
+		}
+	}
+
+	decrypt()
+	now = now.Add(59 * time.Second)
+	decrypt()
+	if next.calls != 1 {
+		t.Errorf("underlying decryptor called %d times within max age, want 1", next.calls)
+	}
+
+	now = now.Add(time.Second)
+	decrypt()
+	if next.calls != 2 {
+		t.Errorf("underlying decryptor called %d times after max age, want 2", next.calls)
+	}
+}
+
+// slowDecryptor counts calls from concurrent callers and takes a while to
+// decrypt, like sops does.
+type slowDecryptor struct {
+	calls atomic.Int32
+}
+
+func (d *slowDecryptor) Decrypt(encryptedYAML []byte) (*DecryptedData, error) {
+	return d.DecryptWithContext(context.Background(), encryptedYAML)
+}
+
+func (d *slowDecryptor) DecryptWithContext(_ context.Context, encryptedYAML []byte) (*DecryptedData, error) {
+	d.calls.Add(1)
+	time.Sleep(20 * time.Millisecond)
+	return &DecryptedData{Data: map[string][]byte{"payload": encryptedYAML}}, nil
+}
+
+func TestCachingDecryptorConcurrentMisses(t *testing.T) {
+	next := &slowDecryptor{}
+	c := NewCachingDecryptor(next, 10)
+
+	start := make(chan struct{})
+	var wg sync.WaitGroup
+	errs := make(chan error, 50)
+	for range 50 {
+		wg.Go(func() {
+			<-start
+			data, err := c.Decrypt([]byte("doc"))
+			if err == nil && string(data.Data["payload"]) != "doc" {
+				err = fmt.Errorf("payload = %q, want doc", data.Data["payload"])
+			}
+			if err == nil {
+				// Every caller gets its own copy
+				data.Data["payload"] = []byte("modified")
+			}
+			errs <- err
+		})
+	}
+	close(start)
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("Decrypt() error = %v", err)
+		}
+	}
+	if calls := next.calls.Load(); calls != 1 {
+		t.Errorf("underlying decryptor called %d times for concurrent misses, want 1", calls)
+	}
+}
+
+// blockingDecryptor decrypts once release is closed, failing with the error
+// of its context if that ends first.
+type blockingDecryptor struct {
+	calls   atomic.Int32
+	started chan struct{}
+	release chan struct{}
+}
+
+func (d *blockingDecryptor) Decrypt(encryptedYAML []byte) (*DecryptedData, error) {
+	return d.DecryptWithContext(context.Background(), encryptedYAML)
+}
+
+func (d *blockingDecryptor) DecryptWithContext(ctx context.Context, encryptedYAML []byte) (*DecryptedData, error) {
+	if d.calls.Add(1) == 1 {
+		close(d.started)
+	}
+	select {
+	case <-d.release:
+		return &DecryptedData{Data: map[string][]byte{"payload": encryptedYAML}}, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func TestCachingDecryptorFirstCallerCanceled(t *testing.T) {
+	next := &blockingDecryptor{started: make(chan struct{}), release: make(chan struct{})}
+	c := NewCachingDecryptor(next, 10)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	first := make(chan error, 1)
+	go func() {
+		_, err := c.DecryptWithContext(ctx, []byte("doc"))
+		first <- err
+	}()
+	<-next.started
+
+	others := make(chan error, 5)
+	for range 5 {
+		go func() {
+			data, err := c.DecryptWithContext(context.Background(), []byte("doc"))
+			if err == nil && string(data.Data["payload"]) != "doc" {
+				err = fmt.Errorf("payload = %q, want doc", data.Data["payload"])
+			}
+			others <- err
+		}()
+	}
+	// Let the other callers join the running decrypt
+	time.Sleep(20 * time.Millisecond)
+
+	cancel()
+	if err := <-first; !errors.Is(err, context.Canceled) {
+		t.Errorf("canceled caller error = %v, want context.Canceled", err)
+	}
+	close(next.release)
+	for range 5 {
+		if err := <-others; err != nil {
+			t.Errorf("Decrypt() after the first caller was canceled error = %v", err)
+		}
+	}
+	if calls := next.calls.Load(); calls != 1 {
+		t.Errorf("underlying decryptor called %d times, want 1", calls)
+	}
+}
+
+func TestDecryptorKeyFingerprint(t *testing.T) {
+	fingerprint := func(keys ...string) string {
+		t.Helper()
+		f, err := NewDecryptor(keys).KeyFingerprint(context.Background())
+		if err != nil {
+			t.Fatalf("KeyFingerprint() error = %v", err)
+		}
+		return f
+	}
+
+	if fingerprint("AGE-SECRET-KEY-A", "AGE-SECRET-KEY-B") != fingerprint("AGE-SECRET-KEY-B", "AGE-SECRET-KEY-A") {
+		t.Error("KeyFingerprint() depends on key order")
+	}
+	if fingerprint("AGE-SECRET-KEY-A") == fingerprint("AGE-SECRET-KEY-B") {
+		t.Error("KeyFingerprint() is the same for different keys")
+	}
+	if f := fingerprint("AGE-SECRET-KEY-A"); containsString(f, "AGE-SECRET-KEY") {
+		t.Errorf("KeyFingerprint() = %q reveals the key", f)
+	}
+}
@@ -4,10 +4,14 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"maps"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"slices"
+	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"gopkg.in/yaml.v3"
@@ -50,6 +54,31 @@ type Decryptor struct {
 	ageKeys    []string
 	ageKeyFile string
 	timeout    time.Duration
+
+	// keyProvider, when set, replaces ageKeys; its keys are cached
+	// for keyRefreshInterval.
+	keyProvider        KeyProvider
+	keyRefreshInterval time.Duration
+	keysMu             sync.Mutex
+	cachedKeys         []string
+	keysFetchedAt      time.Time
+
+	// cleanEnv, when set, runs sops with only PATH, the key variables and
+	// extraEnv instead of the operator's environment.
+	cleanEnv bool
+	extraEnv map[string]string
+
+	// useTempFile passes the document to sops in a temp file instead of on
+	// stdin
+	useTempFile bool
+
+	// secureTempWipe overwrites temp files before they are removed
+	secureTempWipe bool
+	tempFS         tempFileSystem
+
+	// maxDepth rejects decrypted documents nested deeper, zero disables it
+	maxDepth int
+
 	// For testing: allows overriding temp file creation
 	createTempFile TempFileCreator
 	// For testing: allows overriding command execution
@@ -66,6 +95,25 @@ func WithTimeout(d time.Duration) Option {
 	}
 }
 
+// WithCleanEnv runs sops with a minimal environment: PATH, the AGE key
+// variables, the credential variables of the other key backends and extra,
+// instead of inheriting the operator's environment.
+// Entries in extra take precedence, so extra["PATH"] restricts the search path.
+func WithCleanEnv(extra map[string]string) Option {
+	return func(dec *Decryptor) {
+		dec.cleanEnv = true
+		dec.extraEnv = extra
+	}
+}
+
+// WithTempFile passes the encrypted document to sops in a temp file instead
+// of on stdin, for sops releases that cannot read documents from stdin.
+func WithTempFile() Option {
+	return func(dec *Decryptor) {
+		dec.useTempFile = true
+	}
+}
+
 // withTempFileCreator is used internally for testing.
 func withTempFileCreator(fn TempFileCreator) Option {
 	return func(dec *Decryptor) {
@@ -80,23 +128,45 @@ func withCommandRunner(fn CommandRunner) Option {
 	}
 }
 
-// defaultCommandRunner runs sops decrypt using exec.CommandContext.
+// defaultCommandRunner runs sops using exec.CommandContext, with input on stdin.
 func defaultCommandRunner(ctx context.Context, name string, args []string, env []string, input []byte) ([]byte, error) {
 	cmd := exec.CommandContext(ctx, name, args...)
 	cmd.Env = env
 
+	operation := "decrypt"
+	if len(args) > 0 && args[0] == "-e" {
+		operation = "encrypt"
+	}
+
 	var stdout, stderr bytes.Buffer
 	cmd.Stdout = &stdout
 	cmd.Stderr = &stderr
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open stdin: %w", err)
+	}
 
-	if err := cmd.Run(); err != nil {
+	err = cmd.Start()
+	var writeErr error
+	if err == nil {
+		// A failed write is reported after the exit status, which explains it
+		_, writeErr = stdin.Write(input)
+		if closeErr := stdin.Close(); writeErr == nil {
+			writeErr = closeErr
+		}
+		err = cmd.Wait()
+	}
+	if err != nil {
 		if ctx.Err() == context.DeadlineExceeded {
-			return nil, fmt.Errorf("sops decrypt timed out")
+			return nil, fmt.Errorf("sops %s timed out", operation)
 		}
 		if ctx.Err() == context.Canceled {
-			return nil, fmt.Errorf("sops decrypt was canceled")
+			return nil, fmt.Errorf("sops %s was canceled", operation)
 		}
-		return nil, fmt.Errorf("sops decrypt failed: %w: %s", err, stderr.String())
+		return nil, fmt.Errorf("sops %s failed: %w: %s", operation, err, stderr.String())
+	}
+	if writeErr != nil {
+		return nil, fmt.Errorf("failed to write stdin: %w", writeErr)
 	}
 
 	return stdout.Bytes(), nil
@@ -105,10 +175,12 @@ func defaultCommandRunner(ctx context.Context, name string, args []string, env [
 // NewDecryptor creates a new Decryptor with the given AGE private keys.
 func NewDecryptor(ageKeys []string, opts ...Option) *Decryptor {
 	d := &Decryptor{
-		ageKeys:        ageKeys,
-		timeout:        DefaultDecryptTimeout,
-		createTempFile: defaultTempFileCreator,
-		runCommand:     defaultCommandRunner,
+		ageKeys:            ageKeys,
+		timeout:            DefaultDecryptTimeout,
+		keyRefreshInterval: DefaultKeyRefreshInterval,
+		createTempFile:     defaultTempFileCreator,
+		tempFS:             osTempFileSystem{},
+		runCommand:         defaultCommandRunner,
 	}
 	for _, opt := range opts {
 		opt(d)
@@ -119,41 +191,32 @@ func NewDecryptor(ageKeys []string, opts ...Option) *Decryptor {
 // NewDecryptorFromEnv creates a Decryptor using AGE keys from environment.
 // It checks SOPS_AGE_KEY and SOPS_AGE_KEY_FILE environment variables.
 func NewDecryptorFromEnv(opts ...Option) (*Decryptor, error) {
-	var keys []string
+	ctx := context.Background()
 
-	if key := os.Getenv("SOPS_AGE_KEY"); key != "" {
-		keys = append(keys, strings.Split(key, "\n")...)
-	}
+	// EnvKeyProvider never fails
+	keys, _ := EnvKeyProvider{}.AgeKeys(ctx)
 
 	keyFile := os.Getenv("SOPS_AGE_KEY_FILE")
 	if keyFile != "" {
 		keyFile = filepath.Clean(keyFile)
-		data, err := os.ReadFile(keyFile)
+		fileKeys, err := FileKeyProvider{Path: keyFile}.AgeKeys(ctx)
 		if err != nil {
-			return nil, fmt.Errorf("failed to read AGE key file %s: %w", keyFile, err)
+			return nil, err
 		}
-		keys = append(keys, strings.Split(string(data), "\n")...)
+		keys = append(keys, fileKeys...)
 	}
 
-	// Filter out empty lines and comments
-	var validKeys []string
-	for _, k := range keys {
-		k = strings.TrimSpace(k)
-		if k != "" && !strings.HasPrefix(k, "#") {
-			validKeys = append(validKeys, k)
-		}
-	}
-
-	if len(validKeys) == 0 {
+	if len(keys) == 0 {
 		return nil, fmt.Errorf("no AGE keys found in SOPS_AGE_KEY or SOPS_AGE_KEY_FILE")
 	}
 
 	d := &Decryptor{
-		ageKeys:        validKeys,
-		ageKeyFile:     keyFile,
-		timeout:        DefaultDecryptTimeout,
-		createTempFile: defaultTempFileCreator,
-		runCommand:     defaultCommandRunner,
+		ageKeys:            keys,
+		ageKeyFile:         keyFile,
+		timeout:            DefaultDecryptTimeout,
+		keyRefreshInterval: DefaultKeyRefreshInterval,
+		createTempFile:     defaultTempFileCreator,
+		runCommand:         defaultCommandRunner,
 	}
 	for _, opt := range opts {
 		opt(d)
@@ -167,6 +230,48 @@ type DecryptedData struct {
 	Data map[string][]byte
 	// StringData contains string values (for convenience).
 	StringData map[string]string
+	// KeyOrder lists the top-level keys in the order of the document. It is
+	// nil when the order is not known.
+	KeyOrder []string
+}
+
+// GetString returns the value stored for key as a string, preferring
+// StringData over Data.
+func (d *DecryptedData) GetString(key string) (string, bool) {
+	if value, ok := d.StringData[key]; ok {
+		return value, true
+	}
+	if value, ok := d.Data[key]; ok {
+		return string(value), true
+	}
+	return "", false
+}
+
+// GetBytes returns the value stored for key as bytes, preferring Data over
+// StringData.
+func (d *DecryptedData) GetBytes(key string) ([]byte, bool) {
+	if value, ok := d.Data[key]; ok {
+		return value, true
+	}
+	if value, ok := d.StringData[key]; ok {
+		return []byte(value), true
+	}
+	return nil, false
+}
+
+// Keys returns the sorted keys of Data and StringData.
+func (d *DecryptedData) Keys() []string {
+	keys := make([]string, 0, len(d.Data))
+	for key := range d.Data {
+		keys = append(keys, key)
+	}
+	for key := range d.StringData {
+		if _, ok := d.Data[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
 }
 
 // Decrypt decrypts a SOPS-encrypted YAML and returns the data.
@@ -177,13 +282,41 @@ func (d *Decryptor) Decrypt(encryptedYAML []byte) (*DecryptedData, error) {
 
 // DecryptWithContext decrypts with a custom context for cancellation.
 func (d *Decryptor) DecryptWithContext(ctx context.Context, encryptedYAML []byte) (*DecryptedData, error) {
+	start := time.Now()
 	decrypted, err := d.runSopsDecrypt(ctx, encryptedYAML)
+	decryptDuration.WithLabelValues(detectBackend(encryptedYAML)).Observe(time.Since(start).Seconds())
 	if err != nil {
 		return nil, err
 	}
+	if d.maxDepth > 0 {
+		if err := checkDepth(decrypted, d.maxDepth); err != nil {
+			return nil, err
+		}
+	}
 	return parseDecryptedYAML(decrypted)
 }
 
+// IsMACMismatch reports whether err is sops rejecting a document because its
+// MAC does not match the content, as for a document that was read while it
+// was being rewritten.
+func IsMACMismatch(err error) bool {
+	return err != nil && strings.Contains(err.Error(), "MAC mismatch")
+}
+
+// EncryptedKeys returns the keys of data that are still sops ciphertext,
+// sorted. sops configurations that encrypt map keys as well leave them as
+// ENC[...] after decrypting, and those cannot be used as Secret keys.
+func EncryptedKeys(data *DecryptedData) []string {
+	var keys []string
+	for key := range data.Data {
+		if strings.HasPrefix(key, "ENC[") {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
 // DecryptToYAML decrypts and returns raw YAML bytes.
 func (d *Decryptor) DecryptToYAML(encryptedYAML []byte) ([]byte, error) {
 	return d.DecryptToYAMLWithContext(context.Background(), encryptedYAML)
@@ -195,22 +328,35 @@ func (d *Decryptor) DecryptToYAMLWithContext(ctx context.Context, encryptedYAML
 }
 
 func (d *Decryptor) runSopsDecrypt(ctx context.Context, encryptedYAML []byte) ([]byte, error) {
-	// Create temp file for encrypted data
-	tmpFile, err := d.createTempFile("", "sops-*.yaml")
+	activeDecrypts.Inc()
+	defer activeDecrypts.Dec()
+
+	ageKeys, err := d.resolveAgeKeys(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create temp file: %w", err)
+		return nil, err
 	}
-	tmpPath := tmpFile.Name()
-	defer func() {
-		_ = tmpFile.Close()
-		_ = os.Remove(tmpPath)
-	}()
 
-	if _, err := tmpFile.Write(encryptedYAML); err != nil {
-		return nil, fmt.Errorf("failed to write temp file: %w", err)
-	}
-	if err := tmpFile.Close(); err != nil {
-		return nil, fmt.Errorf("failed to close temp file: %w", err)
+	// Pass the document on stdin, or in a temp file with WithTempFile
+	args := []string{"-d", "--input-type", "yaml", "--output-type", "yaml", "/dev/stdin"}
+	if d.useTempFile {
+		tmpFile, err := d.createTempFile("", "sops-*.yaml")
+		if err != nil {
+			return nil, fmt.Errorf("failed to create temp file: %w", err)
+		}
+		tempFilesCreated.Inc()
+		tmpPath := tmpFile.Name()
+		defer func() {
+			_ = tmpFile.Close()
+			d.removeTempFile(ctx, tmpPath, len(encryptedYAML))
+		}()
+
+		if _, err := tmpFile.Write(encryptedYAML); err != nil {
+			return nil, fmt.Errorf("failed to write temp file: %w", err)
+		}
+		if err := tmpFile.Close(); err != nil {
+			return nil, fmt.Errorf("failed to close temp file: %w", err)
+		}
+		args = []string{"-d", tmpPath}
 	}
 
 	// Create context with timeout
@@ -218,16 +364,135 @@ func (d *Decryptor) runSopsDecrypt(ctx context.Context, encryptedYAML []byte) ([
 	defer cancel()
 
 	// Set up environment for sops
-	env := os.Environ()
-	if len(d.ageKeys) > 0 {
-		env = append(env, "SOPS_AGE_KEY="+strings.Join(d.ageKeys, "\n"))
+	env := d.baseEnv()
+	if len(ageKeys) > 0 {
+		env = append(env, "SOPS_AGE_KEY="+strings.Join(ageKeys, "\n"))
 	}
 	if d.ageKeyFile != "" {
 		env = append(env, "SOPS_AGE_KEY_FILE="+d.ageKeyFile)
 	}
 
 	// Run sops decrypt
-	return d.runCommand(execCtx, "sops", []string{"-d", tmpPath}, env, encryptedYAML)
+	sopsProcessesStarted.Inc()
+	decrypted, err := d.runCommand(execCtx, "sops", args, env, encryptedYAML)
+	sopsProcessesCompleted.Inc()
+	if err != nil {
+		return nil, &CommandError{Args: args, EnvNames: envNames(env), Err: err}
+	}
+	return decrypted, nil
+}
+
+// CommandError is returned when running sops fails. Its message is that of
+// the underlying error, Detail adds how sops was invoked.
+type CommandError struct {
+	// Args are the arguments sops was run with.
+	Args []string
+	// EnvNames are the sorted names of the environment variables sops was
+	// run with. Their values are left out, they carry the keys.
+	EnvNames []string
+	Err      error
+}
+
+func (e *CommandError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *CommandError) Unwrap() error {
+	return e.Err
+}
+
+// Detail describes the failed invocation: the command line, the names of the
+// environment variables and the error, which includes the output of sops.
+// It may name key recipients and fingerprints.
+func (e *CommandError) Detail() string {
+	return fmt.Sprintf("command: sops %s\nenv: %s\nerror: %s",
+		strings.Join(e.Args, " "), strings.Join(e.EnvNames, " "), e.Err)
+}
+
+// ErrorDetail returns the Detail of every CommandError in err's tree, or the
+// message of err when it has none.
+func ErrorDetail(err error) string {
+	var details []string
+	var walk func(error)
+	walk = func(err error) {
+		if cmdErr, ok := err.(*CommandError); ok {
+			details = append(details, cmdErr.Detail())
+			return
+		}
+		switch u := err.(type) {
+		case interface{ Unwrap() error }:
+			if inner := u.Unwrap(); inner != nil {
+				walk(inner)
+			}
+		case interface{ Unwrap() []error }:
+			for _, inner := range u.Unwrap() {
+				walk(inner)
+			}
+		}
+	}
+	walk(err)
+	if len(details) == 0 {
+		return err.Error()
+	}
+	return strings.Join(details, "\n---\n")
+}
+
+// envNames returns the sorted names of the variables in env.
+func envNames(env []string) []string {
+	names := make([]string, 0, len(env))
+	for _, kv := range env {
+		name, _, _ := strings.Cut(kv, "=")
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return slices.Compact(names)
+}
+
+// credentialEnvPrefixes lists the environment variables, by prefix, that
+// carry credentials for the sops key backends. They are kept in a clean
+// environment, so a document encrypted to several backends can be decrypted
+// with whichever credentials are configured.
+var credentialEnvPrefixes = []string{
+	"SOPS_",                          // AGE and PGP settings
+	"GNUPGHOME",                      // PGP keyring
+	"AWS_",                           // AWS KMS
+	"GOOGLE_APPLICATION_CREDENTIALS", // GCP KMS
+	"AZURE_",                         // Azure Key Vault
+	"VAULT_",                         // HashiCorp Vault
+}
+
+// baseEnv returns the environment sops starts from before the key variables
+// are added.
+func (d *Decryptor) baseEnv() []string {
+	if !d.cleanEnv {
+		return os.Environ()
+	}
+
+	vars := map[string]string{"PATH": os.Getenv("PATH")}
+	for _, kv := range os.Environ() {
+		k, v, _ := strings.Cut(kv, "=")
+		if isCredentialEnv(k) {
+			vars[k] = v
+		}
+	}
+	for k, v := range d.extraEnv {
+		vars[k] = v
+	}
+	env := make([]string, 0, len(vars))
+	for _, k := range slices.Sorted(maps.Keys(vars)) {
+		env = append(env, k+"="+vars[k])
+	}
+	return env
+}
+
+// isCredentialEnv reports whether the variable configures a key backend.
+func isCredentialEnv(name string) bool {
+	for _, prefix := range credentialEnvPrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
 }
 
 // yamlMarshaler is a function type for marshaling values to YAML.
@@ -244,14 +509,20 @@ func parseDecryptedYAML(data []byte) (*DecryptedData, error) {
 func parseDecryptedYAMLWithMarshaler(data []byte, marshal yamlMarshaler) (*DecryptedData, error) {
 	var raw map[string]interface{}
 
+	// Decode through a node to keep the order of the keys, which the map loses
+	var doc yaml.Node
 	decoder := yaml.NewDecoder(bytes.NewReader(data))
-	if err := decoder.Decode(&raw); err != nil {
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("failed to parse decrypted YAML: %w", err)
+	}
+	if err := doc.Decode(&raw); err != nil {
 		return nil, fmt.Errorf("failed to parse decrypted YAML: %w", err)
 	}
 
 	result := &DecryptedData{
 		Data:       make(map[string][]byte),
 		StringData: make(map[string]string),
+		KeyOrder:   documentKeys(&doc),
 	}
 
 	for key, value := range raw {
@@ -260,6 +531,9 @@ func parseDecryptedYAMLWithMarshaler(data []byte, marshal yamlMarshaler) (*Decry
 			continue
 		}
 
+		// Aliases are resolved by the decoder, so a structure shared through an
+		// anchor is marshaled independently for every key that references it.
+		//
 		// Re-marshal each value wrapped under its original key to preserve YAML structure.
 		// This ensures Secret data entries maintain the top-level key as a wrapper,
 		// e.g. key "app" with nested value becomes "app:\n  db:\n    host: localhost".
@@ -276,6 +550,22 @@ func parseDecryptedYAMLWithMarshaler(data []byte, marshal yamlMarshaler) (*Decry
 	return result, nil
 }
 
+// documentKeys returns the top-level keys of a decoded document in document
+// order, without the sops block.
+func documentKeys(doc *yaml.Node) []string {
+	if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return nil
+	}
+	mapping := doc.Content[0]
+	keys := make([]string, 0, len(mapping.Content)/2)
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if key := mapping.Content[i].Value; key != "sops" {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
 // ValidateEncryptedYAML checks if the given data is a valid SOPS-encrypted YAML.
 func ValidateEncryptedYAML(data []byte) error {
 	if len(data) == 0 {
@@ -3,8 +3,11 @@ package sops
 import (
 	"context"
 	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
+	"reflect"
+	"slices"
 	"strings"
 	"testing"
 	"time"
@@ -569,7 +572,7 @@ func TestWithTempFileCreator(t *testing.T) {
 		return nil, errors.New("mock error")
 	}
 
-	d := NewDecryptor([]string{"test-key"}, withTempFileCreator(mockCreator))
+	d := NewDecryptor([]string{"test-key"}, WithTempFile(), withTempFileCreator(mockCreator))
 
 	_, err := d.Decrypt([]byte("test: value"))
 	if err == nil {
@@ -583,6 +586,50 @@ func TestWithTempFileCreator(t *testing.T) {
 	}
 }
 
+func TestRunSopsDecryptStdin(t *testing.T) {
+	document := []byte("key: ENC[test]\nsops:\n    mac: test\n")
+	var input []byte
+	runner := func(ctx context.Context, name string, args []string, env []string, in []byte) ([]byte, error) {
+		if args[len(args)-1] != "/dev/stdin" {
+			t.Errorf("sops args = %v, want the document read from /dev/stdin", args)
+		}
+		input = in
+		return []byte("key: value"), nil
+	}
+	tempFiles := func(dir, pattern string) (TempFile, error) {
+		t.Error("temp file was created without WithTempFile")
+		return nil, errors.New("unexpected")
+	}
+	d := NewDecryptor([]string{"test-key"}, withCommandRunner(runner), withTempFileCreator(tempFiles))
+
+	if _, err := d.Decrypt(document); err != nil {
+		t.Fatalf("Decrypt() error = %v", err)
+	}
+	if string(input) != string(document) {
+		t.Errorf("sops stdin = %q, want the document", input)
+	}
+}
+
+func TestRunSopsDecryptTempFile(t *testing.T) {
+	document := []byte("key: ENC[test]")
+	var contents []byte
+	runner := func(ctx context.Context, name string, args []string, env []string, input []byte) ([]byte, error) {
+		if len(args) != 2 || args[0] != "-d" {
+			t.Errorf("sops args = %v, want [-d <path>]", args)
+		}
+		contents, _ = os.ReadFile(args[1])
+		return []byte("key: value"), nil
+	}
+	d := NewDecryptor([]string{"test-key"}, WithTempFile(), withCommandRunner(runner))
+
+	if _, err := d.Decrypt(document); err != nil {
+		t.Fatalf("Decrypt() error = %v", err)
+	}
+	if string(contents) != string(document) {
+		t.Errorf("temp file = %q, want the document", contents)
+	}
+}
+
 // mockTempFileWriteError is a mock TempFile that fails on Write.
 type mockTempFileWriteError struct {
 	name string
@@ -600,7 +647,7 @@ func TestRunSopsDecrypt_TempFileWriteError(t *testing.T) {
 		return &mockTempFileWriteError{name: tmpPath}, nil
 	}
 
-	d := NewDecryptor([]string{"test-key"}, withTempFileCreator(mockCreator))
+	d := NewDecryptor([]string{"test-key"}, WithTempFile(), withTempFileCreator(mockCreator))
 
 	_, err := d.Decrypt([]byte("test: value"))
 	if err == nil {
@@ -629,7 +676,7 @@ func TestRunSopsDecrypt_TempFileCloseError(t *testing.T) {
 		return &mockTempFileCloseError{name: tmpPath}, nil
 	}
 
-	d := NewDecryptor([]string{"test-key"}, withTempFileCreator(mockCreator))
+	d := NewDecryptor([]string{"test-key"}, WithTempFile(), withTempFileCreator(mockCreator))
 
 	_, err := d.Decrypt([]byte("test: value"))
 	if err == nil {
@@ -800,6 +847,43 @@ config:
 	}
 }
 
+func TestParseDecryptedYAMLAnchors(t *testing.T) {
+	// An anchored map referenced by several keys must be materialized per key
+	input := `
+defaults: &defaults
+  host: localhost
+  port: 5432
+primary: *defaults
+replica:
+  <<: *defaults
+  host: replica.local
+`
+	result, err := parseDecryptedYAML([]byte(input))
+	if err != nil {
+		t.Fatalf("parseDecryptedYAML() error = %v", err)
+	}
+
+	wantVals := map[string]string{
+		"defaults": "defaults:\n    host: localhost\n    port: 5432",
+		"primary":  "primary:\n    host: localhost\n    port: 5432",
+		"replica":  "replica:\n    host: replica.local\n    port: 5432",
+	}
+	for key, want := range wantVals {
+		if got := result.StringData[key]; got != want {
+			t.Errorf("parseDecryptedYAML() StringData[%q] = %q, want %q", key, got, want)
+		}
+		if got := string(result.Data[key]); got != want {
+			t.Errorf("parseDecryptedYAML() Data[%q] = %q, want %q", key, got, want)
+		}
+	}
+
+	// Mutating one value must not leak into another key sharing the anchor
+	result.Data["primary"][0] = 'X'
+	if got := string(result.Data["defaults"]); got != wantVals["defaults"] {
+		t.Errorf("Data[defaults] changed after mutating Data[primary]: %q", got)
+	}
+}
+
 func TestWithCommandRunner(t *testing.T) {
 	// Test the withCommandRunner option
 	mockRunner := func(ctx context.Context, name string, args []string, env []string, input []byte) ([]byte, error) {
@@ -829,8 +913,8 @@ func TestDecryptWithContext_Success(t *testing.T) {
 		if name != "sops" {
 			t.Errorf("Expected command 'sops', got %q", name)
 		}
-		if len(args) != 2 || args[0] != "-d" {
-			t.Errorf("Expected args ['-d', <path>], got %v", args)
+		if want := []string{"-d", "--input-type", "yaml", "--output-type", "yaml", "/dev/stdin"}; !slices.Equal(args, want) {
+			t.Errorf("Expected args %v, got %v", want, args)
 		}
 		// Return decrypted YAML
 		return []byte("key: value\ncount: 42"), nil
@@ -915,6 +999,14 @@ func TestDefaultCommandRunner_CommandFailure(t *testing.T) {
 	}
 }
 
+func TestDefaultCommandRunner_StdinWriteFailure(t *testing.T) {
+	// true exits without reading, so writing more than a pipe buffer fails
+	_, err := defaultCommandRunner(context.Background(), "true", nil, nil, make([]byte, 1<<20))
+	if err == nil || !containsString(err.Error(), "failed to write stdin") {
+		t.Errorf("Expected 'failed to write stdin' error, got: %v", err)
+	}
+}
+
 func TestDefaultCommandRunner_Success(t *testing.T) {
 	// Test successful command execution
 	ctx := context.Background()
@@ -1013,3 +1105,252 @@ config:
 		t.Errorf("Error should contain 'failed to marshal value', got: %v", err)
 	}
 }
+
+func TestDecryptedDataAccessors(t *testing.T) {
+	data := &DecryptedData{
+		Data:       map[string][]byte{"password": []byte("secret"), "binary": {0x00, 0x01}},
+		StringData: map[string]string{"password": "secret", "username": "admin"},
+	}
+
+	tests := []struct {
+		key    string
+		want   string
+		wantOK bool
+	}{
+		{key: "password", want: "secret", wantOK: true},
+		{key: "username", want: "admin", wantOK: true},
+		{key: "binary", want: "\x00\x01", wantOK: true},
+		{key: "missing", want: "", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			gotString, ok := data.GetString(tt.key)
+			if gotString != tt.want || ok != tt.wantOK {
+				t.Errorf("GetString(%q) = %q, %v, want %q, %v", tt.key, gotString, ok, tt.want, tt.wantOK)
+			}
+			gotBytes, ok := data.GetBytes(tt.key)
+			if string(gotBytes) != tt.want || ok != tt.wantOK {
+				t.Errorf("GetBytes(%q) = %q, %v, want %q, %v", tt.key, gotBytes, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+
+	wantKeys := []string{"binary", "password", "username"}
+	if got := data.Keys(); !reflect.DeepEqual(got, wantKeys) {
+		t.Errorf("Keys() = %v, want %v", got, wantKeys)
+	}
+	if got := (&DecryptedData{}).Keys(); len(got) != 0 {
+		t.Errorf("Keys() on empty data = %v, want none", got)
+	}
+}
+
+// clearCredentialEnv unsets the key backend credential variables for the test.
+func clearCredentialEnv(t *testing.T) {
+	t.Helper()
+	for _, kv := range os.Environ() {
+		name, _, _ := strings.Cut(kv, "=")
+		if isCredentialEnv(name) {
+			t.Setenv(name, "")
+			if err := os.Unsetenv(name); err != nil {
+				t.Fatalf("Unsetenv(%s) error = %v", name, err)
+			}
+		}
+	}
+}
+
+func TestWithCleanEnv(t *testing.T) {
+	clearCredentialEnv(t)
+	t.Setenv("PATH", "/usr/bin")
+	t.Setenv("OPERATOR_TOKEN", "must-not-leak")
+
+	tests := []struct {
+		name    string
+		options []Option
+		want    []string
+	}{
+		{
+			name:    "path and key only",
+			options: []Option{WithCleanEnv(nil)},
+			want:    []string{"PATH=/usr/bin", "SOPS_AGE_KEY=AGE-SECRET-KEY-TEST"},
+		},
+		{
+			name:    "extras override path",
+			options: []Option{WithCleanEnv(map[string]string{"PATH": "/opt/sops/bin", "HOME": "/nonexistent"})},
+			want:    []string{"HOME=/nonexistent", "PATH=/opt/sops/bin", "SOPS_AGE_KEY=AGE-SECRET-KEY-TEST"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			runner := func(ctx context.Context, name string, args []string, env []string, input []byte) ([]byte, error) {
+				got = env
+				return []byte("key: value"), nil
+			}
+
+			d := NewDecryptor([]string{"AGE-SECRET-KEY-TEST"}, append(tt.options, withCommandRunner(runner))...)
+			if _, err := d.Decrypt([]byte("key: ENC[test]")); err != nil {
+				t.Fatalf("Decrypt() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("sops env = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDefaultEnvInheritsEnviron(t *testing.T) {
+	t.Setenv("OPERATOR_SETTING", "inherited")
+
+	var got []string
+	runner := func(ctx context.Context, name string, args []string, env []string, input []byte) ([]byte, error) {
+		got = env
+		return []byte("key: value"), nil
+	}
+
+	d := NewDecryptor([]string{"AGE-SECRET-KEY-TEST"}, withCommandRunner(runner))
+	if _, err := d.Decrypt([]byte("key: ENC[test]")); err != nil {
+		t.Fatalf("Decrypt() error = %v", err)
+	}
+	if !slices.Contains(got, "OPERATOR_SETTING=inherited") {
+		t.Errorf("sops env = %v, want the operator environment to be inherited", got)
+	}
+}
+
+func TestCleanEnvKeepsAllBackendCredentials(t *testing.T) {
+	clearCredentialEnv(t)
+	t.Setenv("PATH", "/usr/bin")
+	t.Setenv("OPERATOR_TOKEN", "must-not-leak")
+	credentials := map[string]string{
+		"SOPS_AGE_KEY_FILE":              "/keys/age.txt",
+		"GNUPGHOME":                      "/keys/gnupg",
+		"AWS_PROFILE":                    "sops",
+		"GOOGLE_APPLICATION_CREDENTIALS": "/keys/gcp.json",
+		"AZURE_CLIENT_ID":                "client",
+		"VAULT_ADDR":                     "https://vault:8200",
+	}
+	for k, v := range credentials {
+		t.Setenv(k, v)
+	}
+
+	calls := 0
+	var got []string
+	runner := func(ctx context.Context, name string, args []string, env []string, input []byte) ([]byte, error) {
+		calls++
+		got = env
+		return []byte("key: value"), nil
+	}
+
+	multiRecipient := []byte(`key: ENC[test]
+sops:
+    age:
+        - recipient: age1test
+    pgp:
+        - fp: 85D77543B3D624B63CEA9E6DBC17301B491B3F21
+    kms:
+        - arn: arn:aws:kms:eu-west-1:111122223333:key/test
+    mac: ENC[test]
+`)
+	d := NewDecryptor([]string{"AGE-SECRET-KEY-TEST"}, WithCleanEnv(nil), withCommandRunner(runner))
+	if _, err := d.Decrypt(multiRecipient); err != nil {
+		t.Fatalf("Decrypt() error = %v", err)
+	}
+
+	if calls != 1 {
+		t.Errorf("sops ran %d times, want a single invocation", calls)
+	}
+	for k, v := range credentials {
+		if !slices.Contains(got, k+"="+v) {
+			t.Errorf("sops env missing %s=%s: %v", k, v, got)
+		}
+	}
+	if !slices.Contains(got, "SOPS_AGE_KEY=AGE-SECRET-KEY-TEST") {
+		t.Errorf("sops env missing the configured AGE keys: %v", got)
+	}
+	if slices.Contains(got, "OPERATOR_TOKEN=must-not-leak") {
+		t.Errorf("sops env leaks unrelated variables: %v", got)
+	}
+}
+
+func TestIsMACMismatch(t *testing.T) {
+	mockRunner := func(ctx context.Context, name string, args []string, env []string, input []byte) ([]byte, error) {
+		return nil, errors.New("sops decrypt failed: exit status 1: MAC mismatch. File has ABC, computed DEF")
+	}
+	d := NewDecryptor([]string{"test-key"}, withCommandRunner(mockRunner))
+
+	_, err := d.Decrypt([]byte("encrypted: data"))
+	if !IsMACMismatch(err) {
+		t.Errorf("IsMACMismatch(%v) = false, want true", err)
+	}
+	if IsMACMismatch(errors.New("sops decrypt failed: exit status 128: no key could decrypt the data")) {
+		t.Error("IsMACMismatch() = true for a key error, want false")
+	}
+	if IsMACMismatch(nil) {
+		t.Error("IsMACMismatch(nil) = true, want false")
+	}
+}
+
+func TestErrorDetail(t *testing.T) {
+	mockRunner := func(ctx context.Context, name string, args []string, env []string, input []byte) ([]byte, error) {
+		return nil, errors.New("sops decrypt failed: exit status 128: no key could decrypt the data: age1recipient")
+	}
+	d := NewDecryptor([]string{"AGE-SECRET-KEY-1TEST"}, withCommandRunner(mockRunner), WithCleanEnv(nil))
+
+	_, err := d.Decrypt([]byte("encrypted: data"))
+	if err == nil {
+		t.Fatal("Decrypt() error = nil, want error")
+	}
+	if !strings.HasPrefix(err.Error(), "sops decrypt failed") {
+		t.Errorf("Decrypt() error = %q, want the message of the command error", err)
+	}
+	detail := ErrorDetail(err)
+	for _, want := range []string{"command: sops -d ", "env: ", " PATH ", " SOPS_AGE_KEY", "age1recipient"} {
+		if !strings.Contains(detail, want) {
+			t.Errorf("ErrorDetail() = %q, want it to contain %q", detail, want)
+		}
+	}
+	if strings.Contains(detail, "AGE-SECRET-KEY") {
+		t.Errorf("ErrorDetail() = %q contains the key", detail)
+	}
+
+	joined := errors.Join(fmt.Errorf("decryptor 0: %w", err), fmt.Errorf("decryptor 1: %w", err))
+	if got := strings.Count(ErrorDetail(joined), "command: sops"); got != 2 {
+		t.Errorf("ErrorDetail() of a joined error has %d invocations, want 2", got)
+	}
+	if got := ErrorDetail(errors.New("plain")); got != "plain" {
+		t.Errorf("ErrorDetail() = %q for an error without a command, want its message", got)
+	}
+}
+
+func TestEncryptedKeys(t *testing.T) {
+	data, err := parseDecryptedYAML([]byte(
+		"ENC[AES256_GCM,data:b2,type:str]: value\nplain: value\nENC[AES256_GCM,data:a1,type:str]: value\n"))
+	if err != nil {
+		t.Fatalf("parseDecryptedYAML() error = %v", err)
+	}
+
+	got := EncryptedKeys(data)
+	want := []string{"ENC[AES256_GCM,data:a1,type:str]", "ENC[AES256_GCM,data:b2,type:str]"}
+	if !slices.Equal(got, want) {
+		t.Errorf("EncryptedKeys() = %v, want %v", got, want)
+	}
+	if got := EncryptedKeys(&DecryptedData{Data: map[string][]byte{"plain": []byte("ENC[value]")}}); got != nil {
+		t.Errorf("EncryptedKeys() = %v for plaintext keys, want none", got)
+	}
+}
+
+func TestParseDecryptedYAMLKeyOrder(t *testing.T) {
+	data, err := parseDecryptedYAML([]byte("zeta: 1\nalpha: 2\nsops:\n  mac: test\nmid:\n  nested: 3\n"))
+	if err != nil {
+		t.Fatalf("parseDecryptedYAML() error = %v", err)
+	}
+
+	want := []string{"zeta", "alpha", "mid"}
+	if !slices.Equal(data.KeyOrder, want) {
+		t.Errorf("KeyOrder = %v, want %v", data.KeyOrder, want)
+	}
+	if got := OmitNullValues(data).KeyOrder; !slices.Equal(got, want) {
+		t.Errorf("OmitNullValues() KeyOrder = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,79 @@
+package sops
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DepthError is returned when a decrypted document is nested deeper than the
+// limit set with WithMaxDepth.
+type DepthError struct {
+	// Key is the top-level key whose value is nested too deeply.
+	Key string
+	// MaxDepth is the configured limit.
+	MaxDepth int
+}
+
+func (e *DepthError) Error() string {
+	return fmt.Sprintf("value of key %s is nested deeper than %d levels", e.Key, e.MaxDepth)
+}
+
+// IsTooDeeplyNested reports whether err is a decrypted document exceeding
+// the limit set with WithMaxDepth.
+func IsTooDeeplyNested(err error) bool {
+	var depthErr *DepthError
+	return errors.As(err, &depthErr)
+}
+
+// WithMaxDepth rejects decrypted documents with maps or lists nested more
+// than depth levels deep, counting the top-level map as the first level,
+// before they are converted. Aliases count with the depth of what they
+// reference. Zero disables the limit.
+func WithMaxDepth(depth int) Option {
+	return func(dec *Decryptor) {
+		dec.maxDepth = depth
+	}
+}
+
+// checkDepth returns a DepthError if the document is nested deeper than
+// maxDepth.
+func checkDepth(data []byte, maxDepth int) error {
+	var doc yaml.Node
+	if err := yaml.NewDecoder(bytes.NewReader(data)).Decode(&doc); err != nil {
+		return fmt.Errorf("failed to parse decrypted YAML: %w", err)
+	}
+	if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return nil
+	}
+	mapping := doc.Content[0]
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if exceedsDepth(mapping.Content[i+1], 1, maxDepth) {
+			return &DepthError{Key: mapping.Content[i].Value, MaxDepth: maxDepth}
+		}
+	}
+	return nil
+}
+
+// exceedsDepth reports whether node, found inside depth levels of
+// collections, takes the nesting beyond maxDepth. The walk stops at the
+// limit, so it ends for recursive aliases too.
+func exceedsDepth(node *yaml.Node, depth, maxDepth int) bool {
+	switch node.Kind {
+	case yaml.AliasNode:
+		return exceedsDepth(node.Alias, depth, maxDepth)
+	case yaml.MappingNode, yaml.SequenceNode:
+		depth++
+		if depth > maxDepth {
+			return true
+		}
+		for _, child := range node.Content {
+			if exceedsDepth(child, depth, maxDepth) {
+				return true
+			}
+		}
+	}
+	return false
+}
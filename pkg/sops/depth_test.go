@@ -0,0 +1,82 @@
+package sops
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestWithMaxDepth(t *testing.T) {
+	tests := []struct {
+		name     string
+		document string
+		maxDepth int
+		wantKey  string
+	}{
+		{
+			name:     "scalars",
+			document: "password: secret\nuser: admin\n",
+			maxDepth: 1,
+		},
+		{
+			name:     "at the limit",
+			document: "app:\n  db:\n    hosts:\n      - localhost\n",
+			maxDepth: 4,
+		},
+		{
+			name:     "beyond the limit",
+			document: "password: secret\napp:\n  db:\n    hosts:\n      - localhost\n",
+			maxDepth: 3,
+			wantKey:  "app",
+		},
+		{
+			name:     "beyond the limit through an alias",
+			document: "base: &base\n  db:\n    host: localhost\nref:\n  nested: *base\n",
+			maxDepth: 3,
+			wantKey:  "ref",
+		},
+		{
+			name:     "unlimited",
+			document: "app:\n  db:\n    hosts:\n      - localhost\n",
+			maxDepth: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runner := func(ctx context.Context, name string, args []string, env []string, input []byte) ([]byte, error) {
+				return []byte(tt.document), nil
+			}
+			d := NewDecryptor([]string{"test-key"}, withCommandRunner(runner), WithMaxDepth(tt.maxDepth))
+
+			result, err := d.Decrypt([]byte("key: ENC[test]"))
+			if tt.wantKey == "" {
+				if err != nil {
+					t.Fatalf("Decrypt() error = %v", err)
+				}
+				if len(result.Data) == 0 {
+					t.Error("Decrypt() returned no data")
+				}
+				return
+			}
+			if !IsTooDeeplyNested(err) {
+				t.Fatalf("Decrypt() error = %v, want a DepthError", err)
+			}
+			var depthErr *DepthError
+			if errors.As(err, &depthErr); depthErr.Key != tt.wantKey || depthErr.MaxDepth != tt.maxDepth {
+				t.Errorf("DepthError = %+v, want key %s and limit %d", depthErr, tt.wantKey, tt.maxDepth)
+			}
+		})
+	}
+}
+
+func TestIsTooDeeplyNested(t *testing.T) {
+	if IsTooDeeplyNested(nil) || IsTooDeeplyNested(errors.New("sops decrypt failed")) {
+		t.Error("IsTooDeeplyNested() = true for an unrelated error")
+	}
+	wrapped := fmt.Errorf("decrypt: %w", &DepthError{Key: "app", MaxDepth: 2})
+	if !IsTooDeeplyNested(wrapped) {
+		t.Error("IsTooDeeplyNested() = false for a wrapped DepthError")
+	}
+}
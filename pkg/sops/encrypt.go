@@ -0,0 +1,57 @@
+package sops
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// EncryptorInterface encrypts plaintext documents with sops.
+type EncryptorInterface interface {
+	EncryptWithContext(ctx context.Context, plaintextYAML []byte, ageRecipients []string) ([]byte, error)
+}
+
+var _ EncryptorInterface = &Decryptor{}
+
+// EncryptWithContext encrypts a plaintext YAML document to the given AGE
+// recipients. The plaintext is passed to sops on stdin and never written to disk.
+func (d *Decryptor) EncryptWithContext(ctx context.Context, plaintextYAML []byte, ageRecipients []string) ([]byte, error) {
+	if len(ageRecipients) == 0 {
+		return nil, errors.New("at least one AGE recipient is required")
+	}
+
+	execCtx, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+
+	args := []string{"-e", "--input-type", "yaml", "--output-type", "yaml"}
+	for _, recipient := range ageRecipients {
+		args = append(args, "--age", recipient)
+	}
+	args = append(args, "/dev/stdin")
+
+	encrypted, err := d.runCommand(execCtx, "sops", args, d.baseEnv(), plaintextYAML)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt document: %w", err)
+	}
+	return encrypted, nil
+}
+
+// Document rebuilds a flat YAML document from the decrypted data, one
+// top-level key per entry in sorted order. Values must still be YAML-wrapped
+// as returned by Decrypt.
+func (d *DecryptedData) Document() []byte {
+	keys := make([]string, 0, len(d.Data))
+	for key := range d.Data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var doc bytes.Buffer
+	for _, key := range keys {
+		doc.Write(d.Data[key])
+		doc.WriteByte('\n')
+	}
+	return doc.Bytes()
+}
@@ -0,0 +1,56 @@
+package sops
+
+import (
+	"context"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestEncryptWithContext(t *testing.T) {
+	const recipient = "age1backup"
+	var gotArgs []string
+	var gotInput []byte
+	runner := func(ctx context.Context, name string, args []string, env []string, input []byte) ([]byte, error) {
+		gotArgs = args
+		gotInput = input
+		return []byte("token: ENC[test]\nsops:\n    mac: ENC[test]\n"), nil
+	}
+
+	d := NewDecryptor(nil, withCommandRunner(runner))
+	encrypted, err := d.EncryptWithContext(context.Background(), []byte("token: abc\n"), []string{recipient})
+	if err != nil {
+		t.Fatalf("EncryptWithContext() error = %v", err)
+	}
+	if err := ValidateEncryptedYAML(encrypted); err != nil {
+		t.Errorf("EncryptWithContext() returned an invalid document: %v", err)
+	}
+	if gotArgs[0] != "-e" || !slices.Contains(gotArgs, recipient) || gotArgs[len(gotArgs)-1] != "/dev/stdin" {
+		t.Errorf("sops args = %v, want -e with --age %s reading stdin", gotArgs, recipient)
+	}
+	if string(gotInput) != "token: abc\n" {
+		t.Errorf("sops input = %q, want the plaintext document", gotInput)
+	}
+}
+
+func TestEncryptWithContextRequiresRecipient(t *testing.T) {
+	d := NewDecryptor(nil)
+	if _, err := d.EncryptWithContext(context.Background(), []byte("token: abc\n"), nil); err == nil {
+		t.Error("EncryptWithContext() expected error without recipients")
+	}
+}
+
+func TestDecryptedDataDocument(t *testing.T) {
+	original, err := parseDecryptedYAML([]byte("token: abc\nnested:\n  host: db\nlist:\n  - a\n  - b\n"))
+	if err != nil {
+		t.Fatalf("parseDecryptedYAML() error = %v", err)
+	}
+
+	roundTrip, err := parseDecryptedYAML(original.Document())
+	if err != nil {
+		t.Fatalf("parseDecryptedYAML(Document()) error = %v", err)
+	}
+	if !reflect.DeepEqual(roundTrip.Data, original.Data) {
+		t.Errorf("Document() round trip = %v, want %v", roundTrip.Data, original.Data)
+	}
+}
@@ -0,0 +1,54 @@
+package sops
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// FallbackDecryptor tries a chain of decryptors in order and returns the
+// result of the first one that succeeds. It eases migrations between key
+// systems: the decryptor with the new keys goes first, the one with the old
+// keys after it. Successful decrypts are counted by position in the chain, so
+// a migration is complete once the later decryptors are no longer used.
+type FallbackDecryptor struct {
+	decryptors []DecryptorInterface
+}
+
+// Ensure FallbackDecryptor implements DecryptorInterface
+var _ DecryptorInterface = &FallbackDecryptor{}
+
+// NewFallbackDecryptor returns a decryptor that tries decryptors in order.
+func NewFallbackDecryptor(decryptors ...DecryptorInterface) *FallbackDecryptor {
+	return &FallbackDecryptor{decryptors: decryptors}
+}
+
+// Decrypt decrypts SOPS-encrypted YAML with the first decryptor that succeeds.
+func (f *FallbackDecryptor) Decrypt(encryptedYAML []byte) (*DecryptedData, error) {
+	return f.DecryptWithContext(context.Background(), encryptedYAML)
+}
+
+// DecryptWithContext decrypts SOPS-encrypted YAML with the first decryptor
+// that succeeds. If all of them fail, the errors of every decryptor are
+// returned joined.
+func (f *FallbackDecryptor) DecryptWithContext(ctx context.Context, encryptedYAML []byte) (*DecryptedData, error) {
+	if len(f.decryptors) == 0 {
+		return nil, errors.New("no decryptors configured")
+	}
+
+	var errs []error
+	for i, d := range f.decryptors {
+		data, err := d.DecryptWithContext(ctx, encryptedYAML)
+		if err == nil {
+			fallbackDecrypts.WithLabelValues(strconv.Itoa(i)).Inc()
+			return data, nil
+		}
+		errs = append(errs, fmt.Errorf("decryptor %d: %w", i, err))
+		// Later decryptors would fail the same way
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	return nil, errors.Join(errs...)
+}
@@ -0,0 +1,81 @@
+package sops
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// failingDecryptor fails every decrypt with err and counts calls.
+type failingDecryptor struct {
+	err   error
+	calls int
+}
+
+func (d *failingDecryptor) Decrypt(encryptedYAML []byte) (*DecryptedData, error) {
+	return d.DecryptWithContext(context.Background(), encryptedYAML)
+}
+
+func (d *failingDecryptor) DecryptWithContext(_ context.Context, _ []byte) (*DecryptedData, error) {
+	d.calls++
+	return nil, d.err
+}
+
+func TestFallbackDecryptorFallsBack(t *testing.T) {
+	first := &failingDecryptor{err: errors.New("no key could decrypt the data")}
+	second := &countingDecryptor{}
+	third := &countingDecryptor{}
+	f := NewFallbackDecryptor(first, second, third)
+
+	before := metricValue(t, fallbackDecrypts.WithLabelValues("1"))
+	data, err := f.Decrypt([]byte("doc"))
+	if err != nil {
+		t.Fatalf("Decrypt() error = %v", err)
+	}
+	if string(data.Data["payload"]) != "doc" {
+		t.Errorf("Decrypt() payload = %q, want doc", data.Data["payload"])
+	}
+	if first.calls != 1 || second.calls != 1 || third.calls != 0 {
+		t.Errorf("calls = %d, %d, %d, want 1, 1, 0", first.calls, second.calls, third.calls)
+	}
+	if got := metricValue(t, fallbackDecrypts.WithLabelValues("1")) - before; got != 1 {
+		t.Errorf("decrypts by decryptor 1 = %v, want 1", got)
+	}
+}
+
+func TestFallbackDecryptorAllFail(t *testing.T) {
+	errNew := errors.New("new keys rejected")
+	errOld := errors.New("old keys rejected")
+	f := NewFallbackDecryptor(&failingDecryptor{err: errNew}, &failingDecryptor{err: errOld})
+
+	_, err := f.Decrypt([]byte("doc"))
+	if !errors.Is(err, errNew) || !errors.Is(err, errOld) {
+		t.Fatalf("Decrypt() error = %v, want both decryptor errors", err)
+	}
+	for _, want := range []string{"decryptor 0: new keys rejected", "decryptor 1: old keys rejected"} {
+		if !containsString(err.Error(), want) {
+			t.Errorf("Decrypt() error = %q, want it to contain %q", err, want)
+		}
+	}
+}
+
+func TestFallbackDecryptorStopsOnCanceledContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	first := &failingDecryptor{err: context.Canceled}
+	second := &countingDecryptor{}
+	f := NewFallbackDecryptor(first, second)
+
+	if _, err := f.DecryptWithContext(ctx, []byte("doc")); !errors.Is(err, context.Canceled) {
+		t.Fatalf("DecryptWithContext() error = %v, want context.Canceled", err)
+	}
+	if second.calls != 0 {
+		t.Errorf("second decryptor called %d times after cancel, want 0", second.calls)
+	}
+}
+
+func TestFallbackDecryptorEmpty(t *testing.T) {
+	if _, err := NewFallbackDecryptor().Decrypt([]byte("doc")); err == nil {
+		t.Error("Decrypt() with no decryptors succeeded, want error")
+	}
+}
@@ -0,0 +1,39 @@
+package sops
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ComplexValuesAsJSON re-encodes nested maps and lists as JSON, e.g. for a
+// config.json key. Scalar values are left as they are. Object keys are sorted,
+// so the output is stable for hashing.
+func ComplexValuesAsJSON(decrypted *DecryptedData) (*DecryptedData, error) {
+	result := &DecryptedData{
+		Data:       make(map[string][]byte, len(decrypted.Data)),
+		StringData: make(map[string]string, len(decrypted.Data)),
+		KeyOrder:   decrypted.KeyOrder,
+	}
+	for key, wrapped := range decrypted.Data {
+		result.Data[key] = wrapped
+		result.StringData[key] = string(wrapped)
+
+		// Top-level values are stored wrapped under their key, see parseDecryptedYAML
+		var raw map[string]any
+		if err := yaml.Unmarshal(wrapped, &raw); err != nil {
+			continue
+		}
+		switch value := raw[key].(type) {
+		case map[string]any, []any:
+			encoded, err := json.Marshal(value)
+			if err != nil {
+				return nil, fmt.Errorf("failed to encode value for key %s as JSON: %w", key, err)
+			}
+			result.Data[key] = encoded
+			result.StringData[key] = string(encoded)
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,87 @@
+package sops
+
+import (
+	"testing"
+)
+
+func TestComplexValuesAsJSON(t *testing.T) {
+	document := []byte(`config:
+  server:
+    port: 8080
+    host: localhost
+  features: [a, b]
+hosts:
+  - one
+  - two
+password: secret
+`)
+
+	flat, err := parseDecryptedYAML(document)
+	if err != nil {
+		t.Fatalf("parseDecryptedYAML() error = %v", err)
+	}
+	got, err := ComplexValuesAsJSON(flat)
+	if err != nil {
+		t.Fatalf("ComplexValuesAsJSON() error = %v", err)
+	}
+
+	tests := []struct {
+		key      string
+		wantYAML string
+		wantJSON string
+	}{
+		{
+			key:      "config",
+			wantYAML: "config:\n    features:\n        - a\n        - b\n    server:\n        host: localhost\n        port: 8080",
+			wantJSON: `{"features":["a","b"],"server":{"host":"localhost","port":8080}}`,
+		},
+		{
+			key:      "hosts",
+			wantYAML: "hosts:\n    - one\n    - two",
+			wantJSON: `["one","two"]`,
+		},
+		{
+			// Scalars keep their YAML form
+			key:      "password",
+			wantYAML: "password: secret",
+			wantJSON: "password: secret",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			if string(flat.Data[tt.key]) != tt.wantYAML {
+				t.Errorf("YAML value = %q, want %q", flat.Data[tt.key], tt.wantYAML)
+			}
+			if string(got.Data[tt.key]) != tt.wantJSON || got.StringData[tt.key] != tt.wantJSON {
+				t.Errorf("JSON value = %q, want %q", got.Data[tt.key], tt.wantJSON)
+			}
+		})
+	}
+}
+
+func TestComplexValuesAsJSONStable(t *testing.T) {
+	document := []byte("config:\n  z: 1\n  a: 2\n  m: {y: 3, b: 4}\n")
+
+	var first string
+	for i := range 10 {
+		flat, err := parseDecryptedYAML(document)
+		if err != nil {
+			t.Fatalf("parseDecryptedYAML() error = %v", err)
+		}
+		got, err := ComplexValuesAsJSON(flat)
+		if err != nil {
+			t.Fatalf("ComplexValuesAsJSON() error = %v", err)
+		}
+		if i == 0 {
+			first = got.StringData["config"]
+			continue
+		}
+		if got.StringData["config"] != first {
+			t.Fatalf("output changed between runs: %q != %q", got.StringData["config"], first)
+		}
+	}
+	if first != `{"a":2,"m":{"b":4,"y":3},"z":1}` {
+		t.Errorf("ComplexValuesAsJSON() = %q, want sorted keys", first)
+	}
+}
@@ -0,0 +1,131 @@
+package sops
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultKeyRefreshInterval is how long keys returned by a KeyProvider are reused.
+	DefaultKeyRefreshInterval = time.Minute
+)
+
+// KeyProvider supplies AGE private keys to a Decryptor.
+// Implementations can load keys from any store, e.g. a vault or a cloud secret manager.
+type KeyProvider interface {
+	AgeKeys(ctx context.Context) ([]string, error)
+}
+
+// KeyFingerprinter is implemented by decryptors that can identify the keys
+// they decrypt with. The fingerprint changes whenever the keys change and
+// never reveals them.
+type KeyFingerprinter interface {
+	KeyFingerprint(ctx context.Context) (string, error)
+}
+
+var _ KeyFingerprinter = &Decryptor{}
+
+// EnvKeyProvider reads AGE keys from the SOPS_AGE_KEY environment variable.
+type EnvKeyProvider struct{}
+
+// AgeKeys returns the newline-separated keys in SOPS_AGE_KEY.
+func (EnvKeyProvider) AgeKeys(_ context.Context) ([]string, error) {
+	return parseAgeKeys(os.Getenv("SOPS_AGE_KEY")), nil
+}
+
+// FileKeyProvider reads AGE keys from a key file, one key per line.
+type FileKeyProvider struct {
+	Path string
+}
+
+// AgeKeys reads the key file on every call, so rotated files are picked up.
+func (p FileKeyProvider) AgeKeys(_ context.Context) ([]string, error) {
+	path := filepath.Clean(p.Path)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read AGE key file %s: %w", path, err)
+	}
+	return parseAgeKeys(string(data)), nil
+}
+
+// WithKeyProvider loads AGE keys from p before each decrypt instead of using static keys.
+// Keys are cached for the refresh interval, see WithKeyRefreshInterval.
+func WithKeyProvider(p KeyProvider) Option {
+	return func(dec *Decryptor) {
+		dec.keyProvider = p
+	}
+}
+
+// WithKeyRefreshInterval sets how long keys returned by a KeyProvider are reused.
+// Zero fetches keys on every decrypt.
+func WithKeyRefreshInterval(d time.Duration) Option {
+	return func(dec *Decryptor) {
+		dec.keyRefreshInterval = d
+	}
+}
+
+// resolveAgeKeys returns the AGE keys to use for the next decrypt.
+func (d *Decryptor) resolveAgeKeys(ctx context.Context) ([]string, error) {
+	if d.keyProvider == nil {
+		return d.ageKeys, nil
+	}
+
+	d.keysMu.Lock()
+	defer d.keysMu.Unlock()
+
+	if d.cachedKeys != nil && time.Since(d.keysFetchedAt) < d.keyRefreshInterval {
+		return d.cachedKeys, nil
+	}
+
+	keys, err := d.keyProvider.AgeKeys(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AGE keys: %w", err)
+	}
+	d.cachedKeys = keys
+	d.keysFetchedAt = time.Now()
+	return keys, nil
+}
+
+// KeyFingerprint returns a hash of the AGE keys and the content of the AGE
+// key file the next decrypt will use.
+func (d *Decryptor) KeyFingerprint(ctx context.Context) (string, error) {
+	keys, err := d.resolveAgeKeys(ctx)
+	if err != nil {
+		return "", err
+	}
+	keys = slices.Sorted(slices.Values(keys))
+
+	hash := sha256.New()
+	for _, key := range keys {
+		hash.Write([]byte(key))
+		hash.Write([]byte{0})
+	}
+	if d.ageKeyFile != "" {
+		path := filepath.Clean(d.ageKeyFile)
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return "", fmt.Errorf("failed to read AGE key file %s: %w", path, err)
+		}
+		hash.Write(data)
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// parseAgeKeys splits newline-separated keys, dropping empty lines and comments.
+func parseAgeKeys(s string) []string {
+	var keys []string
+	for _, k := range strings.Split(s, "\n") {
+		k = strings.TrimSpace(k)
+		if k != "" && !strings.HasPrefix(k, "#") {
+			keys = append(keys, k)
+		}
+	}
+	return keys
+}
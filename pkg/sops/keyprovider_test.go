@@ -0,0 +1,150 @@
+package sops
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// rotatingKeyProvider returns a new key on every call.
+type rotatingKeyProvider struct {
+	calls int
+	err   error
+}
+
+func (p *rotatingKeyProvider) AgeKeys(_ context.Context) ([]string, error) {
+	p.calls++
+	if p.err != nil {
+		return nil, p.err
+	}
+	return []string{fmt.Sprintf("AGE-SECRET-KEY-%d", p.calls)}, nil
+}
+
+// ageKeyFromEnv returns the SOPS_AGE_KEY value passed to the command runner.
+func ageKeyFromEnv(env []string) string {
+	for _, e := range env {
+		if value, ok := strings.CutPrefix(e, "SOPS_AGE_KEY="); ok {
+			return value
+		}
+	}
+	return ""
+}
+
+func TestKeyProviderRotation(t *testing.T) {
+	provider := &rotatingKeyProvider{}
+	var seen []string
+	runner := func(ctx context.Context, name string, args []string, env []string, input []byte) ([]byte, error) {
+		seen = append(seen, ageKeyFromEnv(env))
+		return []byte("key: value"), nil
+	}
+
+	d := NewDecryptor(nil,
+		WithKeyProvider(provider),
+		WithKeyRefreshInterval(0),
+		withCommandRunner(runner),
+	)
+
+	for range 3 {
+		if _, err := d.Decrypt([]byte("key: ENC[test]")); err != nil {
+			t.Fatalf("Decrypt() error = %v", err)
+		}
+	}
+
+	want := []string{"AGE-SECRET-KEY-1", "AGE-SECRET-KEY-2", "AGE-SECRET-KEY-3"}
+	if strings.Join(seen, ",") != strings.Join(want, ",") {
+		t.Errorf("SOPS_AGE_KEY per decrypt = %v, want %v", seen, want)
+	}
+}
+
+func TestKeyProviderCaching(t *testing.T) {
+	provider := &rotatingKeyProvider{}
+	var seen []string
+	runner := func(ctx context.Context, name string, args []string, env []string, input []byte) ([]byte, error) {
+		seen = append(seen, ageKeyFromEnv(env))
+		return []byte("key: value"), nil
+	}
+
+	d := NewDecryptor(nil,
+		WithKeyProvider(provider),
+		WithKeyRefreshInterval(time.Hour),
+		withCommandRunner(runner),
+	)
+
+	for range 3 {
+		if _, err := d.Decrypt([]byte("key: ENC[test]")); err != nil {
+			t.Fatalf("Decrypt() error = %v", err)
+		}
+	}
+
+	if provider.calls != 1 {
+		t.Errorf("provider called %d times, want 1", provider.calls)
+	}
+	for i, key := range seen {
+		if key != "AGE-SECRET-KEY-1" {
+			t.Errorf("decrypt %d used key %q, want cached AGE-SECRET-KEY-1", i, key)
+		}
+	}
+}
+
+func TestKeyProviderError(t *testing.T) {
+	provider := &rotatingKeyProvider{err: errors.New("vault unavailable")}
+	runnerCalled := false
+	runner := func(ctx context.Context, name string, args []string, env []string, input []byte) ([]byte, error) {
+		runnerCalled = true
+		return nil, nil
+	}
+
+	d := NewDecryptor(nil, WithKeyProvider(provider), withCommandRunner(runner))
+
+	_, err := d.Decrypt([]byte("key: ENC[test]"))
+	if err == nil {
+		t.Fatal("Decrypt() expected error when key provider fails")
+	}
+	if !containsString(err.Error(), "failed to load AGE keys") || !containsString(err.Error(), "vault unavailable") {
+		t.Errorf("Decrypt() error = %v, want wrapped provider error", err)
+	}
+	if runnerCalled {
+		t.Error("sops should not run when keys cannot be loaded")
+	}
+}
+
+func TestEnvKeyProvider(t *testing.T) {
+	t.Setenv("SOPS_AGE_KEY", "# comment\nAGE-SECRET-KEY-A\n\n  AGE-SECRET-KEY-B  \n")
+
+	keys, err := EnvKeyProvider{}.AgeKeys(context.Background())
+	if err != nil {
+		t.Fatalf("AgeKeys() error = %v", err)
+	}
+	if len(keys) != 2 || keys[0] != "AGE-SECRET-KEY-A" || keys[1] != "AGE-SECRET-KEY-B" {
+		t.Errorf("AgeKeys() = %v, want [AGE-SECRET-KEY-A AGE-SECRET-KEY-B]", keys)
+	}
+}
+
+func TestFileKeyProvider(t *testing.T) {
+	keyFile := filepath.Join(t.TempDir(), "age.key")
+	if err := os.WriteFile(keyFile, []byte("# created: today\nAGE-SECRET-KEY-A\n"), 0600); err != nil {
+		t.Fatalf("Failed to write key file: %v", err)
+	}
+
+	t.Run("reads keys", func(t *testing.T) {
+		keys, err := FileKeyProvider{Path: keyFile}.AgeKeys(context.Background())
+		if err != nil {
+			t.Fatalf("AgeKeys() error = %v", err)
+		}
+		if len(keys) != 1 || keys[0] != "AGE-SECRET-KEY-A" {
+			t.Errorf("AgeKeys() = %v, want [AGE-SECRET-KEY-A]", keys)
+		}
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		_, err := FileKeyProvider{Path: filepath.Join(t.TempDir(), "missing")}.AgeKeys(context.Background())
+		if err == nil || !containsString(err.Error(), "failed to read AGE key file") {
+			t.Errorf("AgeKeys() error = %v, want error containing 'failed to read AGE key file'", err)
+		}
+	})
+}
@@ -0,0 +1,65 @@
+package sops
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// FromSecretManifest extracts the entries of a decrypted Kubernetes Secret
+// manifest, e.g. a document created with `sops -e secret.yaml` from a Secret.
+// Values under data are base64-decoded, values under stringData are used as-is
+// and take precedence over data, as they do in the API server.
+// Other top-level keys, such as metadata or comments added by tooling, are
+// ignored.
+func FromSecretManifest(decrypted *DecryptedData) (*DecryptedData, error) {
+	data, err := manifestSection(decrypted, "data")
+	if err != nil {
+		return nil, err
+	}
+	stringData, err := manifestSection(decrypted, "stringData")
+	if err != nil {
+		return nil, err
+	}
+	if data == nil && stringData == nil {
+		return nil, errors.New("decrypted Secret manifest has no data or stringData")
+	}
+
+	result := &DecryptedData{
+		Data:       make(map[string][]byte, len(data)+len(stringData)),
+		StringData: make(map[string]string, len(data)+len(stringData)),
+	}
+	for key, value := range data {
+		decoded, err := base64.StdEncoding.DecodeString(value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode data.%s: %w", key, err)
+		}
+		result.Data[key] = decoded
+		result.StringData[key] = string(decoded)
+	}
+	for key, value := range stringData {
+		result.Data[key] = []byte(value)
+		result.StringData[key] = value
+	}
+	return result, nil
+}
+
+// manifestSection returns the string map stored under a top-level key of the
+// decrypted document, or nil when the key is absent.
+func manifestSection(decrypted *DecryptedData, key string) (map[string]string, error) {
+	wrapped, ok := decrypted.Data[key]
+	if !ok {
+		return nil, nil
+	}
+	// Top-level values are stored wrapped under their key, see parseDecryptedYAML
+	var section map[string]map[string]string
+	if err := yaml.Unmarshal(wrapped, &section); err != nil {
+		return nil, fmt.Errorf("invalid %s in decrypted Secret manifest: %w", key, err)
+	}
+	if section[key] == nil {
+		return map[string]string{}, nil
+	}
+	return section[key], nil
+}
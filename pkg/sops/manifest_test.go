@@ -0,0 +1,112 @@
+package sops
+
+import (
+	"testing"
+)
+
+func TestFromSecretManifest(t *testing.T) {
+	tests := []struct {
+		name     string
+		document string
+		want     map[string]string
+		wantErr  string
+	}{
+		{
+			name: "data and stringData",
+			document: `apiVersion: v1
+kind: Secret
+metadata:
+  name: db
+data:
+  username: YWRtaW4=
+  password: b2xk
+stringData:
+  password: new
+`,
+			want: map[string]string{"username": "admin", "password": "new"},
+		},
+		{
+			name: "stringData only",
+			document: `kind: Secret
+stringData:
+  token: abc
+`,
+			want: map[string]string{"token": "abc"},
+		},
+		{
+			name: "extra top-level keys",
+			document: `apiVersion: v1
+kind: Secret
+metadata:
+  name: db
+  annotations:
+    note: rotated by hand
+sops_comment: re-encrypted for the new recipient
+x-generator:
+  tool: kustomize
+  inputs: [base, overlay]
+empty: null
+type: Opaque
+data:
+  username: YWRtaW4=
+`,
+			want: map[string]string{"username": "admin"},
+		},
+		{
+			name: "data not a map",
+			document: `kind: Secret
+data:
+  - YWRtaW4=
+`,
+			wantErr: "invalid data",
+		},
+		{
+			name:     "no data",
+			document: "kind: Secret\n",
+			wantErr:  "has no data or stringData",
+		},
+		{
+			name: "invalid base64",
+			document: `data:
+  token: "not base64!"
+`,
+			wantErr: "failed to decode data.token",
+		},
+		{
+			name: "nested values",
+			document: `data:
+  token:
+    nested: value
+`,
+			wantErr: "invalid data",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			flat, err := parseDecryptedYAML([]byte(tt.document))
+			if err != nil {
+				t.Fatalf("parseDecryptedYAML() error = %v", err)
+			}
+
+			got, err := FromSecretManifest(flat)
+			if tt.wantErr != "" {
+				if err == nil || !containsString(err.Error(), tt.wantErr) {
+					t.Fatalf("FromSecretManifest() error = %v, want error containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("FromSecretManifest() error = %v", err)
+			}
+			if len(got.Data) != len(tt.want) {
+				t.Errorf("FromSecretManifest() keys = %v, want %v", got.StringData, tt.want)
+			}
+			for key, value := range tt.want {
+				if string(got.Data[key]) != value || got.StringData[key] != value {
+					t.Errorf("FromSecretManifest()[%s] = %q, want %q", key, got.Data[key], value)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,253 @@
+package sops
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Key backends a SOPS document can be encrypted with.
+const (
+	BackendAge     = "age"
+	BackendPGP     = "pgp"
+	BackendKMS     = "kms"
+	BackendGCPKMS  = "gcp_kms"
+	BackendAzureKV = "azure_kv"
+	BackendHCVault = "hc_vault"
+
+	// BackendMixed is reported for documents encrypted with more than one backend.
+	BackendMixed = "mixed"
+	// BackendUnknown is reported when no backend can be determined.
+	BackendUnknown = "unknown"
+)
+
+// SopsMetadata is the sops block of an encrypted document.
+type SopsMetadata struct {
+	KeyGroup `yaml:",inline"`
+
+	// KeyGroups holds recipients for Shamir secret sharing, used instead of
+	// the top-level recipient lists.
+	KeyGroups []KeyGroup `yaml:"key_groups,omitempty"`
+
+	MAC          string `yaml:"mac,omitempty"`
+	LastModified string `yaml:"lastmodified,omitempty"`
+	Version      string `yaml:"version,omitempty"`
+
+	// MACOnlyEncrypted is set when the MAC covers only the encrypted values,
+	// so plaintext values can be changed without failing verification.
+	MACOnlyEncrypted bool `yaml:"mac_only_encrypted,omitempty"`
+}
+
+// KeyGroup lists the recipients of each key backend.
+type KeyGroup struct {
+	Age     []AgeRecipient `yaml:"age,omitempty"`
+	PGP     []PGPKey       `yaml:"pgp,omitempty"`
+	KMS     []KMSKey       `yaml:"kms,omitempty"`
+	GCPKMS  []GCPKMSKey    `yaml:"gcp_kms,omitempty"`
+	AzureKV []AzureKVKey   `yaml:"azure_kv,omitempty"`
+	HCVault []HCVaultKey   `yaml:"hc_vault,omitempty"`
+}
+
+// AgeRecipient is an AGE recipient in the sops block.
+type AgeRecipient struct {
+	Recipient string `yaml:"recipient"`
+}
+
+// PGPKey is a PGP key in the sops block.
+type PGPKey struct {
+	Fingerprint string `yaml:"fp"`
+}
+
+// KMSKey is an AWS KMS key in the sops block.
+type KMSKey struct {
+	ARN string `yaml:"arn"`
+}
+
+// GCPKMSKey is a GCP KMS key in the sops block.
+type GCPKMSKey struct {
+	ResourceID string `yaml:"resource_id"`
+}
+
+// AzureKVKey is an Azure Key Vault key in the sops block.
+type AzureKVKey struct {
+	VaultURL string `yaml:"vault_url"`
+	Name     string `yaml:"name"`
+	Version  string `yaml:"version"`
+}
+
+// HCVaultKey is a HashiCorp Vault transit key in the sops block.
+type HCVaultKey struct {
+	VaultAddress string `yaml:"vault_address"`
+	EnginePath   string `yaml:"engine_path"`
+	KeyName      string `yaml:"key_name"`
+}
+
+// ParseSopsMetadata returns the sops block of an encrypted document.
+func ParseSopsMetadata(encryptedYAML []byte) (*SopsMetadata, error) {
+	var doc struct {
+		Sops *SopsMetadata `yaml:"sops"`
+	}
+	if err := yaml.Unmarshal(encryptedYAML, &doc); err != nil {
+		return nil, fmt.Errorf("invalid sops metadata: %w", err)
+	}
+	if doc.Sops == nil {
+		return nil, errors.New("missing sops metadata block")
+	}
+	return doc.Sops, nil
+}
+
+// Backends returns the key backends the document is encrypted with, across
+// the top-level recipients and all key groups.
+func (m *SopsMetadata) Backends() []string {
+	groups := append([]KeyGroup{m.KeyGroup}, m.KeyGroups...)
+
+	var backends []string
+	for _, b := range []struct {
+		name  string
+		count func(KeyGroup) int
+	}{
+		{BackendAge, func(g KeyGroup) int { return len(g.Age) }},
+		{BackendPGP, func(g KeyGroup) int { return len(g.PGP) }},
+		{BackendKMS, func(g KeyGroup) int { return len(g.KMS) }},
+		{BackendGCPKMS, func(g KeyGroup) int { return len(g.GCPKMS) }},
+		{BackendAzureKV, func(g KeyGroup) int { return len(g.AzureKV) }},
+		{BackendHCVault, func(g KeyGroup) int { return len(g.HCVault) }},
+	} {
+		for _, g := range groups {
+			if b.count(g) > 0 {
+				backends = append(backends, b.name)
+				break
+			}
+		}
+	}
+	return backends
+}
+
+// KeyIDs returns the identifiers of all keys the document is encrypted to,
+// across the top-level recipients and all key groups: AGE recipients, PGP
+// fingerprints, KMS ARNs, GCP KMS resource IDs, Azure Key Vault key URLs and
+// Vault transit key URLs.
+func (m *SopsMetadata) KeyIDs() []string {
+	var ids []string
+	for _, g := range append([]KeyGroup{m.KeyGroup}, m.KeyGroups...) {
+		for _, k := range g.Age {
+			ids = append(ids, k.Recipient)
+		}
+		for _, k := range g.PGP {
+			ids = append(ids, k.Fingerprint)
+		}
+		for _, k := range g.KMS {
+			ids = append(ids, k.ARN)
+		}
+		for _, k := range g.GCPKMS {
+			ids = append(ids, k.ResourceID)
+		}
+		for _, k := range g.AzureKV {
+			ids = append(ids, strings.TrimSuffix(k.VaultURL, "/")+"/keys/"+k.Name+"/"+k.Version)
+		}
+		for _, k := range g.HCVault {
+			ids = append(ids, strings.TrimSuffix(k.VaultAddress, "/")+"/v1/"+k.EnginePath+"/keys/"+k.KeyName)
+		}
+	}
+	return ids
+}
+
+// UsesKey reports whether the document is encrypted to the key with the given
+// identifier, as returned by KeyIDs. PGP fingerprints match case-insensitively.
+func (m *SopsMetadata) UsesKey(id string) bool {
+	for _, keyID := range m.KeyIDs() {
+		if keyID == id || strings.EqualFold(keyID, id) && isHex(id) {
+			return true
+		}
+	}
+	return false
+}
+
+// isHex reports whether s only holds hexadecimal digits, like a PGP fingerprint.
+func isHex(s string) bool {
+	for _, c := range s {
+		if !strings.ContainsRune("0123456789abcdefABCDEF", c) {
+			return false
+		}
+	}
+	return s != ""
+}
+
+// Backend returns the single backend the document is encrypted with,
+// BackendMixed for several or BackendUnknown for none.
+func (m *SopsMetadata) Backend() string {
+	switch backends := m.Backends(); len(backends) {
+	case 0:
+		return BackendUnknown
+	case 1:
+		return backends[0]
+	default:
+		return BackendMixed
+	}
+}
+
+// VersionBefore reports whether the document was written by a sops release
+// older than version. It is false when either version cannot be parsed.
+func (m *SopsMetadata) VersionBefore(version string) bool {
+	have, err := parseVersion(m.Version)
+	if err != nil {
+		return false
+	}
+	want, err := parseVersion(version)
+	if err != nil {
+		return false
+	}
+	return slices.Compare(have, want) < 0
+}
+
+// ValidateVersion checks that version is a sops version such as 3.8.1.
+func ValidateVersion(version string) error {
+	_, err := parseVersion(version)
+	return err
+}
+
+// parseVersion returns the major, minor and patch numbers of a version such
+// as 3.8.1, ignoring a v prefix and pre-release or build suffixes.
+func parseVersion(version string) ([]int, error) {
+	core, _, _ := strings.Cut(strings.TrimPrefix(version, "v"), "-")
+	core, _, _ = strings.Cut(core, "+")
+	parts := strings.Split(core, ".")
+	if len(parts) > 3 {
+		return nil, fmt.Errorf("invalid version %q", version)
+	}
+	numbers := make([]int, 3)
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("invalid version %q", version)
+		}
+		numbers[i] = n
+	}
+	return numbers, nil
+}
+
+// ValidateRecipients rejects a document whose sops block carries a MAC but no
+// recipients, since nobody can decrypt it.
+func ValidateRecipients(encryptedYAML []byte) error {
+	metadata, err := ParseSopsMetadata(encryptedYAML)
+	if err != nil {
+		return err
+	}
+	if metadata.MAC != "" && len(metadata.Backends()) == 0 {
+		return errors.New("sops metadata has a MAC but no recipients, the document cannot be decrypted")
+	}
+	return nil
+}
+
+// detectBackend returns the backend of an encrypted document for metrics.
+func detectBackend(encryptedYAML []byte) string {
+	metadata, err := ParseSopsMetadata(encryptedYAML)
+	if err != nil {
+		return BackendUnknown
+	}
+	return metadata.Backend()
+}
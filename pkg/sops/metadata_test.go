@@ -0,0 +1,210 @@
+package sops
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+const (
+	ageOnlyDocument = `token: ENC[test]
+sops:
+    age:
+        - recipient: age1qyqszqgpqyqszqgpqyqszqgpqyqszqgpqyqszqgpqyqszqgpqyqs3290gq
+    mac: ENC[test]
+    version: 3.9.0
+`
+	kmsOnlyDocument = `token: ENC[test]
+sops:
+    kms:
+        - arn: arn:aws:kms:eu-west-1:111122223333:key/test
+    mac: ENC[test]
+`
+	mixedDocument = `token: ENC[test]
+sops:
+    age:
+        - recipient: age1qyqszqgpqyqszqgpqyqszqgpqyqszqgpqyqszqgpqyqszqgpqyqs3290gq
+    hc_vault:
+        - vault_address: https://vault.example.com
+          engine_path: sops
+          key_name: app
+    mac: ENC[test]
+`
+)
+
+func TestParseSopsMetadata(t *testing.T) {
+	metadata, err := ParseSopsMetadata([]byte(ageOnlyDocument))
+	if err != nil {
+		t.Fatalf("ParseSopsMetadata() error = %v", err)
+	}
+	if len(metadata.Age) != 1 || metadata.Age[0].Recipient == "" {
+		t.Errorf("Age = %v, want one recipient", metadata.Age)
+	}
+	if metadata.Version != "3.9.0" || metadata.MAC != "ENC[test]" {
+		t.Errorf("Version = %q, MAC = %q", metadata.Version, metadata.MAC)
+	}
+
+	if _, err := ParseSopsMetadata([]byte("token: plain")); err == nil {
+		t.Error("ParseSopsMetadata() expected error without sops block")
+	}
+}
+
+func TestSopsMetadataBackend(t *testing.T) {
+	tests := []struct {
+		name     string
+		document string
+		want     string
+	}{
+		{name: "age only", document: ageOnlyDocument, want: BackendAge},
+		{name: "kms only", document: kmsOnlyDocument, want: BackendKMS},
+		{name: "mixed", document: mixedDocument, want: BackendMixed},
+		{name: "key groups", document: "sops:\n    key_groups:\n        - pgp:\n            - fp: ABC\n    mac: ENC[test]\n", want: BackendPGP},
+		{name: "no recipients", document: "sops:\n    mac: ENC[test]\n", want: BackendUnknown},
+		{name: "no sops block", document: "token: plain\n", want: BackendUnknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := detectBackend([]byte(tt.document)); got != tt.want {
+				t.Errorf("detectBackend() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateRecipients(t *testing.T) {
+	tests := []struct {
+		name     string
+		document string
+		wantErr  bool
+	}{
+		{name: "age recipient", document: ageOnlyDocument},
+		{name: "key group recipient", document: "sops:\n    key_groups:\n        - age:\n            - recipient: age1test\n    mac: ENC[test]\n"},
+		{name: "no recipients", document: "token: ENC[test]\nsops:\n    age: []\n    kms: []\n    mac: ENC[test]\n", wantErr: true},
+		{name: "no recipients and no MAC", document: "sops:\n    version: 3.9.0\n"},
+		{name: "no sops block", document: "token: plain\n", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateRecipients([]byte(tt.document))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateRecipients() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSopsMetadataUsesKey(t *testing.T) {
+	metadata, err := ParseSopsMetadata([]byte(`sops:
+    pgp:
+        - fp: 85D77543B3D624B63CEA9E6DBC17301B491B3F21
+    key_groups:
+        - kms:
+            - arn: arn:aws:kms:eu-west-1:111122223333:key/test
+          hc_vault:
+            - vault_address: https://vault:8200
+              engine_path: sops
+              key_name: firstkey
+    mac: ENC[test]
+`))
+	if err != nil {
+		t.Fatalf("ParseSopsMetadata() error = %v", err)
+	}
+
+	tests := []struct {
+		id   string
+		want bool
+	}{
+		{id: "85D77543B3D624B63CEA9E6DBC17301B491B3F21", want: true},
+		{id: "85d77543b3d624b63cea9e6dbc17301b491b3f21", want: true},
+		{id: "arn:aws:kms:eu-west-1:111122223333:key/test", want: true},
+		{id: "https://vault:8200/v1/sops/keys/firstkey", want: true},
+		{id: "arn:aws:kms:eu-west-1:111122223333:key/other", want: false},
+		{id: "ARN:AWS:KMS:EU-WEST-1:111122223333:KEY/TEST", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id, func(t *testing.T) {
+			if got := metadata.UsesKey(tt.id); got != tt.want {
+				t.Errorf("UsesKey(%q) = %v, want %v", tt.id, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSopsMetadataVersionBefore(t *testing.T) {
+	tests := []struct {
+		version string
+		min     string
+		want    bool
+	}{
+		{version: "3.7.3", min: "3.8.0", want: true},
+		{version: "3.8.0", min: "3.8.0", want: false},
+		{version: "3.10.1", min: "3.9", want: false},
+		{version: "3.9.0-rc.1", min: "3.9.1", want: true},
+		{version: "v2.0.0", min: "3", want: true},
+		{version: "", min: "3.8.0", want: false},
+		{version: "unknown", min: "3.8.0", want: false},
+		{version: "3.7.0", min: "latest", want: false},
+	}
+	for _, tt := range tests {
+		metadata := &SopsMetadata{Version: tt.version}
+		if got := metadata.VersionBefore(tt.min); got != tt.want {
+			t.Errorf("VersionBefore(%q) with version %q = %v, want %v", tt.min, tt.version, got, tt.want)
+		}
+	}
+
+	if err := ValidateVersion("3.8.1"); err != nil {
+		t.Errorf("ValidateVersion(3.8.1) error = %v", err)
+	}
+	if err := ValidateVersion("3.8.1.4"); err == nil {
+		t.Error("ValidateVersion(3.8.1.4) expected error")
+	}
+}
+
+func TestParseSopsMetadataMACOnlyEncrypted(t *testing.T) {
+	metadata, err := ParseSopsMetadata([]byte(ageOnlyDocument + "    mac_only_encrypted: true\n"))
+	if err != nil {
+		t.Fatalf("ParseSopsMetadata() error = %v", err)
+	}
+	if !metadata.MACOnlyEncrypted {
+		t.Error("MACOnlyEncrypted = false, want true")
+	}
+}
+
+// decryptSamples returns the number of decrypt durations observed for backend.
+func decryptSamples(t *testing.T, backend string) uint64 {
+	t.Helper()
+	m := &dto.Metric{}
+	if err := decryptDuration.WithLabelValues(backend).(prometheus.Metric).Write(m); err != nil {
+		t.Fatalf("failed to read histogram: %v", err)
+	}
+	return m.GetHistogram().GetSampleCount()
+}
+
+func TestDecryptDurationBackendLabel(t *testing.T) {
+	runner := func(ctx context.Context, name string, args []string, env []string, input []byte) ([]byte, error) {
+		return []byte("token: value"), nil
+	}
+	d := NewDecryptor([]string{"AGE-SECRET-KEY-TEST"}, withCommandRunner(runner))
+
+	for _, tt := range []struct {
+		document string
+		backend  string
+	}{
+		{ageOnlyDocument, BackendAge},
+		{kmsOnlyDocument, BackendKMS},
+		{mixedDocument, BackendMixed},
+	} {
+		before := decryptSamples(t, tt.backend)
+		if _, err := d.Decrypt([]byte(tt.document)); err != nil {
+			t.Fatalf("Decrypt() error = %v", err)
+		}
+		if got := decryptSamples(t, tt.backend) - before; got != 1 {
+			t.Errorf("backend %q observed %d decrypts, want 1", tt.backend, got)
+		}
+	}
+}
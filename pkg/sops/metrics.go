@@ -0,0 +1,75 @@
+package sops
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	// cacheEntries is the number of decrypted documents held by the decrypt cache.
+	cacheEntries = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "sopssecret_cache_entries",
+		Help: "Number of decrypted documents held in the decrypt cache.",
+	})
+
+	// cacheBytes is the estimated size of the decrypted data held by the decrypt cache.
+	cacheBytes = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "sopssecret_cache_bytes",
+		Help: "Estimated size in bytes of the decrypted data held in the decrypt cache.",
+	})
+
+	// cacheEvictions counts entries dropped from the decrypt cache to stay within its size limit.
+	cacheEvictions = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "sopssecret_cache_evictions_total",
+		Help: "Total number of entries evicted from the decrypt cache to stay within its size limit.",
+	})
+
+	// decryptDuration tracks how long sops takes to decrypt a document, by key backend.
+	decryptDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "sopssecret_decrypt_duration_seconds",
+		Help:    "Time taken by sops to decrypt a document, by key backend.",
+		Buckets: prometheus.ExponentialBuckets(0.01, 2, 12),
+	}, []string{"backend"})
+
+	// fallbackDecrypts counts documents decrypted by a FallbackDecryptor, by
+	// the position of the decryptor in the chain that succeeded.
+	fallbackDecrypts = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "sopssecret_fallback_decrypts_total",
+		Help: "Total number of documents decrypted by a fallback chain, by position of the decryptor that succeeded.",
+	}, []string{"decryptor"})
+
+	// activeDecrypts is the number of decrypts in progress. It stays above
+	// zero when a decrypt hangs.
+	activeDecrypts = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "sopssecret_active_decrypts",
+		Help: "Number of decrypts in progress.",
+	})
+
+	// tempFilesCreated and tempFilesRemoved count the temp files the
+	// encrypted documents are passed to sops in. A growing difference means
+	// temp files are left behind.
+	tempFilesCreated = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "sopssecret_temp_files_created_total",
+		Help: "Total number of temp files created to pass documents to sops.",
+	})
+	tempFilesRemoved = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "sopssecret_temp_files_removed_total",
+		Help: "Total number of temp files removed after passing documents to sops.",
+	})
+
+	// sopsProcessesStarted and sopsProcessesCompleted count the sops processes
+	// run to decrypt. A growing difference means processes hang.
+	sopsProcessesStarted = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "sopssecret_sops_processes_started_total",
+		Help: "Total number of sops processes started to decrypt a document.",
+	})
+	sopsProcessesCompleted = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "sopssecret_sops_processes_completed_total",
+		Help: "Total number of sops processes that exited, successfully or not.",
+	})
+)
+
+func init() {
+	metrics.Registry.MustRegister(cacheEntries, cacheBytes, cacheEvictions, decryptDuration, fallbackDecrypts,
+		activeDecrypts, tempFilesCreated, tempFilesRemoved, sopsProcessesStarted, sopsProcessesCompleted)
+}
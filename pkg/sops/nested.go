@@ -0,0 +1,73 @@
+package sops
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// NestedLevelError is returned when a sops document stored as a value is
+// found below the number of levels DecryptNested may decrypt.
+type NestedLevelError struct {
+	// Key is the top-level key holding the document that was not decrypted.
+	Key string
+	// MaxLevels is the configured limit.
+	MaxLevels int
+}
+
+func (e *NestedLevelError) Error() string {
+	return fmt.Sprintf("value of key %s is a sops document nested more than %d levels deep", e.Key, e.MaxLevels)
+}
+
+// DecryptNested decrypts top-level string values that are sops documents
+// themselves with dec and replaces them with the decrypted document. The
+// decrypted documents are searched the same way, up to maxLevels levels
+// below decrypted. A sops document found deeper returns a NestedLevelError,
+// which also ends documents that contain themselves.
+func DecryptNested(ctx context.Context, dec DecryptorInterface, decrypted *DecryptedData, maxLevels int) (*DecryptedData, error) {
+	return decryptNested(ctx, dec, decrypted, 1, maxLevels)
+}
+
+func decryptNested(ctx context.Context, dec DecryptorInterface, decrypted *DecryptedData, level, maxLevels int) (*DecryptedData, error) {
+	result := &DecryptedData{
+		Data:       make(map[string][]byte, len(decrypted.Data)),
+		StringData: make(map[string]string, len(decrypted.Data)),
+		KeyOrder:   decrypted.KeyOrder,
+	}
+	for key, wrapped := range decrypted.Data {
+		result.Data[key] = wrapped
+		result.StringData[key] = string(wrapped)
+
+		// Top-level values are stored wrapped under their key, see parseDecryptedYAML
+		var raw map[string]any
+		if err := yaml.Unmarshal(wrapped, &raw); err != nil {
+			continue
+		}
+		value, ok := raw[key].(string)
+		if !ok || ValidateEncryptedYAML([]byte(value)) != nil {
+			continue
+		}
+		if level > maxLevels {
+			return nil, &NestedLevelError{Key: key, MaxLevels: maxLevels}
+		}
+
+		inner, err := dec.DecryptWithContext(ctx, []byte(value))
+		if err != nil {
+			return nil, fmt.Errorf("failed to decrypt the sops document in key %s: %w", key, err)
+		}
+		inner, err = decryptNested(ctx, dec, inner, level+1, maxLevels)
+		if err != nil {
+			return nil, err
+		}
+		inlined, err := defaultYAMLMarshaler(map[string]any{key: string(inner.Document())})
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal value for key %s: %w", key, err)
+		}
+		inlined = bytes.TrimSuffix(inlined, []byte("\n"))
+		result.Data[key] = inlined
+		result.StringData[key] = string(inlined)
+	}
+	return result, nil
+}
@@ -0,0 +1,113 @@
+package sops
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+// stripSopsRunner "decrypts" a document by dropping its sops block, which
+// leaves nested documents stored as values untouched.
+func stripSopsRunner(_ context.Context, _ string, _ []string, _ []string, input []byte) ([]byte, error) {
+	var doc map[string]any
+	if err := yaml.Unmarshal(input, &doc); err != nil {
+		return nil, err
+	}
+	delete(doc, "sops")
+	return yaml.Marshal(doc)
+}
+
+// nestDocument returns a sops document holding inner as the value of key.
+func nestDocument(key, inner string) string {
+	value, _ := yaml.Marshal(map[string]string{key: inner})
+	return string(value) + "sops:\n    mac: test\n"
+}
+
+func TestDecryptNested(t *testing.T) {
+	dec := NewDecryptor(nil, withCommandRunner(stripSopsRunner))
+	ctx := context.Background()
+
+	inner := "password: secret\nsops:\n    mac: test\n"
+	outer, err := dec.DecryptWithContext(ctx, []byte(nestDocument("database", inner)+"user: admin\n"))
+	if err != nil {
+		t.Fatalf("DecryptWithContext() error = %v", err)
+	}
+	got, err := DecryptNested(ctx, dec, outer, 1)
+	if err != nil {
+		t.Fatalf("DecryptNested() error = %v", err)
+	}
+	if want := "database: |\n    password: secret"; string(got.Data["database"]) != want {
+		t.Errorf("Data[database] = %q, want %q", got.Data["database"], want)
+	}
+	if got.StringData["database"] != string(got.Data["database"]) {
+		t.Errorf("StringData[database] = %q, want it to match Data", got.StringData["database"])
+	}
+	if string(got.Data["user"]) != "user: admin" {
+		t.Errorf("Data[user] = %q, want it unchanged", got.Data["user"])
+	}
+	if !strings.Contains(string(outer.Data["database"]), "mac: test") {
+		t.Error("DecryptNested() changed its input")
+	}
+}
+
+func TestDecryptNestedLevels(t *testing.T) {
+	dec := NewDecryptor(nil, withCommandRunner(stripSopsRunner))
+	ctx := context.Background()
+
+	// Three levels of documents below the outer one
+	doc := "password: secret\nsops:\n    mac: test\n"
+	for _, key := range []string{"third", "second", "first"} {
+		doc = nestDocument(key, doc)
+	}
+	outer, err := dec.DecryptWithContext(ctx, []byte(doc))
+	if err != nil {
+		t.Fatalf("DecryptWithContext() error = %v", err)
+	}
+
+	got, err := DecryptNested(ctx, dec, outer, 3)
+	if err != nil {
+		t.Fatalf("DecryptNested() with 3 levels error = %v", err)
+	}
+	if !strings.Contains(string(got.Data["first"]), "password: secret") {
+		t.Errorf("Data[first] = %q, want the innermost document decrypted", got.Data["first"])
+	}
+
+	_, err = DecryptNested(ctx, dec, outer, 2)
+	var levelErr *NestedLevelError
+	if !errors.As(err, &levelErr) {
+		t.Fatalf("DecryptNested() with 2 levels error = %v, want a NestedLevelError", err)
+	}
+	if levelErr.Key != "third" || levelErr.MaxLevels != 2 {
+		t.Errorf("NestedLevelError = %+v, want key third and 2 levels", levelErr)
+	}
+}
+
+func TestDecryptNestedIgnoresOtherValues(t *testing.T) {
+	dec := NewDecryptor(nil, withCommandRunner(func(context.Context, string, []string, []string, []byte) ([]byte, error) {
+		return nil, errors.New("unexpected decrypt")
+	}))
+	decrypted, err := parseDecryptedYAML([]byte(`plain: value
+yaml: "a: b\n"
+nosops: "password: x\nother:\n  mac: y\n"
+nomac: "password: x\nsops:\n  version: 3.9.0\n"
+nested:
+  sops:
+    mac: test
+`))
+	if err != nil {
+		t.Fatalf("parseDecryptedYAML() error = %v", err)
+	}
+
+	got, err := DecryptNested(context.Background(), dec, decrypted, 3)
+	if err != nil {
+		t.Fatalf("DecryptNested() error = %v", err)
+	}
+	for key, value := range decrypted.Data {
+		if string(got.Data[key]) != string(value) {
+			t.Errorf("Data[%s] = %q, want %q", key, got.Data[key], value)
+		}
+	}
+}
@@ -0,0 +1,35 @@
+package sops
+
+import (
+	"gopkg.in/yaml.v3"
+)
+
+// OmitNullValues drops keys whose decrypted value is an explicit null, so a
+// document can remove a key from the Secret with `key: null`. Empty strings
+// are kept as empty values.
+func OmitNullValues(decrypted *DecryptedData) *DecryptedData {
+	result := &DecryptedData{
+		Data:       make(map[string][]byte, len(decrypted.Data)),
+		StringData: make(map[string]string, len(decrypted.Data)),
+		KeyOrder:   decrypted.KeyOrder,
+	}
+	for key, wrapped := range decrypted.Data {
+		if isNullValue(key, wrapped) {
+			continue
+		}
+		result.Data[key] = wrapped
+		result.StringData[key] = string(wrapped)
+	}
+	return result
+}
+
+// isNullValue reports whether the wrapped value stored for key is null.
+func isNullValue(key string, wrapped []byte) bool {
+	// Top-level values are stored wrapped under their key, see parseDecryptedYAML
+	var raw map[string]any
+	if err := yaml.Unmarshal(wrapped, &raw); err != nil {
+		return false
+	}
+	value, ok := raw[key]
+	return ok && value == nil
+}
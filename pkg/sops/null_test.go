@@ -0,0 +1,33 @@
+package sops
+
+import (
+	"testing"
+)
+
+func TestOmitNullValues(t *testing.T) {
+	flat, err := parseDecryptedYAML([]byte(`removed: null
+tilde: ~
+empty: ""
+kept: value
+`))
+	if err != nil {
+		t.Fatalf("parseDecryptedYAML() error = %v", err)
+	}
+
+	got := OmitNullValues(flat)
+
+	for _, key := range []string{"removed", "tilde"} {
+		if _, ok := got.Data[key]; ok {
+			t.Errorf("OmitNullValues() kept null key %q", key)
+		}
+		if _, ok := got.StringData[key]; ok {
+			t.Errorf("OmitNullValues() kept null key %q in StringData", key)
+		}
+	}
+	if string(got.Data["empty"]) != `empty: ""` {
+		t.Errorf("OmitNullValues() Data[empty] = %q, want the empty string kept", got.Data["empty"])
+	}
+	if string(got.Data["kept"]) != "kept: value" {
+		t.Errorf("OmitNullValues() Data[kept] = %q", got.Data["kept"])
+	}
+}
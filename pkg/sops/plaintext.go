@@ -0,0 +1,21 @@
+package sops
+
+import (
+	"gopkg.in/yaml.v3"
+)
+
+// HasSopsMetadata reports whether the document has a top-level sops block.
+func HasSopsMetadata(document []byte) bool {
+	var doc map[string]any
+	if err := yaml.Unmarshal(document, &doc); err != nil {
+		return false
+	}
+	_, ok := doc["sops"]
+	return ok
+}
+
+// ParsePlaintext parses a document that is not encrypted into the same form
+// Decrypt returns, with every top-level key YAML-wrapped.
+func ParsePlaintext(document []byte) (*DecryptedData, error) {
+	return parseDecryptedYAML(document)
+}
@@ -0,0 +1,36 @@
+package sops
+
+import (
+	"testing"
+)
+
+func TestHasSopsMetadata(t *testing.T) {
+	tests := []struct {
+		name     string
+		document string
+		want     bool
+	}{
+		{name: "encrypted", document: "token: ENC[test]\nsops:\n    mac: ENC[test]\n", want: true},
+		{name: "plaintext", document: "token: abc\n", want: false},
+		{name: "nested sops key", document: "config:\n    sops: true\n", want: false},
+		{name: "invalid yaml", document: "token: [", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := HasSopsMetadata([]byte(tt.document)); got != tt.want {
+				t.Errorf("HasSopsMetadata() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParsePlaintext(t *testing.T) {
+	got, err := ParsePlaintext([]byte("token: abc\n"))
+	if err != nil {
+		t.Fatalf("ParsePlaintext() error = %v", err)
+	}
+	if string(got.Data["token"]) != "token: abc" {
+		t.Errorf("ParsePlaintext() token = %q, want YAML-wrapped value", got.Data["token"])
+	}
+}
@@ -0,0 +1,23 @@
+package sops
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// SelfTest decrypts a known fixture to confirm that the configured keys, the
+// sops binary and its environment work together. The decrypted data is discarded.
+func SelfTest(ctx context.Context, d DecryptorInterface, fixture []byte) error {
+	if err := ValidateEncryptedYAML(fixture); err != nil {
+		return fmt.Errorf("invalid self-test fixture: %w", err)
+	}
+	data, err := d.DecryptWithContext(ctx, fixture)
+	if err != nil {
+		return fmt.Errorf("self-test decryption failed: %w", err)
+	}
+	if len(data.Data) == 0 {
+		return errors.New("self-test fixture decrypted to no data")
+	}
+	return nil
+}
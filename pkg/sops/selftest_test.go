@@ -0,0 +1,65 @@
+package sops
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestSelfTest(t *testing.T) {
+	fixture := []byte(`check: ENC[AES256_GCM,data:test]
+sops:
+    mac: test
+`)
+
+	tests := []struct {
+		name    string
+		fixture []byte
+		output  []byte
+		runErr  error
+		wantErr string
+	}{
+		{
+			name:    "success",
+			fixture: fixture,
+			output:  []byte("check: ok"),
+		},
+		{
+			name:    "sops failure",
+			fixture: fixture,
+			runErr:  errors.New("no matching keys"),
+			wantErr: "self-test decryption failed",
+		},
+		{
+			name:    "empty result",
+			fixture: fixture,
+			output:  []byte("{}"),
+			wantErr: "decrypted to no data",
+		},
+		{
+			name:    "invalid fixture",
+			fixture: []byte("check: plain"),
+			wantErr: "invalid self-test fixture",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runner := func(ctx context.Context, name string, args []string, env []string, input []byte) ([]byte, error) {
+				return tt.output, tt.runErr
+			}
+			d := NewDecryptor([]string{"AGE-SECRET-KEY-TEST"}, withCommandRunner(runner))
+
+			err := SelfTest(context.Background(), d, tt.fixture)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("SelfTest() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !containsString(err.Error(), tt.wantErr) {
+				t.Errorf("SelfTest() error = %v, want error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package sops
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+
+	"github.com/go-logr/logr"
+)
+
+// tempFileSystem wipes and removes the temp files sops reads from.
+// This interface allows for mocking in tests.
+type tempFileSystem interface {
+	Overwrite(name string, size int) error
+	Remove(name string) error
+	Stat(name string) (fs.FileInfo, error)
+}
+
+// osTempFileSystem is the tempFileSystem backed by the os package.
+type osTempFileSystem struct{}
+
+// Overwrite replaces the first size bytes of the file with zeros in place
+// and syncs them to disk. Best effort, filesystems may keep older copies.
+func (osTempFileSystem) Overwrite(name string, size int) error {
+	f, err := os.OpenFile(name, os.O_WRONLY, 0)
+	if err != nil {
+		return err
+	}
+	if _, err := f.WriteAt(make([]byte, size), 0); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func (osTempFileSystem) Remove(name string) error {
+	return os.Remove(name)
+}
+
+func (osTempFileSystem) Stat(name string) (fs.FileInfo, error) {
+	return os.Stat(name)
+}
+
+// WithSecureTempWipe overwrites the temp file holding the encrypted document
+// with zeros before it is removed. It only applies with WithTempFile.
+func WithSecureTempWipe() Option {
+	return func(dec *Decryptor) {
+		dec.secureTempWipe = true
+	}
+}
+
+// withTempFileSystem is used internally for testing.
+func withTempFileSystem(fsys tempFileSystem) Option {
+	return func(dec *Decryptor) {
+		dec.tempFS = fsys
+	}
+}
+
+// removeTempFile deletes a temp file of size bytes, wiping it first with
+// WithSecureTempWipe. If the file is still there afterwards, for example on
+// a read-only tmpfs, an error is logged, since the ciphertext would linger,
+// and it is not counted as removed.
+func (d *Decryptor) removeTempFile(ctx context.Context, name string, size int) {
+	log := logr.FromContextOrDiscard(ctx)
+	fsys := d.tempFS
+	if fsys == nil {
+		fsys = osTempFileSystem{}
+	}
+	if d.secureTempWipe {
+		if err := fsys.Overwrite(name, size); err != nil {
+			log.Error(err, "Failed to wipe temp file before removal", "path", name)
+		}
+	}
+	removeErr := fsys.Remove(name)
+	if _, err := fsys.Stat(name); !errors.Is(err, fs.ErrNotExist) {
+		log.Error(removeErr, "Temp file with encrypted data still exists after removal", "path", name)
+		return
+	}
+	tempFilesRemoved.Inc()
+}
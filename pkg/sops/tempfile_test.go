@@ -0,0 +1,168 @@
+package sops
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/go-logr/logr/funcr"
+)
+
+// recordingTempFS records the operations on the real filesystem and lets
+// Remove be failed.
+type recordingTempFS struct {
+	osTempFileSystem
+	wiped     []byte
+	removeErr error
+}
+
+func (f *recordingTempFS) Overwrite(name string, size int) error {
+	if err := f.osTempFileSystem.Overwrite(name, size); err != nil {
+		return err
+	}
+	f.wiped, _ = os.ReadFile(name)
+	return nil
+}
+
+func (f *recordingTempFS) Remove(name string) error {
+	if f.removeErr != nil {
+		return f.removeErr
+	}
+	return f.osTempFileSystem.Remove(name)
+}
+
+// decryptWithTempFS decrypts a document with fsys and returns the temp file
+// path and the log output.
+func decryptWithTempFS(t *testing.T, fsys tempFileSystem, opts ...Option) (string, string) {
+	t.Helper()
+	var tmpPath string
+	runner := func(ctx context.Context, name string, args []string, env []string, input []byte) ([]byte, error) {
+		tmpPath = args[len(args)-1]
+		return []byte("key: value"), nil
+	}
+
+	var logged strings.Builder
+	ctx := logr.NewContext(context.Background(), funcr.New(func(prefix, args string) {
+		logged.WriteString(args + "\n")
+	}, funcr.Options{}))
+
+	opts = append(opts, WithTempFile(), withCommandRunner(runner), withTempFileSystem(fsys))
+	d := NewDecryptor([]string{"test-key"}, opts...)
+	if _, err := d.DecryptWithContext(ctx, []byte("key: ENC[test]")); err != nil {
+		t.Fatalf("DecryptWithContext() error = %v", err)
+	}
+	return tmpPath, logged.String()
+}
+
+func TestSecureTempWipe(t *testing.T) {
+	fsys := &recordingTempFS{}
+	tmpPath, logged := decryptWithTempFS(t, fsys, WithSecureTempWipe())
+
+	if want := make([]byte, len("key: ENC[test]")); !bytes.Equal(fsys.wiped, want) {
+		t.Errorf("temp file after wipe = %q, want %d zero bytes", fsys.wiped, len(want))
+	}
+	if _, err := os.Stat(tmpPath); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("temp file %s still exists, stat error = %v", tmpPath, err)
+	}
+	if logged != "" {
+		t.Errorf("unexpected log output: %s", logged)
+	}
+}
+
+func TestTempFileNotWipedByDefault(t *testing.T) {
+	fsys := &recordingTempFS{}
+	tmpPath, _ := decryptWithTempFS(t, fsys)
+
+	if fsys.wiped != nil {
+		t.Errorf("temp file was wiped without WithSecureTempWipe")
+	}
+	if _, err := os.Stat(tmpPath); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("temp file %s still exists, stat error = %v", tmpPath, err)
+	}
+}
+
+func TestTempFileRemovalFailureLogged(t *testing.T) {
+	fsys := &recordingTempFS{removeErr: errors.New("read-only file system")}
+	tmpPath, logged := decryptWithTempFS(t, fsys)
+	t.Cleanup(func() { _ = os.Remove(tmpPath) })
+
+	if !containsString(logged, "still exists after removal") || !containsString(logged, "read-only file system") {
+		t.Errorf("log output = %q, want warning about the remaining temp file", logged)
+	}
+	if !containsString(logged, tmpPath) {
+		t.Errorf("log output = %q, want temp file path %s", logged, tmpPath)
+	}
+}
+
+func TestDecryptResourceMetrics(t *testing.T) {
+	createdBefore := metricValue(t, tempFilesCreated)
+	removedBefore := metricValue(t, tempFilesRemoved)
+	startedBefore := metricValue(t, sopsProcessesStarted)
+	completedBefore := metricValue(t, sopsProcessesCompleted)
+
+	release := make(chan struct{})
+	running := make(chan struct{})
+	runner := func(ctx context.Context, name string, args []string, env []string, input []byte) ([]byte, error) {
+		running <- struct{}{}
+		<-release
+		if strings.Contains(string(input), "broken") {
+			return nil, errors.New("MAC mismatch")
+		}
+		return []byte("key: value"), nil
+	}
+	d := NewDecryptor([]string{"test-key"}, WithTempFile(), withCommandRunner(runner))
+
+	const decrypts = 5
+	var wg sync.WaitGroup
+	for i := range decrypts {
+		document := "key: ENC[test]"
+		if i%2 == 1 {
+			document = "key: ENC[broken]"
+		}
+		wg.Go(func() {
+			_, _ = d.Decrypt([]byte(document))
+		})
+	}
+	for range decrypts {
+		<-running
+	}
+	if got := metricValue(t, activeDecrypts); got != decrypts {
+		t.Errorf("active decrypts while sops runs = %v, want %d", got, decrypts)
+	}
+	close(release)
+	wg.Wait()
+
+	if got := metricValue(t, activeDecrypts); got != 0 {
+		t.Errorf("active decrypts after the batch = %v, want 0", got)
+	}
+	created := metricValue(t, tempFilesCreated) - createdBefore
+	removed := metricValue(t, tempFilesRemoved) - removedBefore
+	if created != decrypts || removed != created {
+		t.Errorf("temp files created = %v, removed = %v, want %d each", created, removed, decrypts)
+	}
+	started := metricValue(t, sopsProcessesStarted) - startedBefore
+	completed := metricValue(t, sopsProcessesCompleted) - completedBefore
+	if started != decrypts || completed != started {
+		t.Errorf("sops processes started = %v, completed = %v, want %d each", started, completed, decrypts)
+	}
+}
+
+func TestTempFileNotCountedRemovedWhenLeft(t *testing.T) {
+	removedBefore := metricValue(t, tempFilesRemoved)
+	fsys := &recordingTempFS{removeErr: errors.New("read-only file system")}
+	tmpPath, _ := decryptWithTempFS(t, fsys)
+	t.Cleanup(func() { _ = os.Remove(tmpPath) })
+
+	if got := metricValue(t, tempFilesRemoved) - removedBefore; got != 0 {
+		t.Errorf("temp files removed = %v, want 0 for a file that is still there", got)
+	}
+	if got := metricValue(t, activeDecrypts); got != 0 {
+		t.Errorf("active decrypts = %v, want 0", got)
+	}
+}
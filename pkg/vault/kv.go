@@ -0,0 +1,211 @@
+// Package vault is a minimal client for the HashiCorp Vault KV version 2
+// secrets engine and the Kubernetes auth method.
+package vault
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// DefaultTimeout bounds every request to Vault.
+const DefaultTimeout = 30 * time.Second
+
+// Client talks to one Vault server with a token.
+type Client struct {
+	address    string
+	token      string
+	expiry     time.Time
+	httpClient *http.Client
+}
+
+// Option configures a Client.
+type Option func(*Client)
+
+// WithToken sets the token sent with every request.
+func WithToken(token string) Option {
+	return func(c *Client) {
+		c.token = token
+	}
+}
+
+// WithHTTPClient replaces the HTTP client, for example to trust a private CA.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// NewClient returns a client for the Vault server at address, such as
+// https://vault.example.com:8200.
+func NewClient(address string, opts ...Option) *Client {
+	c := &Client{
+		address:    strings.TrimSuffix(address, "/"),
+		httpClient: &http.Client{Timeout: DefaultTimeout},
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// LoginKubernetes logs in with a service account token through the
+// Kubernetes auth method mounted at mount and uses the returned client token
+// for further requests.
+func (c *Client) LoginKubernetes(ctx context.Context, mount, role, jwt string) error {
+	body := map[string]string{"role": role, "jwt": jwt}
+	var resp struct {
+		Auth *struct {
+			ClientToken   string `json:"client_token"`
+			LeaseDuration int64  `json:"lease_duration"`
+		} `json:"auth"`
+	}
+	start := time.Now()
+	if err := c.do(ctx, http.MethodPost, "auth/"+escapePath(mount)+"/login", body, &resp); err != nil {
+		return fmt.Errorf("kubernetes login as role %s: %w", role, err)
+	}
+	if resp.Auth == nil || resp.Auth.ClientToken == "" {
+		return fmt.Errorf("kubernetes login as role %s: no client token in response", role)
+	}
+	c.token = resp.Auth.ClientToken
+	c.expiry = time.Time{}
+	if resp.Auth.LeaseDuration > 0 {
+		c.expiry = start.Add(time.Duration(resp.Auth.LeaseDuration) * time.Second)
+	}
+	return nil
+}
+
+// TokenExpiry returns when the token from LoginKubernetes expires. It is zero
+// for tokens that do not expire and for tokens set with WithToken.
+func (c *Client) TokenExpiry() time.Time {
+	return c.expiry
+}
+
+// ReadKV returns the latest version of the secret at path in the KV version 2
+// engine mounted at mount. A missing or deleted secret returns nil and no
+// error.
+func (c *Client) ReadKV(ctx context.Context, mount, path string) (map[string]string, error) {
+	var resp struct {
+		Data *struct {
+			Data map[string]string `json:"data"`
+		} `json:"data"`
+	}
+	err := c.do(ctx, http.MethodGet, escapePath(mount)+"/data/"+escapePath(path), nil, &resp)
+	if isNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read %s/%s: %w", mount, path, err)
+	}
+	if resp.Data == nil {
+		return nil, nil
+	}
+	return resp.Data.Data, nil
+}
+
+// WriteKV writes data as a new version of the secret at path in the KV
+// version 2 engine mounted at mount.
+func (c *Client) WriteKV(ctx context.Context, mount, path string, data map[string]string) error {
+	body := map[string]any{"data": data}
+	if err := c.do(ctx, http.MethodPost, escapePath(mount)+"/data/"+escapePath(path), body, nil); err != nil {
+		return fmt.Errorf("write %s/%s: %w", mount, path, err)
+	}
+	return nil
+}
+
+// DeleteKV deletes the secret at path in the KV version 2 engine mounted at
+// mount with all its versions and metadata. A missing secret is not an error.
+func (c *Client) DeleteKV(ctx context.Context, mount, path string) error {
+	err := c.do(ctx, http.MethodDelete, escapePath(mount)+"/metadata/"+escapePath(path), nil, nil)
+	if err != nil && !isNotFound(err) {
+		return fmt.Errorf("delete %s/%s: %w", mount, path, err)
+	}
+	return nil
+}
+
+// ResponseError is a request Vault answered with an error status.
+type ResponseError struct {
+	// StatusCode is the HTTP status of the response.
+	StatusCode int
+	// Errors are the messages Vault returned.
+	Errors []string
+}
+
+func (e *ResponseError) Error() string {
+	if len(e.Errors) == 0 {
+		return fmt.Sprintf("vault returned status %d", e.StatusCode)
+	}
+	return fmt.Sprintf("vault returned status %d: %s", e.StatusCode, strings.Join(e.Errors, "; "))
+}
+
+// IsPermissionDenied reports whether Vault refused a request, for example
+// because the token expired or was revoked.
+func IsPermissionDenied(err error) bool {
+	var respErr *ResponseError
+	return errors.As(err, &respErr) && respErr.StatusCode == http.StatusForbidden
+}
+
+func isNotFound(err error) bool {
+	var respErr *ResponseError
+	return errors.As(err, &respErr) && respErr.StatusCode == http.StatusNotFound
+}
+
+// do sends a request to the API path below /v1/ and decodes the JSON
+// response into out, if set.
+func (c *Client) do(ctx context.Context, method, path string, in, out any) error {
+	var body io.Reader
+	if in != nil {
+		encoded, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(encoded)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.address+"/v1/"+path, body)
+	if err != nil {
+		return err
+	}
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.token != "" {
+		req.Header.Set("X-Vault-Token", c.token)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		respErr := &ResponseError{StatusCode: resp.StatusCode}
+		var errBody struct {
+			Errors []string `json:"errors"`
+		}
+		if json.NewDecoder(resp.Body).Decode(&errBody) == nil {
+			respErr.Errors = errBody.Errors
+		}
+		return respErr
+	}
+	if out == nil || resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// escapePath escapes every segment of a slash-separated path.
+func escapePath(path string) string {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return strings.Join(segments, "/")
+}
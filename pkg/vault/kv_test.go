@@ -0,0 +1,177 @@
+package vault
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeVault is a KV version 2 engine mounted at secret and a Kubernetes auth
+// method mounted at kubernetes, accepting the token "root".
+type fakeVault struct {
+	mu      sync.Mutex
+	secrets map[string]map[string]string
+	writes  int
+}
+
+func newFakeVault(t *testing.T) (*fakeVault, *httptest.Server) {
+	t.Helper()
+	v := &fakeVault{secrets: map[string]map[string]string{}}
+	server := httptest.NewServer(v)
+	t.Cleanup(server.Close)
+	return v, server
+}
+
+func (v *fakeVault) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if r.URL.Path == "/v1/auth/kubernetes/login" {
+		var body struct{ Role, JWT string }
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		if body.Role != "sops" || body.JWT != "service-account-token" {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"errors":["permission denied"]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"auth":{"client_token":"root","lease_duration":3600}}`))
+		return
+	}
+	if r.Header.Get("X-Vault-Token") != "root" {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"errors":["permission denied"]}`))
+		return
+	}
+
+	switch {
+	case strings.HasPrefix(r.URL.Path, "/v1/secret/data/"):
+		path := strings.TrimPrefix(r.URL.Path, "/v1/secret/data/")
+		switch r.Method {
+		case http.MethodGet:
+			data, ok := v.secrets[path]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = w.Write([]byte(`{"errors":[]}`))
+				return
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"data": map[string]any{"data": data}})
+		case http.MethodPost:
+			var body struct {
+				Data map[string]string `json:"data"`
+			}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			v.secrets[path] = body.Data
+			v.writes++
+			_, _ = w.Write([]byte(`{"data":{"version":1}}`))
+		}
+	case strings.HasPrefix(r.URL.Path, "/v1/secret/metadata/") && r.Method == http.MethodDelete:
+		delete(v.secrets, strings.TrimPrefix(r.URL.Path, "/v1/secret/metadata/"))
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestClientKV(t *testing.T) {
+	v, server := newFakeVault(t)
+	c := NewClient(server.URL+"/", WithToken("root"))
+	ctx := context.Background()
+
+	data, err := c.ReadKV(ctx, "secret", "apps/db")
+	if err != nil {
+		t.Fatalf("ReadKV() of a missing secret error = %v", err)
+	}
+	if data != nil {
+		t.Errorf("ReadKV() of a missing secret = %v, want nil", data)
+	}
+
+	want := map[string]string{"password": "secret", "user": "admin"}
+	if err := c.WriteKV(ctx, "secret", "/apps/db", want); err != nil {
+		t.Fatalf("WriteKV() error = %v", err)
+	}
+	if !reflect.DeepEqual(v.secrets["apps/db"], want) {
+		t.Errorf("stored %v, want %v", v.secrets["apps/db"], want)
+	}
+	data, err = c.ReadKV(ctx, "secret", "apps/db")
+	if err != nil {
+		t.Fatalf("ReadKV() error = %v", err)
+	}
+	if !reflect.DeepEqual(data, want) {
+		t.Errorf("ReadKV() = %v, want %v", data, want)
+	}
+
+	if err := c.DeleteKV(ctx, "secret", "apps/db"); err != nil {
+		t.Fatalf("DeleteKV() error = %v", err)
+	}
+	if _, ok := v.secrets["apps/db"]; ok {
+		t.Error("DeleteKV() kept the secret")
+	}
+	if err := c.DeleteKV(ctx, "secret", "apps/db"); err != nil {
+		t.Errorf("DeleteKV() of a missing secret error = %v", err)
+	}
+}
+
+func TestClientPermissionDenied(t *testing.T) {
+	_, server := newFakeVault(t)
+	c := NewClient(server.URL, WithToken("wrong"))
+
+	err := c.WriteKV(context.Background(), "secret", "apps/db", map[string]string{"password": "secret"})
+	var respErr *ResponseError
+	if !errors.As(err, &respErr) {
+		t.Fatalf("WriteKV() error = %v, want a ResponseError", err)
+	}
+	if respErr.StatusCode != http.StatusForbidden {
+		t.Errorf("StatusCode = %d, want %d", respErr.StatusCode, http.StatusForbidden)
+	}
+	if !IsPermissionDenied(err) {
+		t.Error("IsPermissionDenied() = false, want true")
+	}
+	if !strings.Contains(err.Error(), "permission denied") {
+		t.Errorf("error = %q, want the message of Vault", err)
+	}
+}
+
+func TestClientLoginKubernetes(t *testing.T) {
+	v, server := newFakeVault(t)
+	ctx := context.Background()
+
+	c := NewClient(server.URL)
+	if err := c.LoginKubernetes(ctx, "kubernetes", "other", "service-account-token"); err == nil {
+		t.Error("LoginKubernetes() with a wrong role succeeded")
+	}
+	before := time.Now()
+	if err := c.LoginKubernetes(ctx, "kubernetes", "sops", "service-account-token"); err != nil {
+		t.Fatalf("LoginKubernetes() error = %v", err)
+	}
+	if expiry := c.TokenExpiry(); expiry.Before(before.Add(time.Hour)) || expiry.After(time.Now().Add(time.Hour)) {
+		t.Errorf("TokenExpiry() = %v, want an hour after the login", expiry)
+	}
+	if err := c.WriteKV(ctx, "secret", "apps/db", map[string]string{"password": "secret"}); err != nil {
+		t.Fatalf("WriteKV() after login error = %v", err)
+	}
+	if v.writes != 1 {
+		t.Errorf("writes = %d, want 1", v.writes)
+	}
+}
+
+func TestEscapePath(t *testing.T) {
+	tests := map[string]string{
+		"apps/db":        "apps/db",
+		"/apps/db/":      "apps/db",
+		"apps/my db":     "apps/my%20db",
+		"apps/a?b#c":     "apps/a%3Fb%23c",
+		"kubernetes-dev": "kubernetes-dev",
+	}
+	for path, want := range tests {
+		if got := escapePath(path); got != want {
+			t.Errorf("escapePath(%q) = %q, want %q", path, got, want)
+		}
+	}
+}